@@ -19,6 +19,21 @@ type Split interface {
 	GetConfiguration() map[string]string
 }
 
+// SplitWithLocations is implemented by splits that are faster to read on
+// some hosts, e.g., the hosts with the HDFS blocks, or the host with the local file.
+type SplitWithLocations interface {
+	Split
+	GetPreferredHosts() []string
+}
+
+// PreferredHosts returns the hosts to read the split on, if any.
+func PreferredHosts(split Split) []string {
+	if s, ok := split.(SplitWithLocations); ok {
+		return s.GetPreferredHosts()
+	}
+	return nil
+}
+
 // Adater implenets input and output to external systems
 type Adapter interface {
 	LoadConfiguration(config map[string]string)
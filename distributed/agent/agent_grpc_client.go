@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/chrislusf/gleam/distributed/security"
 	"github.com/chrislusf/gleam/pb"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -39,16 +40,17 @@ func (as *AgentServer) doHeartbeat(sleepInterval time.Duration) error {
 
 	log.Printf("Heartbeat to %s", as.Master)
 
+	go as.receiveHeartbeatResponses(stream)
+
 	for {
 		beat := &pb.Heartbeat{
-			Location: &pb.Location{
-				DataCenter: *as.Option.DataCenter,
-				Rack:       *as.Option.Rack,
-				Server:     *as.Option.Host,
-				Port:       int32(*as.Option.Port),
-			},
-			Resource:  as.computeResource,
-			Allocated: as.allocatedResource,
+			Location:         as.location(),
+			Resource:         as.computeResource,
+			Allocated:        as.allocatedResource,
+			QueueAllocations: as.getQueueAllocations(),
+			RunningLeaseIds:  as.runningLeaseIds(),
+			Load:             as.measureLoad(),
+			Draining:         as.isDraining(),
 		}
 		if err := stream.Send(beat); err != nil {
 			log.Printf("%v.Send(%v) = %v", stream, beat, err)
@@ -61,10 +63,99 @@ func (as *AgentServer) doHeartbeat(sleepInterval time.Duration) error {
 
 }
 
+func (as *AgentServer) receiveHeartbeatResponses(stream pb.GleamMaster_SendHeartbeatClient) {
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			return
+		}
+		for _, preemptRequest := range response.GetPreemptRequests() {
+			as.handlePreemptRequest(preemptRequest)
+		}
+		if len(response.GetExpiredLeaseIds()) > 0 {
+			as.killExpiredLeases(response.GetExpiredLeaseIds())
+		}
+	}
+}
+
+// handlePreemptRequest kills the newest restartable executors of the queue
+// until the requested resource is freed up. The drivers see the executors
+// preempted, release their leases, and ask the master for new allocations.
+// Executors which can not restart are never preempted, since killing them
+// fails their flows.
+func (as *AgentServer) handlePreemptRequest(preemptRequest *pb.PreemptRequest) {
+	var freed pb.ComputeResource
+	isInQueue := func(stat *AgentExecutorStatus) bool {
+		return stat.Queue == preemptRequest.GetQueue() && stat.Restartable
+	}
+	for _, stat := range as.localExecutorManager.runningExecutors(isInQueue) {
+		if freed.Covers(*preemptRequest.GetResource()) {
+			break
+		}
+		stat.Lock()
+		process := stat.Process
+		if process != nil {
+			stat.Error = fmt.Sprintf("executor preempted by master for queue %s", preemptRequest.GetQueue())
+			stat.Preempted = true
+			log.Printf("%s, pid %d", stat.Error, process.Pid)
+			freed = freed.Plus(stat.Allocated)
+		}
+		stat.Unlock()
+		if process != nil {
+			killExecutor(process)
+		}
+	}
+}
+
+// killExpiredLeases kills the executors of drivers which failed to renew
+// their leases, usually because the drivers are dead.
+func (as *AgentServer) killExpiredLeases(expiredLeaseIds []uint64) {
+	expired := make(map[uint64]bool)
+	for _, id := range expiredLeaseIds {
+		expired[id] = true
+	}
+	hasExpiredLease := func(stat *AgentExecutorStatus) bool {
+		return expired[stat.LeaseId]
+	}
+	for _, stat := range as.localExecutorManager.runningExecutors(hasExpiredLease) {
+		stat.Lock()
+		process := stat.Process
+		if process != nil {
+			stat.Error = fmt.Sprintf("executor killed: lease %d expired", stat.LeaseId)
+			log.Printf("%s, pid %d", stat.Error, process.Pid)
+		}
+		stat.Unlock()
+		if process != nil {
+			killExecutor(process)
+		}
+	}
+}
+
+func (as *AgentServer) runningLeaseIds() (leaseIds []uint64) {
+	hasLease := func(stat *AgentExecutorStatus) bool {
+		return stat.LeaseId != 0
+	}
+	for _, stat := range as.localExecutorManager.runningExecutors(hasLease) {
+		stat.Lock()
+		leaseIds = append(leaseIds, stat.LeaseId)
+		stat.Unlock()
+	}
+	return
+}
+
+func (as *AgentServer) location() *pb.Location {
+	return &pb.Location{
+		DataCenter: *as.Option.DataCenter,
+		Rack:       *as.Option.Rack,
+		Server:     *as.Option.Host,
+		Port:       int32(*as.Option.Port),
+	}
+}
+
 func (as *AgentServer) getClient() (pb.GleamMasterClient, error) {
 	if as.grpcConection == nil {
 		var err error
-		as.grpcConection, err = grpc.Dial(as.Master, grpc.WithInsecure())
+		as.grpcConection, err = grpc.Dial(as.Master, security.GrpcDialOptions()...)
 		if err != nil {
 			return nil, fmt.Errorf("fail to dial: %v", err)
 		}
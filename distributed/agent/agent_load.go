@@ -0,0 +1,54 @@
+package agent
+
+import (
+	"github.com/chrislusf/gleam/pb"
+)
+
+// cpuSample is the cumulative cpu time from /proc/stat, in clock ticks.
+type cpuSample struct {
+	busy  uint64
+	total uint64
+}
+
+// measureLoad collects the actual usage of the machine for the heartbeat.
+func (as *AgentServer) measureLoad() *pb.AgentLoad {
+	load := &pb.AgentLoad{
+		RunningExecutors: int32(len(as.localExecutorManager.runningExecutors(
+			func(*AgentExecutorStatus) bool { return true }))),
+		ShardBytes: as.storageBackend.StoredBytes(),
+	}
+
+	if sample, err := readCpuSample(); err == nil {
+		if as.lastCpuSample != nil && sample.total > as.lastCpuSample.total {
+			load.CpuUsage = float64(sample.busy-as.lastCpuSample.busy) /
+				float64(sample.total-as.lastCpuSample.total)
+		}
+		as.lastCpuSample = &sample
+	}
+
+	if freeMemoryMb, err := readFreeMemoryMb(); err == nil {
+		load.FreeMemoryMb = freeMemoryMb
+	}
+
+	for _, dir := range as.storageBackend.Dirs() {
+		if freeMb, err := diskFreeMb(dir); err == nil {
+			load.Disks = append(load.Disks, &pb.DiskUsage{Dir: dir, FreeMb: freeMb})
+		}
+	}
+
+	return load
+}
+
+// diskMbFor is the disk space for executors: the configured size,
+// or else the free space of the data directories at start.
+func diskMbFor(configuredMb int64, dirs []string) (diskMb int64) {
+	if configuredMb > 0 {
+		return configuredMb
+	}
+	for _, dir := range dirs {
+		if freeMb, err := diskFreeMb(dir); err == nil {
+			diskMb += freeMb
+		}
+	}
+	return
+}
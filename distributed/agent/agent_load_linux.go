@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+func readCpuSample() (sample cpuSample, err error) {
+	data, err := ioutil.ReadFile("/proc/stat")
+	if err != nil {
+		return sample, err
+	}
+	// cpu  user nice system idle iowait irq softirq steal ...
+	line := strings.SplitN(string(data), "\n", 2)[0]
+	fields := strings.Fields(line)
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return sample, fmt.Errorf("unexpected /proc/stat: %s", line)
+	}
+	// guest time is already counted in user time
+	if len(fields) > 9 {
+		fields = fields[:9]
+	}
+	for i, field := range fields[1:] {
+		ticks, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return sample, err
+		}
+		sample.total += ticks
+		// idle and iowait
+		if i != 3 && i != 4 {
+			sample.busy += ticks
+		}
+	}
+	return sample, nil
+}
+
+func readFreeMemoryMb() (int64, error) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// MemAvailable:    1234567 kB
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "MemAvailable:" {
+			kb, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			return kb / 1024, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("MemAvailable not found in /proc/meminfo")
+}
+
+func diskFreeMb(dir string) (int64, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(dir, &stat); err != nil {
+		return 0, err
+	}
+	return int64(stat.Bavail) * int64(stat.Bsize) / 1024 / 1024, nil
+}
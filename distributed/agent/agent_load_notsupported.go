@@ -0,0 +1,19 @@
+// +build !linux
+
+package agent
+
+import (
+	"fmt"
+)
+
+func readCpuSample() (sample cpuSample, err error) {
+	return sample, fmt.Errorf("cpu usage is only measured on linux")
+}
+
+func readFreeMemoryMb() (int64, error) {
+	return 0, fmt.Errorf("free memory is only measured on linux")
+}
+
+func diskFreeMb(dir string) (int64, error) {
+	return 0, fmt.Errorf("free disk is only measured on linux")
+}
@@ -0,0 +1,76 @@
+package agent
+
+import (
+	"net"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/chrislusf/gleam/distributed/metrics"
+)
+
+// agentCounters are counted since the agent started, and accessed atomically.
+type agentCounters struct {
+	receivedBytes    int64 // dataset shards written to this agent
+	sentBytes        int64 // dataset shards read from this agent
+	executorsStarted int64
+	executorFailures int64
+}
+
+// meteredConn counts the dataset bytes passing through a connection.
+type meteredConn struct {
+	net.Conn
+	received *int64
+	sent     *int64
+}
+
+func (c *meteredConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	atomic.AddInt64(c.received, int64(n))
+	return n, err
+}
+
+func (c *meteredConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	atomic.AddInt64(c.sent, int64(n))
+	return n, err
+}
+
+func (as *AgentServer) meter(conn net.Conn) net.Conn {
+	return &meteredConn{
+		Conn:     conn,
+		received: &as.counters.receivedBytes,
+		sent:     &as.counters.sentBytes,
+	}
+}
+
+func (as *AgentServer) serveHTTP(listener net.Listener) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", metrics.Handler(as.writeMetrics))
+	http.Serve(listener, mux)
+}
+
+func (as *AgentServer) writeMetrics(m *metrics.Writer) {
+	running := as.localExecutorManager.runningExecutors(func(*AgentExecutorStatus) bool { return true })
+	m.Metric("gleam_agent_running_executors", metrics.Gauge, "Number of running executors.", float64(len(running)))
+	m.Metric("gleam_agent_executors_started_total", metrics.Counter, "Number of executors started.",
+		float64(atomic.LoadInt64(&as.counters.executorsStarted)))
+	m.Metric("gleam_agent_executor_failures_total", metrics.Counter, "Number of executors failed to start or exited with errors.",
+		float64(atomic.LoadInt64(&as.counters.executorFailures)))
+
+	m.Header("gleam_agent_dataset_shards", metrics.Gauge, "Number of named dataset shards.")
+	m.Sample("gleam_agent_dataset_shards", float64(len(as.storageBackend.NamedDatasetShards())), "storage", "disk")
+	m.Sample("gleam_agent_dataset_shards", float64(len(as.inMemoryChannels.NamedDatasetShards())), "storage", "memory")
+	m.Header("gleam_agent_dataset_shard_bytes", metrics.Gauge, "Size of the named dataset shards stored on disk.")
+	m.Sample("gleam_agent_dataset_shard_bytes", float64(as.storageBackend.StoredBytes()), "storage", "disk")
+
+	m.Metric("gleam_agent_netchan_received_bytes_total", metrics.Counter, "Bytes of dataset shards written to this agent.",
+		float64(atomic.LoadInt64(&as.counters.receivedBytes)))
+	m.Metric("gleam_agent_netchan_sent_bytes_total", metrics.Counter, "Bytes of dataset shards read from this agent.",
+		float64(atomic.LoadInt64(&as.counters.sentBytes)))
+
+	allocated := as.getAllocated()
+	m.Metric("gleam_agent_cpu_cores", metrics.Gauge, "Cpu cores of the agent.", float64(as.computeResource.CpuCount))
+	m.Metric("gleam_agent_memory_megabytes", metrics.Gauge, "Memory of the agent.", float64(as.computeResource.MemoryMb))
+	m.Metric("gleam_agent_allocated_cpu_cores", metrics.Gauge, "Cpu cores allocated to running executors.", float64(allocated.CpuCount))
+	m.Metric("gleam_agent_allocated_memory_megabytes", metrics.Gauge, "Memory allocated to running executors.", float64(allocated.MemoryMb))
+}
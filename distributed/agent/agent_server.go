@@ -4,15 +4,16 @@ package agent
 
 import (
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/chrislusf/gleam/distributed/security"
 	"github.com/chrislusf/gleam/pb"
 	"github.com/chrislusf/gleam/util"
 	"github.com/golang/protobuf/proto"
@@ -29,8 +30,23 @@ type AgentServerOption struct {
 	Rack         *string
 	MaxExecutor  *int32
 	MemoryMB     *int64
+	DiskMB       *int64
 	CPULevel     *int32
 	CleanRestart *bool
+	TLS          *security.TLSOption
+	TokenFile    *string
+
+	// per executor limits
+	MaxOpenFiles           *int64
+	MaxCPUSeconds          *int64
+	AddressSpaceOverheadMB *int64
+	MemoryLimitRatio       *float64
+	MemoryOverheadMB       *int64
+
+	// executor logs
+	LogMaxSizeMB  *int64
+	LogMaxBackups *int
+	LogRetention  *time.Duration
 }
 
 type AgentServer struct {
@@ -38,42 +54,74 @@ type AgentServer struct {
 	Master                string
 	computeResource       *pb.ComputeResource
 	allocatedResource     *pb.ComputeResource
+	queueAllocated        map[string]pb.ComputeResource
 	allocatedResourceLock sync.Mutex
 	storageBackend        *LocalDatasetShardsManager
 	inMemoryChannels      *LocalDatasetShardsManagerInMemory
 	localExecutorManager  *LocalExecutorManager
+	executorLogs          *ExecutorLogs
+	tokens                *security.TokenStore
+	lastCpuSample         *cpuSample
+	draining              int32 // accessed atomically
+	activeStarts          int32 // start requests being handled, accessed atomically
+	activeReads           int32 // open read connections, accessed atomically
+	counters              agentCounters
 
 	grpcConection *grpc.ClientConn
 }
 
 func RunAgentServer(option *AgentServerOption) {
-	absoluteDir, err := filepath.Abs(util.CleanPath(*option.Dir))
+	var dirs []string
+	for _, dir := range strings.Split(*option.Dir, ",") {
+		if strings.TrimSpace(dir) == "" {
+			continue
+		}
+		absoluteDir, err := filepath.Abs(util.CleanPath(strings.TrimSpace(dir)))
+		if err != nil {
+			panic(err)
+		}
+		dirs = append(dirs, absoluteDir)
+	}
+	if len(dirs) == 0 {
+		log.Fatalf("no data directory specified")
+	}
+	joinedDirs := strings.Join(dirs, ",")
+	println("starting in", joinedDirs)
+	option.Dir = &joinedDirs
+
+	tokens, err := security.LoadTokenFile(*option.TokenFile)
 	if err != nil {
-		panic(err)
+		log.Fatalf("Failed to load tokens: %v", err)
+	}
+	if err := tokens.RequireTLS(); err != nil {
+		log.Fatalf("Failed to use token file %s: %v", *option.TokenFile, err)
 	}
-	println("starting in", absoluteDir)
-	option.Dir = &absoluteDir
 
 	as := &AgentServer{
 		Option:           option,
 		Master:           *option.Master,
-		storageBackend:   NewLocalDatasetShardsManager(*option.Dir, int(*option.Port)),
+		storageBackend:   NewLocalDatasetShardsManager(dirs, int(*option.Port)),
 		inMemoryChannels: NewLocalDatasetShardsManagerInMemory(),
 		computeResource: &pb.ComputeResource{
 			CpuCount: int32(*option.MaxExecutor),
 			CpuLevel: int32(*option.CPULevel),
 			MemoryMb: *option.MemoryMB,
+			DiskMb:   diskMbFor(*option.DiskMB, dirs),
 		},
 		allocatedResource:    &pb.ComputeResource{},
+		queueAllocated:       make(map[string]pb.ComputeResource),
 		localExecutorManager: newLocalExecutorsManager(),
+		executorLogs:         NewExecutorLogs(dirs[0], *option.LogMaxSizeMB*1024*1024, *option.LogMaxBackups, *option.LogRetention),
+		tokens:               tokens,
 	}
 
 	go as.storageBackend.purgeExpiredEntries()
 	go as.inMemoryChannels.purgeExpiredEntries()
 	go as.localExecutorManager.purgeExpiredEntries()
+	go as.executorLogs.purgeExpiredEntries()
 	go as.heartbeat()
 
-	listener, err := net.Listen("tcp", fmt.Sprintf("%v:%d", *option.Host, *option.Port))
+	listener, err := security.Listen(fmt.Sprintf("%v:%d", *option.Host, *option.Port))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -81,23 +129,18 @@ func RunAgentServer(option *AgentServerOption) {
 	fmt.Println("AgentServer starts on", fmt.Sprintf("%v:%d", *option.Host, *option.Port))
 
 	if *option.CleanRestart {
-		if fileInfos, err := ioutil.ReadDir(as.storageBackend.dir); err == nil {
-			suffix := fmt.Sprintf("-%d.dat", *option.Port)
-			for _, fi := range fileInfos {
-				name := fi.Name()
-				if !fi.IsDir() && strings.HasSuffix(name, suffix) {
-					// println("removing old dat file:", name)
-					os.Remove(filepath.Join(as.storageBackend.dir, name))
-				}
-			}
-		}
+		as.storageBackend.RemoveStoredShards()
+	} else {
+		as.storageBackend.LoadStoredShards()
 	}
 
 	m := cmux.New(listener)
 	grpcListener := m.Match(cmux.HTTP2HeaderField("content-type", "application/grpc"))
+	httpListener := m.Match(cmux.HTTP1Fast())
 	tcpListener := m.Match(cmux.Any())
 
 	go as.serveGRPC(grpcListener)
+	go as.serveHTTP(httpListener)
 	go as.serveGRPC(tcpListener)
 
 	if err := m.Serve(); !strings.Contains(err.Error(), "use of closed network connection") {
@@ -152,24 +195,49 @@ func (r *AgentServer) handleRequest(conn net.Conn) {
 		conn.Write(data)
 	}
 
+	if drained := reply.GetDrainResponse(); drained != nil && drained.GetError() == "" {
+		conn.Close()
+		log.Printf("Agent is drained. Exiting...")
+		os.Exit(0)
+	}
+
 }
 
 func (as *AgentServer) handleCommandConnection(conn net.Conn,
 	command *pb.ControlMessage) *pb.ControlMessage {
 	reply := &pb.ControlMessage{}
+	if err := as.tokens.Check(command.GetToken(), requiredPermission(command)); err != nil {
+		log.Printf("Denied request from %v: %v", conn.RemoteAddr(), err)
+		if command.GetReadRequest() != nil || command.GetWriteRequest() != nil {
+			// the connection is used for data, just close it
+			return nil
+		}
+		if command.GetStartRequest() != nil {
+			// the output of the start request is treated as errors
+			fmt.Fprintf(conn, "denied: %v\n", err)
+			return nil
+		}
+		reply.Error = fmt.Sprintf("denied: %v", err)
+		return reply
+	}
+	if command.GetReadRequest() != nil || command.GetWriteRequest() != nil {
+		conn = as.meter(conn)
+	}
 	if command.GetReadRequest() != nil {
+		atomic.AddInt32(&as.activeReads, 1)
+		defer atomic.AddInt32(&as.activeReads, -1)
 		if !command.GetIsOnDiskIO() {
-			as.handleInMemoryReadConnection(conn, command.ReadRequest.ReaderName, command.ReadRequest.ChannelName)
+			as.handleInMemoryReadConnection(conn, command.ReadRequest.ReaderName, command.ReadRequest.ChannelName, command.ReadRequest.Compression)
 		} else {
-			as.handleReadConnection(conn, command.ReadRequest.ReaderName, command.ReadRequest.ChannelName)
+			as.handleReadConnection(conn, command.ReadRequest.ReaderName, command.ReadRequest.ChannelName, command.ReadRequest.Compression)
 		}
 		return nil
 	}
 	if command.GetWriteRequest() != nil {
 		if !command.GetIsOnDiskIO() {
-			as.handleLocalInMemoryWriteConnection(conn, command.WriteRequest.WriterName, command.WriteRequest.ChannelName, int(command.GetWriteRequest().GetReaderCount()))
+			as.handleLocalInMemoryWriteConnection(conn, command.WriteRequest.WriterName, command.WriteRequest.ChannelName, int(command.GetWriteRequest().GetReaderCount()), command.WriteRequest.Compression)
 		} else {
-			as.handleLocalWriteConnection(conn, command.WriteRequest.WriterName, command.WriteRequest.ChannelName, int(command.GetWriteRequest().GetReaderCount()))
+			as.handleLocalWriteConnection(conn, command.WriteRequest.WriterName, command.WriteRequest.ChannelName, int(command.GetWriteRequest().GetReaderCount()), command.WriteRequest.Compression)
 		}
 		return nil
 	}
@@ -180,7 +248,7 @@ func (as *AgentServer) handleCommandConnection(conn net.Conn,
 			// println("remote address is", remoteAddress)
 			command.StartRequest.Host = remoteAddress[:strings.LastIndex(remoteAddress, ":")]
 		}
-		reply.StartResponse = as.handleStart(conn, command.StartRequest)
+		reply.StartResponse = as.handleStart(conn, command.StartRequest, command.GetToken())
 		// return nil to avoid writing the response to the connection.
 		// Currently the connection is used for reading outputs
 		return nil
@@ -193,6 +261,26 @@ func (as *AgentServer) handleCommandConnection(conn net.Conn,
 		reply.StopResponse = as.handleStopRequest(command.GetStopRequest())
 	} else if command.GetLocalStatusReportRequest() != nil {
 		reply.LocalStatusReportResponse = as.handleLocalStatusReportRequest(command.GetLocalStatusReportRequest())
+	} else if command.GetDrainRequest() != nil {
+		reply.DrainResponse = as.handleDrainRequest(command.GetDrainRequest())
+	} else if command.GetListDatasetShardsRequest() != nil {
+		reply.ListDatasetShardsResponse = as.handleListDatasetShardsRequest(command.GetListDatasetShardsRequest())
+	} else if command.GetReadLogRequest() != nil {
+		reply.ReadLogResponse = as.handleReadLogRequest(command.GetReadLogRequest())
 	}
 	return reply
 }
+
+func requiredPermission(command *pb.ControlMessage) string {
+	switch {
+	case command.GetReadRequest() != nil, command.GetGetStatusRequest() != nil,
+		command.GetListDatasetShardsRequest() != nil, command.GetReadLogRequest() != nil:
+		return security.PermissionRead
+	case command.GetDeleteDatasetShardRequest() != nil:
+		return security.PermissionDelete
+	case command.GetWriteRequest() != nil, command.GetStartRequest() != nil,
+		command.GetStopRequest() != nil, command.GetLocalStatusReportRequest() != nil:
+		return security.PermissionSubmit
+	}
+	return security.PermissionAdmin
+}
@@ -0,0 +1,208 @@
+package agent
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/chrislusf/gleam/distributed/security"
+	"github.com/chrislusf/gleam/pb"
+	"github.com/chrislusf/gleam/util"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+)
+
+const (
+	drainCheckInterval = time.Second
+
+	// drivers learn the migrated shards with their next progress report
+	migrationNoticeDelay = 10 * time.Second
+)
+
+func (as *AgentServer) isDraining() bool {
+	return atomic.LoadInt32(&as.draining) == 1
+}
+
+// handleDrainRequest takes the agent out of service: the master stops
+// allocating on it, new executors are refused, and the running executors
+// are waited to finish. The dataset shards on disk of the running flows
+// can be copied to other agents, and their drivers are told by the master
+// to read them there. The open readers are waited to finish.
+// The agent exits after the reply if drained successfully, and otherwise
+// stays draining.
+func (as *AgentServer) handleDrainRequest(drainRequest *pb.DrainRequest) *pb.DrainResponse {
+	reply := &pb.DrainResponse{}
+
+	atomic.StoreInt32(&as.draining, 1)
+	log.Printf("Draining agent...")
+
+	var deadline time.Time
+	if drainRequest.GetTimeoutSeconds() > 0 {
+		deadline = time.Now().Add(time.Duration(drainRequest.GetTimeoutSeconds()) * time.Second)
+	}
+
+	client, err := as.getClient()
+	if err != nil {
+		reply.Error = fmt.Sprintf("failed to connect to master %s: %v", as.Master, err)
+		return reply
+	}
+	drained, err := client.DrainAgent(context.Background(), &pb.DrainAgentRequest{
+		Location: as.location(),
+	})
+	if err != nil {
+		reply.Error = fmt.Sprintf("master %s failed to drain the agent: %v", as.Master, err)
+		return reply
+	}
+
+	if err := waitUntilNone("executors", deadline, as.runningExecutorCount); err != nil {
+		reply.Error = err.Error()
+		return reply
+	}
+
+	if drainRequest.GetMigrateShards() {
+		reply.MigratedShards, err = as.migrateShards(drained.GetTargets(), drained.GetRunningFlowHashCodes())
+		if err != nil {
+			reply.Error = err.Error()
+			return reply
+		}
+		if len(reply.MigratedShards) > 0 {
+			if _, err = client.DrainAgent(context.Background(), &pb.DrainAgentRequest{
+				Location:       as.location(),
+				MigratedShards: reply.MigratedShards,
+			}); err != nil {
+				reply.Error = fmt.Sprintf("master %s failed to register the migrated shards: %v", as.Master, err)
+				return reply
+			}
+			// readers starting before their drivers know still come here
+			time.Sleep(migrationNoticeWait(deadline, time.Now()))
+		}
+	}
+
+	if err := waitUntilNone("readers", deadline, as.openReaderCount); err != nil {
+		reply.Error = err.Error()
+	}
+
+	return reply
+}
+
+func (as *AgentServer) runningExecutorCount() int {
+	running := len(as.localExecutorManager.runningExecutors(
+		func(*AgentExecutorStatus) bool { return true }))
+	return running + int(atomic.LoadInt32(&as.activeStarts))
+}
+
+func (as *AgentServer) openReaderCount() int {
+	return int(atomic.LoadInt32(&as.activeReads))
+}
+
+// migrationNoticeWait is the migration notice delay, cut short by the
+// deadline if set, so that the drain still times out in time.
+func migrationNoticeWait(deadline, now time.Time) time.Duration {
+	if deadline.IsZero() {
+		return migrationNoticeDelay
+	}
+	remaining := deadline.Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+	if remaining < migrationNoticeDelay {
+		return remaining
+	}
+	return migrationNoticeDelay
+}
+
+// waitUntilNone waits until count returns 0, or until the deadline if set.
+func waitUntilNone(what string, deadline time.Time, count func() int) error {
+	for {
+		n := count()
+		if n == 0 {
+			return nil
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return fmt.Errorf("%d %s are still running after the drain timeout", n, what)
+		}
+		time.Sleep(drainCheckInterval)
+	}
+}
+
+// migrateShards copies the dataset shards on disk of the running flows to
+// the target agents in turn, and returns the new locations. The shards of
+// other flows are not read any more.
+func (as *AgentServer) migrateShards(targets []*pb.Location, runningFlowHashCodes []uint32) (migrated []*pb.DataLocation, err error) {
+	running := make(map[uint32]bool)
+	for _, hashCode := range runningFlowHashCodes {
+		running[hashCode] = true
+	}
+
+	shards := as.storageBackend.NamedDatasetShards()
+	var names []string
+	for name := range shards {
+		var hashCode uint32
+		if _, err := fmt.Sscanf(name, "f%d-", &hashCode); err == nil && running[hashCode] {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no agents to take over %d dataset shards", len(names))
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		target := targets[i%len(targets)]
+		if err := copyShard(shards[name], name, target.URL()); err != nil {
+			return migrated, fmt.Errorf("failed to copy %s to %s: %v", name, target.URL(), err)
+		}
+		log.Printf("Copied %s to %s", name, target.URL())
+		migrated = append(migrated, &pb.DataLocation{
+			Name:     name,
+			Location: target,
+			OnDisk:   true,
+		})
+	}
+	return migrated, nil
+}
+
+// copyShard writes the stored messages as they are to another agent,
+// which keeps them with the same compression.
+func copyShard(dsStore *dataDirStore, name string, address string) error {
+	conn, err := security.Dial(address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	data, err := proto.Marshal(&pb.ControlMessage{
+		IsOnDiskIO: true,
+		Token:      security.ClientToken(),
+		WriteRequest: &pb.WriteRequest{
+			ChannelName: name,
+			ReaderCount: 1,
+			WriterName:  "drain",
+			Compression: dsStore.compression,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err = util.WriteMessage(conn, data); err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriterSize(conn, util.BUFFER_SIZE)
+	if _, err = forEachStoredMessage(dsStore, name, func(message []byte) error {
+		return util.WriteMessage(writer, message)
+	}); err != nil && err != io.EOF {
+		return err
+	}
+	if err = util.WriteEOFMessage(writer); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,139 @@
+package agent
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+	"github.com/chrislusf/gleam/util"
+)
+
+func TestMigrationNoticeWait(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		deadline time.Time
+		expected time.Duration
+	}{
+		{"no deadline", time.Time{}, migrationNoticeDelay},
+		{"far deadline", now.Add(time.Minute), migrationNoticeDelay},
+		{"near deadline", now.Add(3 * time.Second), 3 * time.Second},
+		{"passed deadline", now.Add(-time.Second), 0},
+	}
+	for _, tt := range tests {
+		if wait := migrationNoticeWait(tt.deadline, now); wait != tt.expected {
+			t.Errorf("%s: expecting %v, got %v", tt.name, tt.expected, wait)
+		}
+	}
+}
+
+// serveTestAgent serves the data connections of the agent on loopback,
+// until the returned function is called.
+func serveTestAgent(t *testing.T, as *AgentServer) (*pb.Location, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	accepting := make(chan struct{})
+	go func() {
+		defer close(accepting)
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer conn.Close()
+				as.handleRequest(conn)
+			}()
+		}
+	}()
+	addr := listener.Addr().(*net.TCPAddr)
+	return &pb.Location{Server: "127.0.0.1", Port: int32(addr.Port)}, func() {
+		listener.Close()
+		<-accepting
+		wg.Wait()
+	}
+}
+
+func TestMigrateShards(t *testing.T) {
+	source := &AgentServer{storageBackend: NewLocalDatasetShardsManager([]string{t.TempDir()}, 45326)}
+	target := &AgentServer{storageBackend: NewLocalDatasetShardsManager([]string{t.TempDir()}, 45327)}
+	location, stop := serveTestAgent(t, target)
+
+	shards := map[string][]string{
+		"f7-d1-s0": {"a", "bb"},
+		"f7-d1-s1": {"ccc"},
+		"f8-d1-s0": {"finished flow"},
+	}
+	for name, messages := range shards {
+		dsStore := source.storageBackend.CreateNamedDatasetShard(name, util.CompressionSnappy)
+		for _, message := range messages {
+			if err := util.WriteMessage(dsStore, []byte(message)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		util.WriteEOFMessage(dsStore)
+	}
+
+	migrated, err := source.migrateShards([]*pb.Location{location}, []uint32{7})
+	stop()
+	if err != nil {
+		t.Fatalf("migrate shards: %v", err)
+	}
+	if len(migrated) != 2 || migrated[0].Name != "f7-d1-s0" || migrated[1].Name != "f7-d1-s1" {
+		t.Fatalf("expecting the shards of the running flow migrated, got %v", migrated)
+	}
+	for _, dataLocation := range migrated {
+		if dataLocation.Location.URL() != location.URL() || !dataLocation.OnDisk {
+			t.Errorf("expecting %s on disk at %s, got %v", dataLocation.Name, location.URL(), dataLocation)
+		}
+	}
+
+	copied := target.storageBackend.NamedDatasetShards()
+	if _, found := copied["f8-d1-s0"]; found || len(copied) != 2 {
+		t.Errorf("expecting only the shards of the running flow copied, got %v", copied)
+	}
+	for _, name := range []string{"f7-d1-s0", "f7-d1-s1"} {
+		dsStore, found := copied[name]
+		if !found {
+			t.Errorf("expecting %s copied", name)
+			continue
+		}
+		if dsStore.compression != util.CompressionSnappy {
+			t.Errorf("expecting %s kept with snappy, got %q", name, dsStore.compression)
+		}
+		var messages []string
+		forEachStoredMessage(dsStore, name, func(message []byte) error {
+			messages = append(messages, string(message))
+			return nil
+		})
+		if len(messages) != len(shards[name]) {
+			t.Errorf("expecting %s messages %v, got %v", name, shards[name], messages)
+			continue
+		}
+		for i, message := range messages {
+			if message != shards[name][i] {
+				t.Errorf("expecting %s messages %v, got %v", name, shards[name], messages)
+				break
+			}
+		}
+	}
+}
+
+func TestMigrateShardsWithoutTargets(t *testing.T) {
+	source := &AgentServer{storageBackend: NewLocalDatasetShardsManager([]string{t.TempDir()}, 45326)}
+	source.storageBackend.CreateNamedDatasetShard("f7-d1-s0", util.CompressionNone)
+
+	if migrated, err := source.migrateShards(nil, []uint32{8}); err != nil || len(migrated) != 0 {
+		t.Errorf("expecting nothing to migrate for other flows, got %v: %v", migrated, err)
+	}
+	if _, err := source.migrateShards(nil, []uint32{7}); err == nil {
+		t.Errorf("expecting an error without agents to take over")
+	}
+}
@@ -1,31 +1,51 @@
 package agent
 
 import (
+	"fmt"
+	"io"
 	"log"
 	"net"
 	"os"
 	"os/exec"
 	"path"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/chrislusf/gleam/distributed/rsync"
+	"github.com/chrislusf/gleam/distributed/security"
 	"github.com/chrislusf/gleam/pb"
 	"github.com/golang/protobuf/proto"
 	"github.com/kardianos/osext"
 )
 
+// flow config is visible to executors and scripts as environment variables with this prefix
+const flowConfigEnvPrefix = "GLEAM_CONFIG_"
+
 func (as *AgentServer) handleStart(conn net.Conn,
-	startRequest *pb.StartRequest) *pb.StartResponse {
+	startRequest *pb.StartRequest, token string) *pb.StartResponse {
 
 	// println("starting", startRequest.GetInstructions())
 	reply := &pb.StartResponse{}
-	stat := as.localExecutorManager.getExecutorStatus(startRequest.GetInstructions().FlowHashCode)
+
+	// counted before checking draining, so draining waits for this request
+	atomic.AddInt32(&as.activeStarts, 1)
+	defer atomic.AddInt32(&as.activeStarts, -1)
+	if as.isDraining() {
+		reply.Error = fmt.Sprintf("agent %s:%d is draining", *as.Option.Host, *as.Option.Port)
+		// the output of the start request is treated as errors
+		fmt.Fprintln(conn, reply.Error)
+		return reply
+	}
+
+	stat := as.localExecutorManager.getExecutorStatus(startRequest.GetInstructions().HashCode())
+	stat.Lock()
 	stat.RequestTime = time.Now()
+	stat.Unlock()
 
-	dir := path.Join(*as.Option.Dir, startRequest.GetDir())
+	dir := path.Join(as.storageBackend.FirstHealthyDir(), startRequest.GetDir())
 	os.MkdirAll(dir, 0755)
-	err := rsync.FetchFilesTo(startRequest.GetHost()+":"+strconv.Itoa(int(startRequest.GetPort())), dir)
+	err := rsync.FetchFilesTo(startRequest.GetHost()+":"+strconv.Itoa(int(startRequest.GetPort())), dir, token)
 	if err != nil {
 		log.Printf("Failed to download file: %v", err)
 		reply.Error = err.Error()
@@ -33,11 +53,20 @@ func (as *AgentServer) handleStart(conn net.Conn,
 	}
 
 	allocated := *startRequest.GetResource()
+	stat.Lock()
+	stat.Allocated = allocated
+	stat.Queue = startRequest.GetQueue()
+	stat.LeaseId = startRequest.GetLeaseId()
+	stat.InstructionStats = nil
+	stat.Profiles = nil
+	stat.Restartable = startRequest.GetRestartable()
+	stat.Preempted = false
+	stat.Unlock()
 
-	as.plusAllocated(allocated)
-	defer as.minusAllocated(allocated)
+	as.plusAllocated(stat.Queue, allocated)
+	defer as.minusAllocated(stat.Queue, allocated)
 
-	as.doCommand(conn, startRequest, stat, dir, reply)
+	as.doCommand(conn, startRequest, stat, dir, reply, token)
 
 	return reply
 }
@@ -47,36 +76,60 @@ func (as *AgentServer) doCommand(
 	startRequest *pb.StartRequest,
 	stat *AgentExecutorStatus,
 	dir string,
-	reply *pb.StartResponse) (err error) {
+	reply *pb.StartResponse,
+	token string) (err error) {
 	// start the command
 	executableFullFilename, _ := osext.Executable()
+	stat.Lock()
 	stat.StartTime = time.Now()
-	command := exec.Command(
-		executableFullFilename,
-		"execute",
-		"--note",
-		startRequest.GetName(),
-	)
+	stat.Unlock()
+	// the executor reports its instruction stats back to this agent
+	args := []string{"execute", "--note", startRequest.GetName(), "--agent", as.location().URL()}
+	args = append(args, as.Option.TLS.Args()...)
+	// the executor applies the limits to itself before reading the instructions
+	args = append(args, as.executorLimitsFor(startRequest.GetResource()).Args()...)
+	command := exec.Command(executableFullFilename, args...)
 	stdin, err := command.StdinPipe()
 	if err != nil {
 		log.Printf("Failed to create stdin pipe: %v", err)
 		return
 	}
-	// msg.Env = startRequest.Envs
+	command.Env = append(os.Environ(), startRequest.GetEnvs()...)
+	for _, keyValue := range startRequest.GetConfig() {
+		command.Env = append(command.Env, flowConfigEnvPrefix+keyValue)
+	}
+	if token != "" {
+		// the executor reads and writes datasets on behalf of the driver
+		command.Env = append(command.Env, security.TokenEnvironmentVariable+"="+token)
+	}
 	command.Dir = dir
 	// the "gleam execute" stdout actually should always be empty
 	command.Stdout = conn
-	// the "gleam execute" stderr goes directly back to the driver
+	// the "gleam execute" stderr goes directly back to the driver, and
+	// is kept in the executor log
 	command.Stderr = conn
+	if executorLog, err := as.executorLogs.open(startRequest); err != nil {
+		log.Printf("Failed to open executor log: %v", err)
+	} else {
+		defer executorLog.Close()
+		command.Stderr = io.MultiWriter(conn, executorLog)
+	}
 	err = command.Start()
 	if err != nil {
 		log.Printf("Failed to start command %s under %s: %v",
 			command.Path, command.Dir, err)
 		reply.Error = err.Error()
+		atomic.AddInt64(&as.counters.executorFailures, 1)
 	} else {
+		atomic.AddInt64(&as.counters.executorsStarted, 1)
 		reply.Pid = int32(command.Process.Pid)
+		stopMonitor := make(chan bool)
+		defer close(stopMonitor)
+		go as.monitorExecutor(command.Process, startRequest.GetResource(), stat, conn, stopMonitor)
 	}
+	stat.Lock()
 	stat.Process = command.Process
+	stat.Unlock()
 
 	// send instruction set to executor
 	msgMessageBytes, err := proto.Marshal(startRequest.GetInstructions())
@@ -95,25 +148,54 @@ func (as *AgentServer) doCommand(
 
 	// wait for finish
 	err = command.Wait()
+	stat.Lock()
 	if err != nil {
 		reply.Error = err.Error()
+		if stat.Process != nil {
+			atomic.AddInt64(&as.counters.executorFailures, 1)
+		}
 	}
 	// println("finished", startRequest.GetInstructions().String())
 	stat.StopTime = time.Now()
+	stat.Unlock()
 
 	// log.Printf("Finish command %+v", msg)
 
 	return err
 }
 
-func (as *AgentServer) plusAllocated(allocated pb.ComputeResource) {
+func (as *AgentServer) plusAllocated(queue string, allocated pb.ComputeResource) {
 	as.allocatedResourceLock.Lock()
 	defer as.allocatedResourceLock.Unlock()
 	*as.allocatedResource = as.allocatedResource.Plus(allocated)
+	as.queueAllocated[queue] = as.queueAllocated[queue].Plus(allocated)
 }
 
-func (as *AgentServer) minusAllocated(allocated pb.ComputeResource) {
+func (as *AgentServer) minusAllocated(queue string, allocated pb.ComputeResource) {
 	as.allocatedResourceLock.Lock()
 	defer as.allocatedResourceLock.Unlock()
 	*as.allocatedResource = as.allocatedResource.Minus(allocated)
+	as.queueAllocated[queue] = as.queueAllocated[queue].Minus(allocated)
+	if as.queueAllocated[queue].IsZero() {
+		delete(as.queueAllocated, queue)
+	}
+}
+
+func (as *AgentServer) getAllocated() pb.ComputeResource {
+	as.allocatedResourceLock.Lock()
+	defer as.allocatedResourceLock.Unlock()
+	return *as.allocatedResource
+}
+
+func (as *AgentServer) getQueueAllocations() (queueAllocations []*pb.QueueAllocation) {
+	as.allocatedResourceLock.Lock()
+	defer as.allocatedResourceLock.Unlock()
+	for queue, allocated := range as.queueAllocated {
+		allocated := allocated
+		queueAllocations = append(queueAllocations, &pb.QueueAllocation{
+			Queue:     queue,
+			Allocated: &allocated,
+		})
+	}
+	return
 }
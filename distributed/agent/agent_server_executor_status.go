@@ -10,14 +10,27 @@ import (
 func (as *AgentServer) handleGetStatusRequest(getStatusRequest *pb.GetStatusRequest) *pb.GetStatusResponse {
 	requestId := getStatusRequest.GetStartRequestHash()
 	stat := as.localExecutorManager.getExecutorStatus(requestId)
+	stat.Lock()
+	defer stat.Unlock()
 
 	reply := &pb.GetStatusResponse{
-		StartRequestHash: requestId,
-		InputStatuses:    driver.ToProto(stat.InputChannelStatuses),
-		OutputStatuses:   driver.ToProto(stat.OutputChannelStatuses),
-		RequestTime:      stat.RequestTime.Unix(),
-		StartTime:        stat.StartTime.Unix(),
-		StopTime:         stat.StopTime.Unix(),
+		StartRequestHash:  requestId,
+		InputStatuses:     driver.ToProto(stat.InputChannelStatuses),
+		OutputStatuses:    driver.ToProto(stat.OutputChannelStatuses),
+		RequestTime:       stat.RequestTime.Unix(),
+		StartTime:         stat.StartTime.Unix(),
+		StopTime:          stat.StopTime.Unix(),
+		MemoryMb:          stat.MemoryMb,
+		PeakMemoryMb:      stat.PeakMemoryMb,
+		AllocatedMemoryMb: stat.AllocatedMemoryMb,
+		Error:             stat.Error,
+		InstructionStats:  stat.InstructionStats,
+		Profiles:          stat.Profiles,
+		Preempted:         stat.Preempted,
+	}
+	// the profiles can be large, and are collected once by the driver
+	if getStatusRequest.GetClearProfiles() {
+		stat.Profiles = nil
 	}
 
 	return reply
@@ -26,9 +39,16 @@ func (as *AgentServer) handleGetStatusRequest(getStatusRequest *pb.GetStatusRequ
 func (as *AgentServer) handleLocalStatusReportRequest(localStatusRequest *pb.LocalStatusReportRequest) *pb.LocalStatusReportResponse {
 	requestId := localStatusRequest.GetStartRequestHash()
 	stat := as.localExecutorManager.getExecutorStatus(requestId)
+	stat.Lock()
+	defer stat.Unlock()
 
 	stat.InputChannelStatuses = driver.FromProto(localStatusRequest.GetInputStatuses())
 	stat.OutputChannelStatuses = driver.FromProto(localStatusRequest.GetOutputStatuses())
+	stat.InstructionStats = localStatusRequest.GetInstructionStats()
+	stat.Profiles = localStatusRequest.GetProfiles()
+	if localStatusRequest.GetError() != "" {
+		stat.Error = localStatusRequest.GetError()
+	}
 	stat.LastAccessTime = time.Now()
 
 	reply := &pb.LocalStatusReportResponse{}
@@ -39,6 +59,8 @@ func (as *AgentServer) handleLocalStatusReportRequest(localStatusRequest *pb.Loc
 func (as *AgentServer) handleStopRequest(stopRequest *pb.StopRequest) *pb.StopResponse {
 	requestId := stopRequest.GetStartRequestHash()
 	stat := as.localExecutorManager.getExecutorStatus(requestId)
+	stat.Lock()
+	defer stat.Unlock()
 
 	if stat.Process != nil {
 		stat.Process.Kill()
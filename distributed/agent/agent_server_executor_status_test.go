@@ -0,0 +1,30 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+func TestGetStatusClearsProfilesOnlyWhenCollected(t *testing.T) {
+	as := &AgentServer{localExecutorManager: newLocalExecutorsManager()}
+	as.handleLocalStatusReportRequest(&pb.LocalStatusReportRequest{
+		StartRequestHash: 7,
+		Profiles:         []*pb.Profile{{}},
+	})
+
+	reply := as.handleGetStatusRequest(&pb.GetStatusRequest{StartRequestHash: 7})
+	if len(reply.GetProfiles()) != 1 {
+		t.Fatalf("status read returned %d profiles, want 1", len(reply.GetProfiles()))
+	}
+
+	reply = as.handleGetStatusRequest(&pb.GetStatusRequest{StartRequestHash: 7, ClearProfiles: true})
+	if len(reply.GetProfiles()) != 1 {
+		t.Fatalf("collect read returned %d profiles, want 1", len(reply.GetProfiles()))
+	}
+
+	reply = as.handleGetStatusRequest(&pb.GetStatusRequest{StartRequestHash: 7})
+	if len(reply.GetProfiles()) != 0 {
+		t.Errorf("status read after collect returned %d profiles, want 0", len(reply.GetProfiles()))
+	}
+}
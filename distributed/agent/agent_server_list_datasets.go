@@ -0,0 +1,53 @@
+package agent
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+// handleListDatasetShardsRequest lists the named dataset shards stored on disk
+// and the ones being passed in memory.
+func (as *AgentServer) handleListDatasetShardsRequest(listRequest *pb.ListDatasetShardsRequest) *pb.ListDatasetShardsResponse {
+	reply := &pb.ListDatasetShardsResponse{}
+
+	for name, s := range as.storageBackend.NamedDatasetShards() {
+		reply.Shards = append(reply.Shards, &pb.DatasetShardStatus{
+			Name:          name,
+			OnDisk:        true,
+			Size:          atomic.LoadInt64(&s.size),
+			Compression:   s.compression,
+			CreateTime:    unixTime(s.createdAt),
+			LastWriteTime: unixTime(s.LastWriteAt()),
+			LastReadTime:  unixTime(s.LastReadAt()),
+		})
+	}
+
+	for name, tc := range as.inMemoryChannels.NamedDatasetShards() {
+		reply.Shards = append(reply.Shards, &pb.DatasetShardStatus{
+			Name:          name,
+			Compression:   tc.compression,
+			CreateTime:    unixTime(tc.createdAt),
+			LastWriteTime: unixTime(tc.lastWriteAt),
+		})
+	}
+
+	sort.Sort(byShardName(reply.Shards))
+
+	return reply
+}
+
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+type byShardName []*pb.DatasetShardStatus
+
+func (s byShardName) Len() int           { return len(s) }
+func (s byShardName) Less(i, j int) bool { return s[i].Name < s[j].Name }
+func (s byShardName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
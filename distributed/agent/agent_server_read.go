@@ -10,7 +10,7 @@ import (
 	"github.com/chrislusf/gleam/util"
 )
 
-func (as *AgentServer) handleReadConnection(conn net.Conn, readerName, channelName string) {
+func (as *AgentServer) handleReadConnection(conn net.Conn, readerName, channelName string, compression string) {
 
 	log.Println("on disk", readerName, "waits for", channelName)
 
@@ -18,15 +18,33 @@ func (as *AgentServer) handleReadConnection(conn net.Conn, readerName, channelNa
 
 	log.Println("on disk", readerName, "starts reading", channelName)
 
+	var writer io.Writer = conn
+	if dsStore.compression != compression {
+		transcoder := util.NewTranscodingWriter(dsStore.compression, compression, conn)
+		defer transcoder.Close()
+		writer = transcoder
+	}
+
+	count, err := forEachStoredMessage(dsStore, channelName, func(message []byte) error {
+		err := util.WriteMessage(writer, message)
+		if err != nil {
+			log.Printf("Write %s to %s failed: %v", channelName, readerName, err)
+		}
+		return err
+	})
+
+	log.Println("on disk", readerName, "finish reading", channelName, "byte:", count, "err:", err)
+}
+
+// forEachStoredMessage reads the messages of a dataset shard stored on disk,
+// until the EOF message, or until fn returns an error.
+func forEachStoredMessage(dsStore *dataDirStore, channelName string, fn func(message []byte) error) (count int64, err error) {
 	var offset int64
-	var err error
 
 	var size int32
 	sizeBuf := make([]byte, 4)
 	sizeReader := bytes.NewReader(sizeBuf)
 
-	var count int64
-
 	// loop for every read
 	for {
 		_, err = dsStore.ReadAt(sizeBuf, offset)
@@ -36,14 +54,14 @@ func (as *AgentServer) handleReadConnection(conn net.Conn, readerName, channelNa
 				log.Printf("Read size from %s offset %d: %v", channelName, offset, err)
 			}
 			// println("got problem reading", channelName, offset, err.Error())
-			break
+			return
 		}
 
 		sizeReader.Reset(sizeBuf)
 		binary.Read(sizeReader, binary.LittleEndian, &size)
 		if size < 0 {
 			// size == -1 means EOF
-			break
+			return
 		}
 
 		// println("reading", channelName, offset, "size:", size)
@@ -56,19 +74,15 @@ func (as *AgentServer) handleReadConnection(conn net.Conn, readerName, channelNa
 			if err != io.EOF {
 				log.Printf("Read data from %s offset %d: %v", channelName, offset, err)
 			}
-			break
+			return
 		}
 		offset += int64(size)
 
-		err = util.WriteMessage(conn, messageBytes)
-		if err != nil {
-			log.Printf("Write %s to %s failed at %d: %v", channelName, readerName, offset, err)
-			break
+		if err = fn(messageBytes); err != nil {
+			return
 		}
 
 		count += int64(size)
 
 	}
-
-	log.Println("on disk", readerName, "finish reading", channelName, "byte:", count, "err:", err)
 }
@@ -9,11 +9,11 @@ import (
 	"github.com/chrislusf/gleam/util"
 )
 
-func (as *AgentServer) handleInMemoryReadConnection(conn net.Conn, readerName, channelName string) {
+func (as *AgentServer) handleInMemoryReadConnection(conn net.Conn, readerName, channelName string, compression string) {
 
 	log.Println("in memory", readerName, "waits for", channelName)
 
-	ch := as.inMemoryChannels.WaitForNamedDatasetShard(channelName)
+	ch, chCompression := as.inMemoryChannels.WaitForNamedDatasetShard(channelName)
 
 	if ch == nil {
 		log.Println("in memory", readerName, "read an empty", channelName)
@@ -25,7 +25,7 @@ func (as *AgentServer) handleInMemoryReadConnection(conn net.Conn, readerName, c
 
 	log.Println("in memory", readerName, "start reading", channelName)
 	buf := make([]byte, util.BUFFER_SIZE)
-	count, err := io.CopyBuffer(writer, ch.Reader, buf)
+	count, err := io.CopyBuffer(writer, util.NewTranscodingReader(chCompression, compression, ch.Reader), buf)
 
 	if err == nil {
 		if ch.Error != nil {
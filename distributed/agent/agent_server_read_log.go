@@ -0,0 +1,19 @@
+package agent
+
+import (
+	"github.com/chrislusf/gleam/pb"
+)
+
+// handleReadLogRequest returns the logs of the executors of a flow.
+func (as *AgentServer) handleReadLogRequest(readLogRequest *pb.ReadLogRequest) *pb.ReadLogResponse {
+	reply := &pb.ReadLogResponse{}
+
+	logs, err := as.executorLogs.read(readLogRequest)
+	if err != nil {
+		reply.Error = err.Error()
+		return reply
+	}
+	reply.Logs = logs
+
+	return reply
+}
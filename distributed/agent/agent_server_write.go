@@ -9,9 +9,9 @@ import (
 	"github.com/chrislusf/gleam/util"
 )
 
-func (as *AgentServer) handleLocalWriteConnection(reader io.Reader, writerName, channelName string, readerCount int) {
+func (as *AgentServer) handleLocalWriteConnection(reader io.Reader, writerName, channelName string, readerCount int, compression string) {
 
-	dsStore := as.storageBackend.CreateNamedDatasetShard(channelName)
+	dsStore := as.storageBackend.CreateNamedDatasetShard(channelName, compression)
 
 	log.Println("on disk", writerName, "start writing", channelName, "expected reader:", readerCount)
 
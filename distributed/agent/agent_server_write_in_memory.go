@@ -8,9 +8,9 @@ import (
 	"github.com/chrislusf/gleam/util"
 )
 
-func (as *AgentServer) handleLocalInMemoryWriteConnection(r io.Reader, writerName, channelName string, readerCount int) {
+func (as *AgentServer) handleLocalInMemoryWriteConnection(r io.Reader, writerName, channelName string, readerCount int, compression string) {
 
-	ch := as.inMemoryChannels.CreateNamedDatasetShard(channelName, readerCount)
+	ch := as.inMemoryChannels.CreateNamedDatasetShard(channelName, readerCount, compression)
 	defer func() {
 		ch.incomingChannel.Writer.Close()
 		ch.wg.Wait()
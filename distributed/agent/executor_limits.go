@@ -0,0 +1,111 @@
+package agent
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	exe "github.com/chrislusf/gleam/distributed/executor"
+	"github.com/chrislusf/gleam/pb"
+)
+
+const memoryAccountingInterval = time.Second
+
+// executorLimitsFor computes the rlimits of an executor from its allocation.
+// The address space includes some overhead, since both the executor and the
+// scripts reserve much more virtual memory than they actually use.
+func (as *AgentServer) executorLimitsFor(allocated *pb.ComputeResource) (limits exe.Limits) {
+	if *as.Option.AddressSpaceOverheadMB > 0 {
+		limits.AddressSpaceBytes = uint64(allocated.GetMemoryMb()+*as.Option.AddressSpaceOverheadMB) * 1024 * 1024
+	}
+	if *as.Option.MaxOpenFiles > 0 {
+		limits.OpenFiles = uint64(*as.Option.MaxOpenFiles)
+	}
+	if *as.Option.MaxCPUSeconds > 0 {
+		limits.CPUSeconds = uint64(*as.Option.MaxCPUSeconds)
+	}
+	return
+}
+
+// memoryLimitMbFor computes the memory an executor can use before it is killed,
+// the allocated memory times the limit ratio, but at least the allocated memory
+// plus the overhead, since even an idle executor and its scripts use some memory.
+// It returns 0 for no limit.
+func (as *AgentServer) memoryLimitMbFor(allocated *pb.ComputeResource) int64 {
+	if *as.Option.MemoryLimitRatio <= 0 {
+		return 0
+	}
+	limitMb := int64(float64(allocated.GetMemoryMb()) * *as.Option.MemoryLimitRatio)
+	if floorMb := allocated.GetMemoryMb() + *as.Option.MemoryOverheadMB; limitMb < floorMb {
+		limitMb = floorMb
+	}
+	return limitMb
+}
+
+// killExecutor kills the executor process and the processes it started.
+func killExecutor(process *os.Process) {
+	pids, _, err := processTree(process.Pid)
+	if err != nil {
+		process.Kill()
+		return
+	}
+	killProcesses(pids)
+}
+
+func killProcesses(pids []int) {
+	for _, pid := range pids {
+		if p, err := os.FindProcess(pid); err == nil {
+			p.Kill()
+		}
+	}
+}
+
+// monitorExecutor samples the memory used by the executor process tree,
+// and kills the tree if it uses more memory than allocated.
+func (as *AgentServer) monitorExecutor(process *os.Process, allocated *pb.ComputeResource,
+	stat *AgentExecutorStatus, output io.Writer, stopChan chan bool) {
+
+	stat.Lock()
+	stat.AllocatedMemoryMb = allocated.GetMemoryMb()
+	stat.Unlock()
+	limitMb := as.memoryLimitMbFor(allocated)
+
+	ticker := time.NewTicker(memoryAccountingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-ticker.C:
+		}
+
+		pids, rssBytes, err := processTree(process.Pid)
+		if err != nil {
+			return
+		}
+		memoryMb := rssBytes / 1024 / 1024
+
+		stat.Lock()
+		stat.MemoryMb = memoryMb
+		if memoryMb > stat.PeakMemoryMb {
+			stat.PeakMemoryMb = memoryMb
+		}
+		exceeded := limitMb > 0 && memoryMb > limitMb
+		if exceeded {
+			stat.Error = fmt.Sprintf("executor killed: used %d MB memory, allocated %d MB",
+				memoryMb, allocated.GetMemoryMb())
+		}
+		message := stat.Error
+		stat.Unlock()
+
+		if exceeded {
+			log.Printf("%s, pid %d", message, process.Pid)
+			fmt.Fprintln(output, message)
+			killProcesses(pids)
+			return
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package agent
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// processTree returns the pid and all its descendants,
+// with the sum of their resident memory in bytes.
+func processTree(pid int) (pids []int, rssBytes int64, err error) {
+	entries, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	children := make(map[int][]int)
+	for _, entry := range entries {
+		childPid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		data, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", childPid))
+		if err != nil {
+			continue
+		}
+		// the process name in parentheses may contain spaces
+		fields := strings.Fields(string(data[strings.LastIndexByte(string(data), ')')+1:]))
+		if len(fields) < 2 {
+			continue
+		}
+		parentPid, _ := strconv.Atoi(fields[1])
+		children[parentPid] = append(children[parentPid], childPid)
+	}
+
+	pageSize := int64(os.Getpagesize())
+	pending := []int{pid}
+	for len(pending) > 0 {
+		p := pending[0]
+		pending = pending[1:]
+		data, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/statm", p))
+		if err != nil {
+			// the process has exited
+			continue
+		}
+		fields := strings.Fields(string(data))
+		if len(fields) < 2 {
+			continue
+		}
+		residentPages, _ := strconv.ParseInt(fields[1], 10, 64)
+		rssBytes += residentPages * pageSize
+		pids = append(pids, p)
+		pending = append(pending, children[p]...)
+	}
+
+	return pids, rssBytes, nil
+}
@@ -0,0 +1,11 @@
+// +build !linux
+
+package agent
+
+import (
+	"fmt"
+)
+
+func processTree(pid int) (pids []int, rssBytes int64, err error) {
+	return nil, 0, fmt.Errorf("memory accounting is only supported on linux")
+}
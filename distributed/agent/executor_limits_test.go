@@ -0,0 +1,33 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+func TestMemoryLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		ratio       float64
+		overheadMb  int64
+		allocatedMb int64
+		expected    int64
+	}{
+		{"no limit", 0, 256, 1024, 0},
+		{"ratio over the overhead", 2, 256, 1024, 2048},
+		{"small allocation", 2, 256, 3, 259},
+		{"no allocation", 2, 256, 0, 256},
+		{"ratio below 1", 0.5, 256, 1024, 1280},
+		{"no overhead", 2, 0, 5, 10},
+	}
+	for _, tt := range tests {
+		as := &AgentServer{Option: &AgentServerOption{
+			MemoryLimitRatio: &tt.ratio,
+			MemoryOverheadMB: &tt.overheadMb,
+		}}
+		if limitMb := as.memoryLimitMbFor(&pb.ComputeResource{MemoryMb: tt.allocatedMb}); limitMb != tt.expected {
+			t.Errorf("%s: expecting %d MB, got %d MB", tt.name, tt.expected, limitMb)
+		}
+	}
+}
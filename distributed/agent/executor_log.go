@@ -0,0 +1,333 @@
+package agent
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+// ExecutorLogs keeps the stderr of each executor, including the logs of
+// its scripts, in one file per task group under the first data directory:
+//
+//	logs/flow-<flowHashCode>/taskgroup-<taskGroupId>.log
+//
+// A log growing over maxSize is rotated to .1, .2, ..., keeping maxBackups
+// rotated files. The logs of flows not written for the retention period
+// are removed.
+type ExecutorLogs struct {
+	sync.Mutex // guards writers
+	dir        string
+	maxSize    int64
+	maxBackups int
+	retention  time.Duration
+	writers    map[string]*rotatingLog // open logs, shared by reruns of a task group
+}
+
+func NewExecutorLogs(dir string, maxSize int64, maxBackups int, retention time.Duration) *ExecutorLogs {
+	return &ExecutorLogs{
+		dir:        filepath.Join(dir, "logs"),
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+		retention:  retention,
+		writers:    make(map[string]*rotatingLog),
+	}
+}
+
+func (l *ExecutorLogs) flowDir(flowHashCode uint32) string {
+	return filepath.Join(l.dir, fmt.Sprintf("flow-%d", flowHashCode))
+}
+
+// open appends to the log of the task group, starting with a header line
+// tagging the following output with the instructions run by the executor.
+// The executors of the same task group share one writer, so the log is
+// rotated once for all of them. Each open should be closed.
+func (l *ExecutorLogs) open(startRequest *pb.StartRequest) (*rotatingLog, error) {
+	instructions := startRequest.GetInstructions()
+	dir := l.flowDir(instructions.GetFlowHashCode())
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	fileName := filepath.Join(dir, fmt.Sprintf("taskgroup-%d.log", startRequest.GetTaskGroupId()))
+
+	l.Lock()
+	w, found := l.writers[fileName]
+	if !found {
+		w = &rotatingLog{
+			logs:       l,
+			fileName:   fileName,
+			maxSize:    l.maxSize,
+			maxBackups: l.maxBackups,
+		}
+		if err := w.openFile(); err != nil {
+			l.Unlock()
+			return nil, err
+		}
+		l.writers[fileName] = w
+	}
+	w.openCount++
+	l.Unlock()
+
+	fmt.Fprintf(w, "==== %s request %d %s: %s\n", time.Now().Format("2006/01/02 15:04:05"),
+		instructions.HashCode(), startRequest.GetName(), strings.Join(instructions.InstructionNames(), ","))
+	return w, nil
+}
+
+// read returns the logs of the task groups of the flow, or of all its task
+// groups if none is given. The rotated files come before the current one.
+func (l *ExecutorLogs) read(request *pb.ReadLogRequest) (logs []*pb.ExecutorLog, err error) {
+	dir := l.flowDir(request.GetFlowHashCode())
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[int32]bool)
+	for _, id := range request.GetTaskGroupIds() {
+		wanted[id] = true
+	}
+	for _, f := range files {
+		id, ok := logTaskGroupId(f.Name())
+		if !ok || (len(wanted) > 0 && !wanted[id]) {
+			continue
+		}
+		executorLog, err := readLogFiles(filepath.Join(dir, f.Name()), request.GetTailBytes())
+		if err != nil {
+			return nil, err
+		}
+		executorLog.TaskGroupId = id
+		logs = append(logs, executorLog)
+	}
+	sort.Sort(byTaskGroupId(logs))
+	return logs, nil
+}
+
+func logTaskGroupId(fileName string) (int32, bool) {
+	if !strings.HasPrefix(fileName, "taskgroup-") || !strings.HasSuffix(fileName, ".log") {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(fileName, "taskgroup-"), ".log"), 10, 32)
+	return int32(id), err == nil
+}
+
+// readLogFiles reads the log with its rotated files, or only the last
+// tailBytes if positive, seeking back from the newest file until the tail
+// is filled.
+func readLogFiles(fileName string, tailBytes int64) (*pb.ExecutorLog, error) {
+	executorLog := &pb.ExecutorLog{Name: filepath.Base(fileName)}
+	fileNames := []string{fileName}
+	for i := 1; ; i++ {
+		if _, err := os.Stat(fmt.Sprintf("%s.%d", fileName, i)); err != nil {
+			break
+		}
+		fileNames = append(fileNames, fmt.Sprintf("%s.%d", fileName, i))
+	}
+
+	// the chunks are read from the newest file to the oldest
+	var chunks [][]byte
+	var contentSize int64
+	for _, name := range fileNames {
+		wanted := int64(0)
+		if tailBytes > 0 {
+			wanted = tailBytes - contentSize
+		}
+		if tailBytes > 0 && wanted <= 0 {
+			info, err := os.Stat(name)
+			if err == nil {
+				executorLog.Size += info.Size()
+			}
+			continue
+		}
+		data, size, err := readFileTail(name, wanted)
+		if os.IsNotExist(err) {
+			// rotated while reading
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		executorLog.Size += size
+		chunks = append(chunks, data)
+		contentSize += int64(len(data))
+	}
+
+	executorLog.Content = make([]byte, 0, contentSize)
+	for i := len(chunks) - 1; i >= 0; i-- {
+		executorLog.Content = append(executorLog.Content, chunks[i]...)
+	}
+	if info, err := os.Stat(fileName); err == nil {
+		executorLog.LastWriteTime = info.ModTime().Unix()
+	}
+	return executorLog, nil
+}
+
+// readFileTail reads the last tailBytes of the file, or the whole file if
+// tailBytes is not positive, and returns the file size.
+func readFileTail(fileName string, tailBytes int64) (data []byte, size int64, err error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+	size = info.Size()
+	offset := int64(0)
+	if tailBytes > 0 && size > tailBytes {
+		offset = size - tailBytes
+	}
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		return nil, 0, err
+	}
+	// the file may grow while reading, so read up to the size seen
+	data, err = ioutil.ReadAll(io.LimitReader(file, size-offset))
+	return data, size, err
+}
+
+// purgeExpiredEntries removes the logs of flows not written for the
+// retention period.
+func (l *ExecutorLogs) purgeExpiredEntries() {
+	for {
+		if l.retention > 0 {
+			l.purge(time.Now().Add(-l.retention))
+		}
+		time.Sleep(1 * time.Hour)
+	}
+}
+
+func (l *ExecutorLogs) purge(cutoverLimit time.Time) {
+	dirs, err := ioutil.ReadDir(l.dir)
+	if err != nil {
+		return
+	}
+	for _, dir := range dirs {
+		if !dir.IsDir() || !strings.HasPrefix(dir.Name(), "flow-") {
+			continue
+		}
+		flowDir := filepath.Join(l.dir, dir.Name())
+		files, _ := ioutil.ReadDir(flowDir)
+		lastWrite := dir.ModTime()
+		for _, f := range files {
+			if f.ModTime().After(lastWrite) {
+				lastWrite = f.ModTime()
+			}
+		}
+		if lastWrite.Before(cutoverLimit) {
+			if err := os.RemoveAll(flowDir); err != nil {
+				log.Printf("Failed to remove executor logs %s: %v", flowDir, err)
+			}
+		}
+	}
+}
+
+// rotatingLog appends to a file, and rotates the file when it grows over
+// maxSize.
+type rotatingLog struct {
+	sync.Mutex
+	logs       *ExecutorLogs
+	openCount  int // guarded by logs
+	fileName   string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func (w *rotatingLog) openFile() error {
+	file, err := os.OpenFile(w.fileName, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	w.file, w.size = file, info.Size()
+	return nil
+}
+
+// Write never fails, so failing to log does not fail the executor output
+// written along with the log.
+func (w *rotatingLog) Write(p []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+	if w.file == nil {
+		return len(p), nil
+	}
+	if w.maxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			log.Printf("Failed to rotate executor log %s: %v", w.fileName, err)
+			return len(p), nil
+		}
+	}
+	n, err := w.file.Write(p)
+	if err != nil {
+		log.Printf("Failed to write executor log %s: %v", w.fileName, err)
+	}
+	w.size += int64(n)
+	return len(p), nil
+}
+
+// rotate shifts the rotated files by one, dropping the oldest, and starts
+// a new file.
+func (w *rotatingLog) rotate() error {
+	w.file.Close()
+	w.file = nil
+	os.Remove(fmt.Sprintf("%s.%d", w.fileName, w.maxBackups))
+	for i := w.maxBackups - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", w.fileName, i), fmt.Sprintf("%s.%d", w.fileName, i+1))
+	}
+	if w.maxBackups > 0 {
+		if err := os.Rename(w.fileName, w.fileName+".1"); err != nil {
+			return err
+		}
+	} else if err := os.Remove(w.fileName); err != nil {
+		return err
+	}
+	return w.openFile()
+}
+
+// Close closes the file when the last executor sharing the log is done.
+func (w *rotatingLog) Close() error {
+	if w.logs != nil {
+		w.logs.Lock()
+		w.openCount--
+		last := w.openCount == 0
+		if last {
+			delete(w.logs.writers, w.fileName)
+		}
+		w.logs.Unlock()
+		if !last {
+			return nil
+		}
+	}
+
+	w.Lock()
+	defer w.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+type byTaskGroupId []*pb.ExecutorLog
+
+func (s byTaskGroupId) Len() int           { return len(s) }
+func (s byTaskGroupId) Less(i, j int) bool { return s[i].TaskGroupId < s[j].TaskGroupId }
+func (s byTaskGroupId) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
@@ -0,0 +1,185 @@
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+func logStartRequest(flowHashCode uint32, taskGroupId int32) *pb.StartRequest {
+	return &pb.StartRequest{
+		Instructions: &pb.InstructionSet{FlowHashCode: flowHashCode},
+		Name:         "test",
+		TaskGroupId:  taskGroupId,
+	}
+}
+
+// writeLog writes the numbered lines to the log of the task group.
+func writeLog(t *testing.T, l *ExecutorLogs, flowHashCode uint32, taskGroupId int32, lines int) {
+	w, err := l.open(logStartRequest(flowHashCode, taskGroupId))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	for i := 0; i < lines; i++ {
+		fmt.Fprintf(w, "line %02d of task group %d\n", i, taskGroupId)
+	}
+}
+
+func fileSizes(t *testing.T, fileName string, maxBackups int) (sizes []int64) {
+	for i := 0; i <= maxBackups+1; i++ {
+		name := fileName
+		if i > 0 {
+			name = fmt.Sprintf("%s.%d", fileName, i)
+		}
+		info, err := os.Stat(name)
+		if os.IsNotExist(err) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		sizes = append(sizes, info.Size())
+	}
+	return sizes
+}
+
+func TestExecutorLogRotation(t *testing.T) {
+	for _, maxBackups := range []int{2, 0} {
+		l := NewExecutorLogs(t.TempDir(), 100, maxBackups, 0)
+		writeLog(t, l, 7, 1, 20)
+
+		sizes := fileSizes(t, filepath.Join(l.flowDir(7), "taskgroup-1.log"), maxBackups)
+		if len(sizes) != maxBackups+1 {
+			t.Errorf("expecting the log and %d rotated files, got sizes %v", maxBackups, sizes)
+		}
+		for _, size := range sizes {
+			if size > 100 {
+				t.Errorf("expecting files up to 100 bytes with %d backups, got sizes %v", maxBackups, sizes)
+			}
+		}
+	}
+}
+
+func TestExecutorLogSharedWriter(t *testing.T) {
+	l := NewExecutorLogs(t.TempDir(), 0, 0, 0)
+	first, err := l.open(logStartRequest(7, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := l.open(logStartRequest(7, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Fatalf("expecting the executors of a task group to share the writer")
+	}
+
+	first.Close()
+	fmt.Fprintf(second, "still open\n")
+	second.Close()
+	if len(l.writers) != 0 {
+		t.Errorf("expecting the writer closed after the last executor, got %v", l.writers)
+	}
+
+	logs, err := l.read(&pb.ReadLogRequest{FlowHashCode: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 || !strings.HasSuffix(string(logs[0].Content), "still open\n") ||
+		strings.Count(string(logs[0].Content), "==== ") != 2 {
+		t.Errorf("expecting both headers and the line written, got %q", logs)
+	}
+}
+
+func TestExecutorLogRead(t *testing.T) {
+	l := NewExecutorLogs(t.TempDir(), 100, 5, 0)
+	writeLog(t, l, 7, 2, 10)
+	writeLog(t, l, 7, 1, 10)
+
+	logs, err := l.read(&pb.ReadLogRequest{FlowHashCode: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 2 || logs[0].TaskGroupId != 1 || logs[1].TaskGroupId != 2 {
+		t.Fatalf("expecting the logs of task groups 1 and 2, got %v", logs)
+	}
+	full := logs[0]
+	if int64(len(full.Content)) != full.Size || full.LastWriteTime == 0 {
+		t.Errorf("expecting all %d bytes with the last write time, got %d bytes at %d", full.Size, len(full.Content), full.LastWriteTime)
+	}
+	if !strings.Contains(string(full.Content), "line 00 of task group 1\n") ||
+		!strings.HasSuffix(string(full.Content), "line 09 of task group 1\n") {
+		t.Errorf("expecting the rotated files before the current one, got %q", full.Content)
+	}
+
+	logs, err = l.read(&pb.ReadLogRequest{FlowHashCode: 7, TaskGroupIds: []int32{2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 || logs[0].TaskGroupId != 2 {
+		t.Errorf("expecting only the log of task group 2, got %v", logs)
+	}
+
+	// the tail spans the rotated files
+	for _, tailBytes := range []int64{10, 150, full.Size + 100} {
+		logs, err = l.read(&pb.ReadLogRequest{FlowHashCode: 7, TaskGroupIds: []int32{1}, TailBytes: tailBytes})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := full.Content
+		if int64(len(want)) > tailBytes {
+			want = want[int64(len(want))-tailBytes:]
+		}
+		if !bytes.Equal(logs[0].Content, want) || logs[0].Size != full.Size {
+			t.Errorf("expecting the last %d bytes of %d, got %q of %d", tailBytes, full.Size, logs[0].Content, logs[0].Size)
+		}
+	}
+
+	if logs, err := l.read(&pb.ReadLogRequest{FlowHashCode: 9}); err != nil || len(logs) != 0 {
+		t.Errorf("expecting no logs of an unknown flow, got %v, %v", logs, err)
+	}
+}
+
+func TestLogTaskGroupId(t *testing.T) {
+	for fileName, expected := range map[string]int32{
+		"taskgroup-0.log":  0,
+		"taskgroup-12.log": 12,
+	} {
+		if id, ok := logTaskGroupId(fileName); !ok || id != expected {
+			t.Errorf("expecting task group %d from %s, got %d", expected, fileName, id)
+		}
+	}
+	for _, fileName := range []string{"taskgroup-1.log.1", "taskgroup-x.log", "flow-1", "taskgroup-.log"} {
+		if id, ok := logTaskGroupId(fileName); ok {
+			t.Errorf("expecting %s skipped, got task group %d", fileName, id)
+		}
+	}
+}
+
+func TestExecutorLogPurge(t *testing.T) {
+	l := NewExecutorLogs(t.TempDir(), 0, 0, time.Hour)
+	writeLog(t, l, 7, 1, 1)
+	writeLog(t, l, 9, 1, 1)
+
+	old := time.Now().Add(-2 * time.Hour)
+	for _, name := range []string{filepath.Join(l.flowDir(7), "taskgroup-1.log"), l.flowDir(7)} {
+		if err := os.Chtimes(name, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l.purge(time.Now().Add(-l.retention))
+	if _, err := os.Stat(l.flowDir(7)); !os.IsNotExist(err) {
+		t.Errorf("expecting the logs of flow 7 removed, got %v", err)
+	}
+	if _, err := os.Stat(l.flowDir(9)); err != nil {
+		t.Errorf("expecting the logs of flow 9 kept, got %v", err)
+	}
+}
@@ -2,30 +2,219 @@ package agent
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/chrislusf/gleam/distributed/store"
+	"github.com/chrislusf/gleam/util"
 )
 
+// a data directory stops receiving new dataset shards after this many I/O errors
+const maxDataDirErrors = 3
+
+type dataDir struct {
+	sync.Mutex
+	path       string
+	errorCount int
+}
+
+func (d *dataDir) isHealthy() bool {
+	d.Lock()
+	defer d.Unlock()
+	return d.errorCount < maxDataDirErrors
+}
+
+func (d *dataDir) reportError(err error) {
+	if err == nil || err == io.EOF {
+		return
+	}
+	d.Lock()
+	defer d.Unlock()
+	d.errorCount++
+	if d.errorCount == maxDataDirErrors {
+		log.Printf("data directory %s is marked unhealthy after error: %v", d.path, err)
+	}
+}
+
+// dataDirStore tracks I/O errors of a dataset shard against its data directory.
+// The data is kept as written, compressed with the writer's compression codec.
+type dataDirStore struct {
+	store.DataStore
+	dir         *dataDir
+	compression string
+	createdAt   time.Time
+	size        int64 // bytes written, accessed atomically
+}
+
+func (s *dataDirStore) Write(data []byte) (int, error) {
+	n, err := s.DataStore.Write(data)
+	s.dir.reportError(err)
+	atomic.AddInt64(&s.size, int64(n))
+	return n, err
+}
+
+func (s *dataDirStore) ReadAt(data []byte, offset int64) (int, error) {
+	n, err := s.DataStore.ReadAt(data, offset)
+	s.dir.reportError(err)
+	return n, err
+}
+
 type LocalDatasetShardsManager struct {
 	sync.Mutex
-	dir            string
+	dirs           []*dataDir
+	nextDirIndex   int
 	port           int
-	name2Store     map[string]store.DataStore
+	name2Store     map[string]*dataDirStore
 	name2StoreCond *sync.Cond
 }
 
-func NewLocalDatasetShardsManager(dir string, port int) *LocalDatasetShardsManager {
+func NewLocalDatasetShardsManager(dirs []string, port int) *LocalDatasetShardsManager {
 	m := &LocalDatasetShardsManager{
-		dir:        dir,
 		port:       port,
-		name2Store: make(map[string]store.DataStore),
+		name2Store: make(map[string]*dataDirStore),
+	}
+	for _, dir := range dirs {
+		m.dirs = append(m.dirs, &dataDir{path: dir})
 	}
 	m.name2StoreCond = sync.NewCond(m)
 	return m
 }
 
+// Dirs returns all configured data directories.
+func (m *LocalDatasetShardsManager) Dirs() (dirs []string) {
+	for _, d := range m.dirs {
+		dirs = append(dirs, d.path)
+	}
+	return
+}
+
+// StoredBytes returns the size of all dataset shards stored on disk.
+func (m *LocalDatasetShardsManager) StoredBytes() (total int64) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, s := range m.name2Store {
+		total += atomic.LoadInt64(&s.size)
+	}
+	return
+}
+
+// NamedDatasetShards returns the dataset shards currently stored on disk.
+func (m *LocalDatasetShardsManager) NamedDatasetShards() map[string]*dataDirStore {
+	m.Lock()
+	defer m.Unlock()
+
+	shards := make(map[string]*dataDirStore, len(m.name2Store))
+	for name, s := range m.name2Store {
+		shards[name] = s
+	}
+	return shards
+}
+
+// FirstHealthyDir returns the first data directory without too many I/O errors.
+func (m *LocalDatasetShardsManager) FirstHealthyDir() string {
+	for _, d := range m.dirs {
+		if d.isHealthy() {
+			return d.path
+		}
+	}
+	return m.dirs[0].path
+}
+
+// shardFileName names the file of a dataset shard, with the compression codec
+// of its data, so the shard can be found again after the agent restarts.
+func (m *LocalDatasetShardsManager) shardFileName(name, compression string) string {
+	if compression != util.CompressionNone {
+		name = name + "." + compression
+	}
+	return fmt.Sprintf("%s-%d", name, m.port)
+}
+
+// parseShardFileName returns the shard name and compression codec of a .dat
+// file written by this agent.
+func (m *LocalDatasetShardsManager) parseShardFileName(fileName string) (name, compression string, ok bool) {
+	suffix := fmt.Sprintf("-%d.dat", m.port)
+	if !strings.HasSuffix(fileName, suffix) {
+		return "", "", false
+	}
+	name = strings.TrimSuffix(fileName, suffix)
+	if i := strings.LastIndex(name, "."); i > 0 && i < len(name)-1 && util.ValidateCompression(name[i+1:]) == nil {
+		name, compression = name[:i], name[i+1:]
+	}
+	return name, compression, true
+}
+
+// LoadStoredShards finds the dataset shards kept in all data directories,
+// written before the agent restarted.
+func (m *LocalDatasetShardsManager) LoadStoredShards() {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, dir := range m.dirs {
+		fileInfos, err := ioutil.ReadDir(dir.path)
+		if err != nil {
+			continue
+		}
+		for _, fi := range fileInfos {
+			name, compression, ok := m.parseShardFileName(fi.Name())
+			if fi.IsDir() || !ok {
+				continue
+			}
+			ds, err := store.OpenLocalFileDataStore(dir.path, strings.TrimSuffix(fi.Name(), ".dat"))
+			if err != nil {
+				log.Printf("Failed to load dataset shard %s: %v", name, err)
+				continue
+			}
+			m.name2Store[name] = &dataDirStore{
+				DataStore:   ds,
+				dir:         dir,
+				compression: compression,
+				createdAt:   fi.ModTime(),
+				size:        fi.Size(),
+			}
+		}
+	}
+	m.name2StoreCond.Broadcast()
+}
+
+// RemoveStoredShards removes the dataset shard files of this agent from all
+// data directories.
+func (m *LocalDatasetShardsManager) RemoveStoredShards() {
+	for _, dir := range m.dirs {
+		fileInfos, err := ioutil.ReadDir(dir.path)
+		if err != nil {
+			continue
+		}
+		for _, fi := range fileInfos {
+			if _, _, ok := m.parseShardFileName(fi.Name()); ok && !fi.IsDir() {
+				os.Remove(filepath.Join(dir.path, fi.Name()))
+			}
+		}
+	}
+}
+
+// pickDir chooses data directories in round-robin order, skipping unhealthy ones.
+// If all directories are unhealthy, it still returns one of them.
+func (m *LocalDatasetShardsManager) pickDir() *dataDir {
+	for i := 0; i < len(m.dirs); i++ {
+		d := m.dirs[m.nextDirIndex]
+		m.nextDirIndex = (m.nextDirIndex + 1) % len(m.dirs)
+		if d.isHealthy() {
+			return d
+		}
+	}
+	d := m.dirs[m.nextDirIndex]
+	m.nextDirIndex = (m.nextDirIndex + 1) % len(m.dirs)
+	return d
+}
+
 func (m *LocalDatasetShardsManager) doDelete(name string) {
 
 	// println("deleting from LocalDatasetShardsManager:", name)
@@ -53,7 +242,7 @@ func (m *LocalDatasetShardsManager) DeleteNamedDatasetShard(name string) {
 
 }
 
-func (m *LocalDatasetShardsManager) CreateNamedDatasetShard(name string) store.DataStore {
+func (m *LocalDatasetShardsManager) CreateNamedDatasetShard(name string, compression string) *dataDirStore {
 
 	m.Lock()
 	defer m.Unlock()
@@ -63,7 +252,13 @@ func (m *LocalDatasetShardsManager) CreateNamedDatasetShard(name string) store.D
 		m.doDelete(name)
 	}
 
-	s := store.NewLocalFileDataStore(m.dir, fmt.Sprintf("%s-%d", name, m.port))
+	dir := m.pickDir()
+	s := &dataDirStore{
+		DataStore:   store.NewLocalFileDataStore(dir.path, m.shardFileName(name, compression)),
+		dir:         dir,
+		compression: compression,
+		createdAt:   time.Now(),
+	}
 
 	m.name2Store[name] = s
 	// println(name, "is broadcasting...")
@@ -73,7 +268,7 @@ func (m *LocalDatasetShardsManager) CreateNamedDatasetShard(name string) store.D
 
 }
 
-func (m *LocalDatasetShardsManager) WaitForNamedDatasetShard(name string) store.DataStore {
+func (m *LocalDatasetShardsManager) WaitForNamedDatasetShard(name string) *dataDirStore {
 
 	m.Lock()
 	defer m.Unlock()
@@ -13,18 +13,22 @@ type trackedChannel struct {
 	outgoingChannels []*util.Piper
 	index            int
 	wg               *sync.WaitGroup
+	createdAt        time.Time
 	lastWriteAt      time.Time
 	isClosed         bool
+	compression      string
 }
 
-func newTrackedChannel(readerCount int) *trackedChannel {
+func newTrackedChannel(readerCount int, compression string) *trackedChannel {
 	var wg sync.WaitGroup
 	t := &trackedChannel{
 		incomingChannel:  util.NewPiper(),
 		outgoingChannels: make([]*util.Piper, readerCount),
 		index:            0,
 		wg:               &wg,
+		createdAt:        time.Now(),
 		lastWriteAt:      time.Now(),
+		compression:      compression,
 	}
 	if readerCount > 1 {
 		for i := range t.outgoingChannels {
@@ -78,7 +82,7 @@ func (m *LocalDatasetShardsManagerInMemory) doDelete(name string) {
 
 }
 
-func (m *LocalDatasetShardsManagerInMemory) CreateNamedDatasetShard(name string, readerCount int) *trackedChannel {
+func (m *LocalDatasetShardsManagerInMemory) CreateNamedDatasetShard(name string, readerCount int, compression string) *trackedChannel {
 
 	m.Lock()
 	defer m.Unlock()
@@ -88,7 +92,7 @@ func (m *LocalDatasetShardsManagerInMemory) CreateNamedDatasetShard(name string,
 		m.doDelete(name)
 	}
 
-	tc := newTrackedChannel(readerCount)
+	tc := newTrackedChannel(readerCount, compression)
 
 	m.name2Channel[name] = tc
 	m.name2ChannelCond.Broadcast()
@@ -97,7 +101,8 @@ func (m *LocalDatasetShardsManagerInMemory) CreateNamedDatasetShard(name string,
 	return tc
 }
 
-func (m *LocalDatasetShardsManagerInMemory) WaitForNamedDatasetShard(name string) *util.Piper {
+// WaitForNamedDatasetShard returns the channel and the compression codec used by its writer.
+func (m *LocalDatasetShardsManagerInMemory) WaitForNamedDatasetShard(name string) (*util.Piper, string) {
 
 	m.Lock()
 	defer m.Unlock()
@@ -106,9 +111,9 @@ func (m *LocalDatasetShardsManagerInMemory) WaitForNamedDatasetShard(name string
 		if tc, ok := m.name2Channel[name]; ok {
 			// println("found existing channel", name, "closed:", tc.isClosed)
 			if tc.isClosed {
-				return nil
+				return nil, tc.compression
 			}
-			return tc.borrowChannel()
+			return tc.borrowChannel(), tc.compression
 		}
 		// println("waiting for", name, m, m.name2Channel[name])
 		m.name2ChannelCond.Wait()
@@ -117,6 +122,18 @@ func (m *LocalDatasetShardsManagerInMemory) WaitForNamedDatasetShard(name string
 
 }
 
+// NamedDatasetShards returns the dataset shards currently passed in memory.
+func (m *LocalDatasetShardsManagerInMemory) NamedDatasetShards() map[string]*trackedChannel {
+	m.Lock()
+	defer m.Unlock()
+
+	channels := make(map[string]*trackedChannel, len(m.name2Channel))
+	for name, tc := range m.name2Channel {
+		channels[name] = tc
+	}
+	return channels
+}
+
 func (m *LocalDatasetShardsManagerInMemory) Cleanup(name string) {
 
 	m.Lock()
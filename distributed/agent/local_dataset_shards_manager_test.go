@@ -0,0 +1,127 @@
+package agent
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/chrislusf/gleam/util"
+)
+
+func failDir(d *dataDir, errorCount int) {
+	for i := 0; i < errorCount; i++ {
+		d.reportError(errors.New("input/output error"))
+	}
+}
+
+func TestPickDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		errors   []int // I/O errors of each dir
+		expected []string
+	}{
+		{"round robin", []int{0, 0, 0}, []string{"a", "b", "c", "a"}},
+		{"few errors", []int{0, maxDataDirErrors - 1, 0}, []string{"a", "b", "c", "a"}},
+		{"skip unhealthy", []int{0, maxDataDirErrors, 0}, []string{"a", "c", "a", "c"}},
+		{"only one healthy", []int{maxDataDirErrors, 0, maxDataDirErrors + 1}, []string{"b", "b", "b", "b"}},
+		{"all unhealthy", []int{maxDataDirErrors, maxDataDirErrors, maxDataDirErrors}, []string{"a", "b", "c", "a"}},
+	}
+	for _, tt := range tests {
+		m := NewLocalDatasetShardsManager([]string{"a", "b", "c"}, 45326)
+		for i, errorCount := range tt.errors {
+			failDir(m.dirs[i], errorCount)
+		}
+		var picked []string
+		for range tt.expected {
+			picked = append(picked, m.pickDir().path)
+		}
+		if !reflect.DeepEqual(picked, tt.expected) {
+			t.Errorf("%s: expecting %v, got %v", tt.name, tt.expected, picked)
+		}
+	}
+}
+
+func TestFirstHealthyDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		errors   []int
+		expected string
+	}{
+		{"all healthy", []int{0, 0, 0}, "a"},
+		{"first unhealthy", []int{maxDataDirErrors, 0, 0}, "b"},
+		{"all unhealthy", []int{maxDataDirErrors, maxDataDirErrors, maxDataDirErrors}, "a"},
+	}
+	for _, tt := range tests {
+		m := NewLocalDatasetShardsManager([]string{"a", "b", "c"}, 45326)
+		for i, errorCount := range tt.errors {
+			failDir(m.dirs[i], errorCount)
+		}
+		if dir := m.FirstHealthyDir(); dir != tt.expected {
+			t.Errorf("%s: expecting %s, got %s", tt.name, tt.expected, dir)
+		}
+	}
+}
+
+func TestLoadStoredShards(t *testing.T) {
+	dirs := []string{t.TempDir(), t.TempDir()}
+	m := NewLocalDatasetShardsManager(dirs, 45326)
+	shards := []struct {
+		name        string
+		compression string
+		data        string
+	}{
+		{"f1-d1-s0", util.CompressionNone, "plain rows"},
+		{"f1-d1-s1", util.CompressionSnappy, "snappy blocks"},
+	}
+	for _, shard := range shards {
+		s := m.CreateNamedDatasetShard(shard.name, shard.compression)
+		if _, err := s.Write([]byte(shard.data)); err != nil {
+			t.Fatalf("write %s: %v", shard.name, err)
+		}
+	}
+	// a shard of another agent sharing the data directory
+	if err := ioutil.WriteFile(filepath.Join(dirs[0], "f1-d1-s2-45327.dat"), []byte("other"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	restarted := NewLocalDatasetShardsManager(dirs, 45326)
+	restarted.LoadStoredShards()
+	loaded := restarted.NamedDatasetShards()
+	if len(loaded) != len(shards) {
+		t.Fatalf("expecting %d shards, got %v", len(shards), loaded)
+	}
+	for i, shard := range shards {
+		s, found := loaded[shard.name]
+		if !found {
+			t.Errorf("expecting shard %s loaded", shard.name)
+			continue
+		}
+		if s.dir.path != dirs[i] {
+			t.Errorf("expecting shard %s in %s, got %s", shard.name, dirs[i], s.dir.path)
+		}
+		if s.compression != shard.compression {
+			t.Errorf("expecting shard %s compressed with %q, got %q", shard.name, shard.compression, s.compression)
+		}
+		data := make([]byte, len(shard.data))
+		if n, err := s.ReadAt(data, 0); err != nil || string(data[:n]) != shard.data {
+			t.Errorf("expecting shard %s data %q, got %q: %v", shard.name, shard.data, data[:n], err)
+		}
+	}
+	if size := restarted.StoredBytes(); size != int64(len("plain rows")+len("snappy blocks")) {
+		t.Errorf("unexpected stored bytes %d", size)
+	}
+
+	restarted.RemoveStoredShards()
+	for i, dir := range dirs {
+		files, _ := ioutil.ReadDir(dir)
+		expected := 0
+		if i == 0 {
+			expected = 1 // the other agent's shard is kept
+		}
+		if len(files) != expected {
+			t.Errorf("expecting %d files left in %s, got %d", expected, dir, len(files))
+		}
+	}
+}
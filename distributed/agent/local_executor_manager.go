@@ -2,9 +2,11 @@ package agent
 
 import (
 	"os"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/chrislusf/gleam/pb"
 	"github.com/chrislusf/gleam/util"
 )
 
@@ -13,11 +15,26 @@ type LocalExecutorManager struct {
 	id2ExecutorStatus map[uint32]*AgentExecutorStatus
 }
 
+// AgentExecutorStatus is updated by the executor start, the executor's own
+// reports and the memory monitor, while read by the status requests, all
+// from different goroutines, so its fields are accessed under its lock.
 type AgentExecutorStatus struct {
+	sync.Mutex
 	util.ExecutorStatus
-	RequestHash    int32
-	Process        *os.Process
-	LastAccessTime time.Time // used for expiring entries
+	RequestHash       int32
+	Process           *os.Process
+	LastAccessTime    time.Time // used for expiring entries
+	MemoryMb          int64     // resident memory of the executor process tree
+	PeakMemoryMb      int64
+	AllocatedMemoryMb int64
+	Error             string
+	Queue             string
+	Allocated         pb.ComputeResource
+	LeaseId           uint64
+	Restartable       bool // only restartable executors are preempted
+	Preempted         bool
+	InstructionStats  []*pb.InstructionStat // reported by the executor
+	Profiles          []*pb.Profile         // reported by the executor, if profiling
 }
 
 func newLocalExecutorsManager() *LocalExecutorManager {
@@ -41,6 +58,39 @@ func (m *LocalExecutorManager) getExecutorStatus(id uint32) *AgentExecutorStatus
 	return executorStatus
 }
 
+// runningExecutors lists the running executors matching the filter, the newest first.
+// The filter is called with the executor status locked.
+func (m *LocalExecutorManager) runningExecutors(filter func(*AgentExecutorStatus) bool) (running []*AgentExecutorStatus) {
+	m.Lock()
+	defer m.Unlock()
+
+	var starts []executorStart
+	for _, executorStatus := range m.id2ExecutorStatus {
+		executorStatus.Lock()
+		if executorStatus.Process != nil && executorStatus.StopTime.IsZero() && filter(executorStatus) {
+			starts = append(starts, executorStart{executorStatus, executorStatus.StartTime})
+		}
+		executorStatus.Unlock()
+	}
+	sort.Sort(byNewestStart(starts))
+	for _, start := range starts {
+		running = append(running, start.status)
+	}
+	return
+}
+
+// executorStart keeps the start time read under the executor status lock.
+type executorStart struct {
+	status    *AgentExecutorStatus
+	startTime time.Time
+}
+
+type byNewestStart []executorStart
+
+func (s byNewestStart) Len() int           { return len(s) }
+func (s byNewestStart) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byNewestStart) Less(i, j int) bool { return s[i].startTime.After(s[j].startTime) }
+
 // purge executor status older than 24 hours to save memory
 func (m *LocalExecutorManager) purgeExpiredEntries() {
 	for {
@@ -48,7 +98,10 @@ func (m *LocalExecutorManager) purgeExpiredEntries() {
 			m.Lock()
 			cutoverLimit := time.Now().Add(-24 * time.Hour)
 			for id, executorStatus := range m.id2ExecutorStatus {
-				if executorStatus.LastAccessTime.Before(cutoverLimit) {
+				executorStatus.Lock()
+				expired := executorStatus.LastAccessTime.Before(cutoverLimit)
+				executorStatus.Unlock()
+				if expired {
 					delete(m.id2ExecutorStatus, id)
 				}
 			}
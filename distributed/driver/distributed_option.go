@@ -1,5 +1,13 @@
 package driver
 
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/chrislusf/gleam/distributed/security"
+)
+
 type Option struct {
 	Master       string
 	DataCenter   string
@@ -9,4 +17,56 @@ type Option struct {
 	Module       string
 	Host         string
 	Port         int
+	Compression  string
+	TLS          *security.TLSOption
+	Token        string
+	Env          map[string]string
+	EnvAllowList []string
+	Config       map[string]string
+	Queue        string
+	User         string
+	ProfileDir   string
+	ProfileSteps []string
+}
+
+// ExecutorEnvs returns the environment variables for remote executors:
+// the allowed ones from the driver's environment, overridden by Env.
+// An allow list entry ending with "*" matches by prefix.
+func (o *Option) ExecutorEnvs() (envs []string) {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) == 2 && isEnvAllowed(parts[0], o.EnvAllowList) {
+			env[parts[0]] = parts[1]
+		}
+	}
+	for k, v := range o.Env {
+		env[k] = v
+	}
+	return toSortedKeyValues(env)
+}
+
+// ExecutorConfig returns the flow config as sorted "key=value" entries.
+func (o *Option) ExecutorConfig() []string {
+	return toSortedKeyValues(o.Config)
+}
+
+func isEnvAllowed(name string, allowList []string) bool {
+	for _, allowed := range allowList {
+		if allowed == name {
+			return true
+		}
+		if strings.HasSuffix(allowed, "*") && strings.HasPrefix(name, allowed[:len(allowed)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+func toSortedKeyValues(m map[string]string) (keyValues []string) {
+	for k, v := range m {
+		keyValues = append(keyValues, k+"="+v)
+	}
+	sort.Strings(keyValues)
+	return
 }
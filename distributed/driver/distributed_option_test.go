@@ -0,0 +1,61 @@
+package driver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsEnvAllowed(t *testing.T) {
+	allowList := []string{"PATH", "GLEAM_*", "LC_"}
+	tests := []struct {
+		name    string
+		allowed bool
+	}{
+		{"PATH", true},
+		{"PATHEXT", false},
+		{"GLEAM_HOME", true},
+		{"GLEAM_", true},
+		{"GLEAM", false},
+		{"LC_", true},
+		{"LC_ALL", false},
+		{"HOME", false},
+	}
+	for _, tt := range tests {
+		if allowed := isEnvAllowed(tt.name, allowList); allowed != tt.allowed {
+			t.Errorf("%s: expecting allowed %v, got %v", tt.name, tt.allowed, allowed)
+		}
+	}
+	if isEnvAllowed("PATH", nil) {
+		t.Errorf("expecting nothing allowed without an allow list")
+	}
+}
+
+func TestExecutorEnvs(t *testing.T) {
+	t.Setenv("GLEAM_TEST_REGION", "us-east")
+	t.Setenv("GLEAM_TEST_ZONE", "a")
+	t.Setenv("GLEAM_TEST_SECRET", "hidden")
+
+	option := &Option{
+		EnvAllowList: []string{"GLEAM_TEST_REGION", "GLEAM_TEST_Z*"},
+		Env:          map[string]string{"GLEAM_TEST_REGION": "eu-west", "GLEAM_TEST_DEBUG": "1"},
+	}
+	expected := []string{
+		"GLEAM_TEST_DEBUG=1",
+		"GLEAM_TEST_REGION=eu-west",
+		"GLEAM_TEST_ZONE=a",
+	}
+	if envs := option.ExecutorEnvs(); !reflect.DeepEqual(envs, expected) {
+		t.Errorf("expecting %v, got %v", expected, envs)
+	}
+}
+
+func TestToSortedKeyValues(t *testing.T) {
+	keyValues := toSortedKeyValues(map[string]string{"b": "2", "a": "1", "c": "", "a.b": "x=y"})
+	expected := []string{"a.b=x=y", "a=1", "b=2", "c="}
+	if !reflect.DeepEqual(keyValues, expected) {
+		t.Errorf("expecting %v, got %v", expected, keyValues)
+	}
+	if keyValues := toSortedKeyValues(nil); len(keyValues) != 0 {
+		t.Errorf("expecting no entries, got %v", keyValues)
+	}
+}
@@ -4,13 +4,17 @@ package driver
 import (
 	"log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/chrislusf/gleam/distributed/driver/scheduler"
 	"github.com/chrislusf/gleam/distributed/plan"
 	"github.com/chrislusf/gleam/distributed/rsync"
+	"github.com/chrislusf/gleam/distributed/security"
 	"github.com/chrislusf/gleam/flow"
+	"github.com/chrislusf/gleam/util"
 	"github.com/chrislusf/gleam/util/on_interrupt"
 )
 
@@ -25,9 +29,32 @@ func NewFlowContextDriver(option *Option) *FlowContextDriver {
 	return &FlowContextDriver{Option: option}
 }
 
+// flowName names the flow for cluster administration.
+func (fcd *FlowContextDriver) flowName() string {
+	if fcd.Option.Module != "" {
+		return fcd.Option.Module
+	}
+	return filepath.Base(os.Args[0])
+}
+
 // driver runs on local, controlling all tasks
 func (fcd *FlowContextDriver) RunFlowContext(fc *flow.FlowContext) {
 
+	if err := util.ValidateCompression(fcd.Option.Compression); err != nil {
+		log.Fatalf("Invalid compression option: %v", err)
+	}
+
+	if err := security.SetupTLS(fcd.Option.TLS); err != nil {
+		log.Fatalf("Failed to setup TLS: %v", err)
+	}
+	if err := security.SetClientToken(fcd.Option.Token); err != nil {
+		log.Fatalf("Failed to set token: %v", err)
+	}
+
+	if fcd.Option.User == "" {
+		fcd.Option.User = os.Getenv("USER")
+	}
+
 	// task fusion to minimize disk IO
 	fcd.stepGroups, fcd.taskGroups = plan.GroupTasks(fc)
 
@@ -36,6 +63,11 @@ func (fcd *FlowContextDriver) RunFlowContext(fc *flow.FlowContext) {
 	if err != nil {
 		log.Fatalf("Failed to start local server: %v", err)
 	}
+	for _, f := range fc.RelatedFiles {
+		if err := rsyncServer.AddFile(f.FullPath, f.Name); err != nil {
+			log.Fatalf("Failed to read file %s: %v", f.FullPath, err)
+		}
+	}
 	rsyncServer.StartRsyncServer(fcd.Option.Host + ":" + strconv.Itoa(fcd.Option.Port))
 
 	// create thes cheduler
@@ -48,6 +80,15 @@ func (fcd *FlowContextDriver) RunFlowContext(fc *flow.FlowContext) {
 			DriverHost:   fcd.Option.Host,
 			DriverPort:   rsyncServer.Port,
 			Module:       fcd.Option.Module,
+			Compression:  fcd.Option.Compression,
+			Envs:         fcd.Option.ExecutorEnvs(),
+			Config:       fcd.Option.ExecutorConfig(),
+			Queue:        fcd.Option.Queue,
+			User:         fcd.Option.User,
+			FlowHashCode: fc.HashCode,
+			FlowName:     fcd.flowName(),
+			ProfileDir:   fcd.Option.ProfileDir,
+			ProfileSteps: fcd.Option.ProfileSteps,
 		},
 	)
 
@@ -56,6 +97,7 @@ func (fcd *FlowContextDriver) RunFlowContext(fc *flow.FlowContext) {
 	defer fcd.cleanup(sched, fc)
 
 	go sched.EventLoop()
+	go sched.RenewLeasesLoop()
 
 	on_interrupt.OnInterrupt(func() {
 		fcd.OnInterrupt(fc, sched)
@@ -65,6 +107,15 @@ func (fcd *FlowContextDriver) RunFlowContext(fc *flow.FlowContext) {
 		fcd.cleanup(sched, fc)
 	})
 
+	stopReporting, reportingDone := make(chan struct{}), make(chan struct{})
+	go fcd.reportProgress(fc, sched, time.Now(), stopReporting, reportingDone)
+	defer func() {
+		close(stopReporting)
+		<-reportingDone
+	}()
+
+	progress := fc.StartProgress(fcd.isTaskDone())
+
 	// schedule to run the steps
 	var wg sync.WaitGroup
 	for _, taskGroup := range fcd.taskGroups {
@@ -80,6 +131,9 @@ func (fcd *FlowContextDriver) RunFlowContext(fc *flow.FlowContext) {
 
 	wg.Wait()
 
+	progress.Stop()
+	fcd.writeTrace(fc, sched)
+
 }
 
 func (fcd *FlowContextDriver) cleanup(sched *scheduler.Scheduler, fc *flow.FlowContext) {
@@ -92,4 +146,6 @@ func (fcd *FlowContextDriver) cleanup(sched *scheduler.Scheduler, fc *flow.FlowC
 	}
 
 	wg.Wait()
+
+	sched.ReleaseLeases()
 }
@@ -3,6 +3,7 @@ package driver
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/chrislusf/gleam/distributed/driver/scheduler"
@@ -58,6 +59,9 @@ func (fcd *FlowContextDriver) printDistributedStatus(sched *scheduler.Scheduler,
 			fmt.Printf(" %s%d", step.Name, step.Id)
 		}
 		fmt.Print("\n")
+		for _, step := range stepGroup.Steps {
+			printStepStats(step)
+		}
 
 		for _, tg := range stepGroup.TaskGroups {
 			stat := stats[tg.Id]
@@ -72,6 +76,12 @@ func (fcd *FlowContextDriver) printDistributedStatus(sched *scheduler.Scheduler,
 			} else {
 				fmt.Printf("  %s first task:%s time:%v\n", stat.Allocation.Location.URL(), firstTask.Step.Name, stat.TimeTaken())
 			}
+			if stat.PeakMemoryMb > 0 {
+				fmt.Printf("    memory : %d MB peak:%d MB allocated:%d MB\n", stat.MemoryMb, stat.PeakMemoryMb, stat.AllocatedMemoryMb)
+			}
+			if stat.Error != "" {
+				fmt.Printf("    error  : %s\n", stat.Error)
+			}
 			for _, inputStat := range stat.InputChannelStatuses {
 				fmt.Printf("    input  : %s  length:%d\n", inputStat.Name, inputStat.Length)
 			}
@@ -81,9 +91,23 @@ func (fcd *FlowContextDriver) printDistributedStatus(sched *scheduler.Scheduler,
 		}
 
 	}
+	locality := &sched.Locality
+	fmt.Printf("locality: node-local:%d rack-local:%d remote:%d\n",
+		atomic.LoadInt64(&locality.NodeLocal), atomic.LoadInt64(&locality.RackLocal), atomic.LoadInt64(&locality.Remote))
 	fmt.Print("\n")
 }
 
+func printStepStats(step *flow.Step) {
+	stats := step.Stats()
+	fmt.Printf("  %s%d rows in:%d out:%d bytes in:%d out:%d time:%v cpu:%v spill:%d\n",
+		step.Name, step.Id, stats.InputRows, stats.OutputRows, stats.InputBytes, stats.OutputBytes,
+		stats.WallTime, stats.CPUTime, stats.SpillBytes)
+	if step.OutputDataset != nil {
+		rows, bytes := step.OutputDataset.Stats()
+		fmt.Printf("    dataset d%d rows:%d bytes:%d\n", step.OutputDataset.Id, rows, bytes)
+	}
+}
+
 func (fcd *FlowContextDriver) collectStatusFromRemoteExecutors(sched *scheduler.Scheduler) []*RemoteExecutorStatus {
 	stats := make([]*RemoteExecutorStatus, len(fcd.taskGroups))
 	var wg sync.WaitGroup
@@ -131,6 +155,10 @@ func askExecutorStatusForRequest(server string, requestId uint32) (*RemoteExecut
 			StartTime:             time.Unix(response.GetStartTime(), 0),
 			StopTime:              time.Unix(response.GetStopTime(), 0),
 		},
+		MemoryMb:          response.GetMemoryMb(),
+		PeakMemoryMb:      response.GetPeakMemoryMb(),
+		AllocatedMemoryMb: response.GetAllocatedMemoryMb(),
+		Error:             response.GetError(),
 	}, nil
 }
 
@@ -0,0 +1,219 @@
+package driver
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/chrislusf/gleam/distributed/driver/scheduler"
+	"github.com/chrislusf/gleam/distributed/plan"
+	"github.com/chrislusf/gleam/flow"
+	"github.com/chrislusf/gleam/pb"
+)
+
+// drivers report more often than the master considers them lost
+const progressReportInterval = 5 * time.Second
+
+// reportProgress tells the master how the flow is going, until the flow stops.
+// The last report marks the flow completed, or failed if any task group failed.
+func (fcd *FlowContextDriver) reportProgress(fc *flow.FlowContext, sched *scheduler.Scheduler, startTime time.Time, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	hasReportError := false
+	report := func(progress *pb.FlowProgress) {
+		if err := sched.ReportFlowProgress(progress); err != nil && !hasReportError {
+			// older masters do not take progress reports
+			log.Printf("%s Failed to report flow progress: %v", sched.Master, err)
+			hasReportError = true
+		}
+	}
+
+	ticker := time.NewTicker(progressReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			report(fcd.flowProgress(fc, sched, startTime))
+		case <-stop:
+			progress := fcd.flowProgress(fc, sched, startTime)
+			progress.State = pb.StateCompleted
+			for _, tg := range progress.TaskGroups {
+				if tg.State == pb.StateFailed {
+					progress.State, progress.Error = pb.StateFailed, tg.Error
+					break
+				}
+			}
+			progress.StopTime = time.Now().Unix()
+			// the master keeps the last report as the run record
+			var planJSON bytes.Buffer
+			if err := fc.ToJSON(&planJSON); err == nil {
+				progress.Plan = planJSON.String()
+			}
+			report(progress)
+			return
+		}
+	}
+}
+
+// flowProgress describes the steps, datasets and task groups of the flow
+// as a graph, with their states and stats so far.
+func (fcd *FlowContextDriver) flowProgress(fc *flow.FlowContext, sched *scheduler.Scheduler, startTime time.Time) *pb.FlowProgress {
+	progress := &pb.FlowProgress{
+		State:     pb.StateRunning,
+		StartTime: startTime.Unix(),
+	}
+
+	writers := make(map[*flow.DatasetShard]*plan.TaskGroup)
+	locations := make(map[*plan.TaskGroup]*pb.Location)
+	for _, tg := range fcd.taskGroups {
+		tgProgress := taskGroupProgress(sched, tg)
+		progress.TaskGroups = append(progress.TaskGroups, tgProgress)
+		locations[tg] = tgProgress.Location
+		for _, task := range tg.Tasks {
+			for _, shard := range task.OutputShards {
+				writers[shard] = tg
+			}
+		}
+	}
+
+	for _, step := range fc.Steps {
+		stats := step.Stats()
+		stepStats := stats.ToProto()
+		stepStats.StepId, stepStats.Name = int32(step.Id), step.Name
+		progress.Steps = append(progress.Steps, &pb.StepProgress{
+			Id:             int32(step.Id),
+			Name:           step.Name,
+			NetworkType:    step.NetworkType.String(),
+			IsOnDriverSide: step.IsOnDriverSide,
+			TaskCount:      int32(len(step.Tasks)),
+			Stats:          stepStats,
+		})
+	}
+
+	for _, d := range fc.Datasets {
+		dProgress := &pb.DatasetProgress{
+			Id:     int32(d.Id),
+			OnDisk: d.GetIsOnDiskIO(),
+		}
+		if d.Step != nil {
+			dProgress.StepId = int32(d.Step.Id)
+		}
+		for _, step := range d.ReadingSteps {
+			dProgress.ReadingStepIds = append(dProgress.ReadingStepIds, int32(step.Id))
+		}
+		for _, shard := range d.Shards {
+			tg := writers[shard]
+			shardProgress := &pb.DatasetShardProgress{
+				Id:       int32(shard.Id),
+				Status:   shardStatus(tg).String(),
+				Location: locations[tg],
+			}
+			if tg != nil {
+				shardProgress.Rows, shardProgress.Bytes = shardStats(tg, shard)
+			}
+			dProgress.Shards = append(dProgress.Shards, shardProgress)
+		}
+		progress.Datasets = append(progress.Datasets, dProgress)
+	}
+
+	return progress
+}
+
+// isTaskDone returns whether a task is done, i.e., its task group has
+// completed, to print the progress of the flow.
+func (fcd *FlowContextDriver) isTaskDone() func(*flow.Task) bool {
+	taskGroups := make(map[*flow.Task]*plan.TaskGroup)
+	for _, tg := range fcd.taskGroups {
+		for _, task := range tg.Tasks {
+			taskGroups[task] = tg
+		}
+	}
+	return func(task *flow.Task) bool {
+		tg, found := taskGroups[task]
+		return found && tg.State() == pb.StateCompleted
+	}
+}
+
+// shardStatus follows the state of the task group writing the shard.
+func shardStatus(tg *plan.TaskGroup) flow.DatasetShardStatus {
+	if tg == nil {
+		return flow.Untouched
+	}
+	status := tg.Status()
+	switch status.State {
+	case pb.StateRunning:
+		if status.Error != nil {
+			return flow.InRetry
+		}
+		return flow.InProgress
+	case pb.StateCompleted:
+		return flow.Successful
+	case pb.StateFailed:
+		return flow.Failed
+	}
+	if tg.RequestId != 0 {
+		return flow.LocationAssigned
+	}
+	return flow.Untouched
+}
+
+// shardStats returns the rows and bytes written into the shard, known when
+// its task writes only this shard.
+func shardStats(tg *plan.TaskGroup, shard *flow.DatasetShard) (rows, bytes int64) {
+	for _, task := range tg.Tasks {
+		if len(task.OutputShards) == 1 && task.OutputShards[0] == shard {
+			if _, _, stats := task.GetRun(); stats != nil {
+				return stats.OutputRows, stats.OutputBytes
+			}
+		}
+	}
+	return 0, 0
+}
+
+// taskGroupProgress describes the task group from snapshots of it and its
+// tasks, which the scheduler updates while the flow runs.
+func taskGroupProgress(sched *scheduler.Scheduler, tg *plan.TaskGroup) *pb.TaskGroupProgress {
+	status := tg.Status()
+	progress := &pb.TaskGroupProgress{
+		Id:        int32(tg.Id),
+		Name:      tg.String(),
+		State:     status.State,
+		WaitTime:  unixTime(status.WaitAt),
+		StartTime: unixTime(status.StartAt),
+		StopTime:  unixTime(status.StopAt),
+	}
+	for i, task := range tg.Tasks {
+		progress.Steps = append(progress.Steps, fmt.Sprintf("%s%d", task.Step.Name, task.Step.Id))
+		progress.StepIds = append(progress.StepIds, int32(task.Step.Id))
+		// the stats are collected after the task group stops
+		start, stop, stats := task.GetRun()
+		if stats == nil {
+			continue
+		}
+		if i == 0 {
+			progress.InputRows, progress.InputBytes = stats.InputRows, stats.InputBytes
+		}
+		if i == len(tg.Tasks)-1 {
+			progress.OutputRows, progress.OutputBytes = stats.OutputRows, stats.OutputBytes
+		}
+		taskStats := stats.ToProto()
+		taskStats.StepId, taskStats.TaskId, taskStats.Name = int32(task.Step.Id), int32(task.Id), task.Step.Name
+		taskStats.StartTime, taskStats.StopTime = unixNano(start), unixNano(stop)
+		progress.TaskStats = append(progress.TaskStats, taskStats)
+	}
+	if status.Error != nil {
+		progress.Error = status.Error.Error()
+	}
+	if tg.RequestId != 0 {
+		progress.Location = sched.ExecutorLocation(tg.RequestId)
+	}
+	return progress
+}
+
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
@@ -0,0 +1,126 @@
+package driver
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/gleam/distributed/driver/scheduler"
+	"github.com/chrislusf/gleam/distributed/plan"
+	"github.com/chrislusf/gleam/flow"
+	"github.com/chrislusf/gleam/pb"
+)
+
+// trace returns the timeline of the distributed run. Each step group is a
+// process, with the step group itself as the first thread. Each task group
+// follows, with its executor on the same thread, and then its tasks and
+// network channels as threads.
+func (fcd *FlowContextDriver) trace(sched *scheduler.Scheduler) *flow.Trace {
+	t := flow.NewTrace()
+	for i, stepGroup := range fcd.stepGroups {
+		pid := i + 1
+		var steps []string
+		for _, step := range stepGroup.Steps {
+			steps = append(steps, fmt.Sprintf("%s%d", step.Name, step.Id))
+		}
+		name := strings.Join(steps, "-")
+		t.AddProcess(pid, fmt.Sprintf("step group %d: %s", i, name))
+
+		tid := t.AddThread(pid, "step group")
+		start, stop := taskGroupTimes(stepGroup.TaskGroups)
+		t.AddSpan(pid, tid, "stepGroup", name, start, stop, map[string]interface{}{
+			"taskGroups": len(stepGroup.TaskGroups),
+		})
+
+		for _, tg := range stepGroup.TaskGroups {
+			fcd.traceTaskGroup(t, pid, sched, tg)
+		}
+	}
+	return t
+}
+
+func (fcd *FlowContextDriver) traceTaskGroup(t *flow.Trace, pid int, sched *scheduler.Scheduler, tg *plan.TaskGroup) {
+	tid := t.AddThread(pid, fmt.Sprintf("task group %d", tg.Id))
+	tgStatus := tg.Status()
+	args := map[string]interface{}{"state": tgStatus.State}
+	if tgStatus.Error != nil {
+		args["error"] = tgStatus.Error.Error()
+	}
+	t.AddSpan(pid, tid, "taskGroup", tg.String(), tgStatus.StartAt, tgStatus.StopAt, args)
+
+	status, hasExecutor := sched.ExecutorStatus(tg.RequestId)
+	if hasExecutor && status.Allocation != nil {
+		t.AddSpan(pid, tid, "executor", status.Allocation.Location.URL(), status.RequestTime, status.StopTime, map[string]interface{}{
+			"allocatedMemoryMb": status.Allocation.Allocated.GetMemoryMb(),
+			"peakMemoryMb":      status.PeakMemoryMb,
+		})
+	}
+
+	for _, task := range tg.Tasks {
+		tid := t.AddThread(pid, fmt.Sprintf("%s%d.%d", task.Step.Name, task.Step.Id, task.Id))
+		t.AddTask(pid, tid, task)
+	}
+
+	if hasExecutor {
+		traceChannels(t, pid, "input", status.InputStatuses)
+		traceChannels(t, pid, "output", status.OutputStatuses)
+		return
+	}
+	// the channels of the tasks on the driver side
+	firstTask, lastTask := tg.Tasks[0], tg.Tasks[len(tg.Tasks)-1]
+	for _, shard := range firstTask.InputShards {
+		traceShard(t, pid, "input", shard)
+	}
+	for _, shard := range lastTask.OutputShards {
+		traceShard(t, pid, "output", shard)
+	}
+}
+
+func traceChannels(t *flow.Trace, pid int, direction string, statuses []*pb.ChannelStatus) {
+	for _, stat := range FromProto(statuses) {
+		tid := t.AddThread(pid, direction+" "+stat.Name)
+		t.AddSpan(pid, tid, "channel", stat.Name, stat.StartTime, stat.StopTime, map[string]interface{}{
+			"bytes": stat.Length,
+		})
+	}
+}
+
+func traceShard(t *flow.Trace, pid int, direction string, shard *flow.DatasetShard) {
+	if shard.ReadyTime.IsZero() {
+		return
+	}
+	tid := t.AddThread(pid, direction+" "+shard.Name())
+	t.AddSpan(pid, tid, "channel", shard.Name(), shard.ReadyTime, shard.CloseTime, nil)
+}
+
+// taskGroupTimes returns when the first task group started, and when the
+// last one stopped, or zero time if any task group has not stopped.
+func taskGroupTimes(taskGroups []*plan.TaskGroup) (start, stop time.Time) {
+	stopped := true
+	for _, tg := range taskGroups {
+		status := tg.Status()
+		if !status.StartAt.IsZero() && (start.IsZero() || status.StartAt.Before(start)) {
+			start = status.StartAt
+		}
+		if status.StopAt.IsZero() {
+			stopped = false
+		} else if status.StopAt.After(stop) {
+			stop = status.StopAt
+		}
+	}
+	if !stopped {
+		stop = time.Time{}
+	}
+	return
+}
+
+// writeTrace writes the timeline of the run, if asked by the flow.
+func (fcd *FlowContextDriver) writeTrace(fc *flow.FlowContext, sched *scheduler.Scheduler) {
+	if fc.TraceFile == "" {
+		return
+	}
+	if err := fcd.trace(sched).WriteFile(fc.TraceFile); err != nil {
+		log.Printf("Failed to write trace to %s: %v", fc.TraceFile, err)
+	}
+}
@@ -10,17 +10,23 @@ import (
 
 type RemoteExecutorStatus struct {
 	util.ExecutorStatus
-	Allocation *pb.Allocation
-	taskGroup  *plan.TaskGroup
+	Allocation        *pb.Allocation
+	MemoryMb          int64
+	PeakMemoryMb      int64
+	AllocatedMemoryMb int64
+	Error             string
+	taskGroup         *plan.TaskGroup
 }
 
 func ToProto(channelStatuses []*util.ChannelStatus) (ret []*pb.ChannelStatus) {
 	for _, stat := range channelStatuses {
 		ret = append(ret, &pb.ChannelStatus{
-			Length:    stat.Length,
-			StartTime: stat.StartTime.Unix(),
-			StopTime:  stat.StopTime.Unix(),
-			Name:      stat.Name,
+			Length:      stat.Length,
+			StartTime:   stat.StartTime.Unix(),
+			StopTime:    stat.StopTime.Unix(),
+			Name:        stat.Name,
+			StartTimeNs: unixNano(stat.StartTime),
+			StopTimeNs:  unixNano(stat.StopTime),
 		})
 	}
 	return
@@ -30,10 +36,27 @@ func FromProto(channelStatuses []*pb.ChannelStatus) (ret []*util.ChannelStatus)
 	for _, stat := range channelStatuses {
 		ret = append(ret, &util.ChannelStatus{
 			Length:    stat.GetLength(),
-			StartTime: time.Unix(stat.GetStartTime(), 0),
-			StopTime:  time.Unix(stat.GetStopTime(), 0),
+			StartTime: channelTime(stat.GetStartTimeNs(), stat.GetStartTime()),
+			StopTime:  channelTime(stat.GetStopTimeNs(), stat.GetStopTime()),
 			Name:      stat.GetName(),
 		})
 	}
 	return
 }
+
+// unixNano is 0 for the zero time
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// channelTime prefers the time in unix nanoseconds, which older executors
+// do not send, to the time in unix seconds.
+func channelTime(nanoseconds, seconds int64) time.Time {
+	if nanoseconds != 0 {
+		return time.Unix(0, nanoseconds)
+	}
+	return time.Unix(seconds, 0)
+}
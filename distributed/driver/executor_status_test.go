@@ -0,0 +1,28 @@
+package driver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+	"github.com/chrislusf/gleam/util"
+)
+
+func TestChannelStatusTimes(t *testing.T) {
+	start := time.Unix(100, 250*int64(time.Millisecond))
+	statuses := ToProto([]*util.ChannelStatus{{Name: "f1-d1-s0", StartTime: start}})
+	if s := statuses[0]; s.StartTime != 100 || s.StartTimeNs != start.UnixNano() || s.StopTimeNs != 0 {
+		t.Errorf("expecting the times in seconds and nanoseconds, got %+v", s)
+	}
+
+	back := FromProto(statuses)[0]
+	if !back.StartTime.Equal(start) || !back.StopTime.IsZero() {
+		t.Errorf("expecting the nanosecond times back, got %v to %v", back.StartTime, back.StopTime)
+	}
+
+	// older executors send only seconds
+	old := FromProto([]*pb.ChannelStatus{{Name: "f1-d1-s0", StartTime: 100, StopTime: time.Time{}.Unix()}})[0]
+	if !old.StartTime.Equal(time.Unix(100, 0)) || !old.StopTime.IsZero() {
+		t.Errorf("expecting the second times, got %v to %v", old.StartTime, old.StopTime)
+	}
+}
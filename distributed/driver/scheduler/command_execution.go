@@ -7,15 +7,15 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"net"
 
+	"github.com/chrislusf/gleam/distributed/security"
 	"github.com/chrislusf/gleam/pb"
 	"github.com/chrislusf/gleam/util"
 	"github.com/golang/protobuf/proto"
 )
 
 func NewStartRequest(name string, dir string, instructions *pb.InstructionSet,
-	allocated *pb.ComputeResource, envs []string, host string, port int32) *pb.ControlMessage {
+	allocated *pb.ComputeResource, envs []string, config []string, host string, port int32) *pb.ControlMessage {
 
 	request := &pb.ControlMessage{
 		StartRequest: &pb.StartRequest{
@@ -25,6 +25,8 @@ func NewStartRequest(name string, dir string, instructions *pb.InstructionSet,
 			Host:         host,
 			Port:         port,
 			Name:         name,
+			Envs:         envs,
+			Config:       config,
 		},
 	}
 
@@ -39,6 +41,17 @@ func NewGetStatusRequest(requestId uint32) *pb.ControlMessage {
 	}
 }
 
+// NewCollectStatusRequest gets the status after the executor stops, and
+// clears the profiles on the agent, which are collected only once.
+func NewCollectStatusRequest(requestId uint32) *pb.ControlMessage {
+	return &pb.ControlMessage{
+		GetStatusRequest: &pb.GetStatusRequest{
+			StartRequestHash: requestId,
+			ClearProfiles:    true,
+		},
+	}
+}
+
 func NewStopRequest(requestId uint32) *pb.ControlMessage {
 	return &pb.ControlMessage{
 		StopRequest: &pb.StopRequest{
@@ -69,6 +82,7 @@ func RemoteDirectExecute(server string, command *pb.ControlMessage) error {
 func doExecute(server string, conn io.ReadWriteCloser, command *pb.ControlMessage) error {
 
 	// serialize the commend
+	command.Token = security.ClientToken()
 	data, err := proto.Marshal(command)
 	if err != nil {
 		return fmt.Errorf("marshaling execute request error: %v", err)
@@ -113,6 +127,7 @@ func RemoteDirectCommand(server string, command *pb.ControlMessage) (response *p
 func doCommand(server string, conn io.ReadWriteCloser, command *pb.ControlMessage) (response *pb.ControlMessage, err error) {
 
 	// serialize the commend
+	command.Token = security.ClientToken()
 	data, err := proto.Marshal(command)
 	if err != nil {
 		return nil, fmt.Errorf("marshaling command error: %v", err)
@@ -137,10 +152,13 @@ func doCommand(server string, conn io.ReadWriteCloser, command *pb.ControlMessag
 	if err != nil {
 		return nil, fmt.Errorf("unmarshaling error: %v", err)
 	}
+	if response.GetError() != "" {
+		return response, fmt.Errorf("%s: %s", server, response.GetError())
+	}
 
 	return response, err
 }
 
 func getDirectCommandConnection(target string) (io.ReadWriteCloser, error) {
-	return net.Dial("tcp", target)
+	return security.Dial(target)
 }
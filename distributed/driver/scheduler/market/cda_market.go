@@ -117,6 +117,20 @@ func (m *Market) AddSupply(supply Supply) {
 	m.Supplies = append(m.Supplies, supply)
 }
 
+// RemoveSupplies drops the unused supplies matching the filter.
+func (m *Market) RemoveSupplies(filter func(Supply) bool) {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	var kept []Supply
+	for _, supply := range m.Supplies {
+		if !filter(supply) {
+			kept = append(kept, supply)
+		}
+	}
+	m.Supplies = kept
+}
+
 func (m *Market) pickBestSupplyFor(r Requirement) (ret Supply, matched bool) {
 
 	scores := make([]float64, len(m.Supplies))
@@ -2,6 +2,7 @@
 package scheduler
 
 import (
+	"io"
 	"sync"
 	"time"
 
@@ -18,17 +19,28 @@ type Scheduler struct {
 	Option                 *SchedulerOption
 	shardLocator           *DatasetShardLocator
 	RemoteExecutorStatuses map[uint32]*RemoteExecutorStatus
+	leases                 map[uint64]bool
+	leaseDuration          time.Duration
+	deadAgents             map[string]bool
+	hostRacks              map[string]string
+	Locality               LocalityCounts
 }
 
 type RemoteExecutorStatus struct {
-	Request      *pb.ControlMessage
-	Allocation   *pb.Allocation
-	RequestTime  time.Time
-	InputLength  int
-	OutputLength int
-	ReadyTime    time.Time
-	RunTime      time.Time
-	StopTime     time.Time
+	Request        *pb.ControlMessage
+	Allocation     *pb.Allocation
+	RequestTime    time.Time
+	InputLength    int
+	OutputLength   int
+	ReadyTime      time.Time
+	RunTime        time.Time
+	StopTime       time.Time
+	PeakMemoryMb   int64               // measured by the agent
+	Preempted      bool                // stopped by the agent for another queue
+	InputStatuses  []*pb.ChannelStatus // the network channels of the executor
+	OutputStatuses []*pb.ChannelStatus
+
+	conn io.Closer // the running execution
 }
 
 type SchedulerOption struct {
@@ -38,6 +50,15 @@ type SchedulerOption struct {
 	DriverHost   string
 	DriverPort   int
 	Module       string
+	Compression  string
+	Envs         []string
+	Config       []string
+	Queue        string
+	User         string
+	FlowHashCode uint32
+	FlowName     string
+	ProfileDir   string   // profiles of the executors are saved here, if set
+	ProfileSteps []string // profile only the task groups with these steps, or all if empty
 }
 
 func NewScheduler(leader string, option *SchedulerOption) *Scheduler {
@@ -48,6 +69,10 @@ func NewScheduler(leader string, option *SchedulerOption) *Scheduler {
 		shardLocator:           NewDatasetShardLocator(),
 		Option:                 option,
 		RemoteExecutorStatuses: make(map[uint32]*RemoteExecutorStatus),
+		leases:                 make(map[uint64]bool),
+		leaseDuration:          time.Minute,
+		deadAgents:             make(map[string]bool),
+		hostRacks:              make(map[string]string),
 	}
 	s.Market.SetScoreFunction(s.Score).SetFetchFunction(s.Fetch)
 	return s
@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/chrislusf/gleam/distributed/driver/scheduler/market"
+	"github.com/chrislusf/gleam/pb"
+)
+
+func (s *Scheduler) isDeadAgent(location *pb.Location) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.deadAgents[location.URL()]
+}
+
+// markDeadAgents stops using the agents the master found dead: drops their
+// unused allocations, and interrupts the executions on them, so the task
+// groups can be rescheduled.
+func (s *Scheduler) markDeadAgents(locations []*pb.Location) {
+	dead := make(map[string]bool)
+	var interrupted []io.Closer
+
+	s.Lock()
+	for _, location := range locations {
+		if s.deadAgents[location.URL()] {
+			continue
+		}
+		log.Printf("%s Agent %s is dead", s.Master, location.URL())
+		s.deadAgents[location.URL()] = true
+		dead[location.URL()] = true
+	}
+	for _, status := range s.RemoteExecutorStatuses {
+		if status.conn != nil && dead[status.Allocation.Location.URL()] {
+			interrupted = append(interrupted, status.conn)
+		}
+	}
+	s.Unlock()
+
+	if len(dead) == 0 {
+		return
+	}
+	s.Market.RemoveSupplies(func(supply market.Supply) bool {
+		return dead[supply.Object.(*pb.Allocation).Location.URL()]
+	})
+	for _, conn := range interrupted {
+		conn.Close()
+	}
+}
+
+// remoteExecute runs the request on the agent, and can be interrupted
+// if the agent is dead.
+func (s *Scheduler) remoteExecute(status *RemoteExecutorStatus, location *pb.Location, request *pb.ControlMessage) error {
+	if s.isDeadAgent(location) {
+		return fmt.Errorf("agent %s is dead", location.URL())
+	}
+	conn, err := getDirectCommandConnection(location.URL())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	s.Lock()
+	status.conn = conn
+	s.Unlock()
+	defer func() {
+		s.Lock()
+		status.conn = nil
+		s.Unlock()
+	}()
+
+	err = doExecute(location.URL(), conn, request)
+	if s.isDeadAgent(location) {
+		return fmt.Errorf("agent %s is dead", location.URL())
+	}
+	return err
+}
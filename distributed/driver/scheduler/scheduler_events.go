@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"log"
 	"sync"
 
 	"github.com/chrislusf/gleam/distributed/driver/scheduler/market"
@@ -49,7 +50,9 @@ func (s *Scheduler) EventLoop() {
 				lastTask := tasks[len(tasks)-1]
 				if tasks[0].Step.IsOnDriverSide {
 					// these should be only one task on the driver side
+					taskGroup.MarkStart()
 					s.localExecute(event.FlowContext, lastTask, event.WaitGroup)
+					taskGroup.MarkStop(nil)
 				} else {
 					if !needsInputFromDriver(tasks[0]) {
 						// wait until inputs are registed
@@ -64,45 +67,8 @@ func (s *Scheduler) EventLoop() {
 
 					// fmt.Printf("inputs of %s is %s\n", tasks[0].Name(), s.allInputLocations(tasks[0]))
 
-					pickedServerChan := make(chan market.Supply, 1)
-					s.Market.AddDemand(market.Requirement(taskGroup), event.Bid, pickedServerChan)
-
-					// get assigned executor location
-					supply := <-pickedServerChan
-					allocation := supply.Object.(*pb.Allocation)
-					defer s.Market.ReturnSupply(supply)
-
-					if needsInputFromDriver(tasks[0]) {
-						// tell the driver to write to me
-						for _, shard := range tasks[0].InputShards {
-							// println("registering", shard.Name(), "at", allocation.Location.URL())
-							s.SetShardLocation(shard, pb.DataLocation{
-								Name:     shard.Name(),
-								Location: allocation.Location,
-								OnDisk:   shard.Dataset.GetIsOnDiskIO(),
-							})
-						}
-					}
-
-					for _, shard := range lastTask.OutputShards {
-						// println("registering", shard.Name(), "at", allocation.Location.URL(), "onDisk", shard.Dataset.GetIsOnDiskIO())
-						s.SetShardLocation(shard, pb.DataLocation{
-							Name:     shard.Name(),
-							Location: allocation.Location,
-							OnDisk:   shard.Dataset.GetIsOnDiskIO(),
-						})
-					}
-
-					fn := func() error {
-						err := s.remoteExecuteOnLocation(event.FlowContext, taskGroup, allocation, event.WaitGroup)
-						taskGroup.MarkStop(err)
-						return err
-					}
-
-					if isRestartableTasks(tasks) {
-						util.Retry(fn)
-					} else {
-						fn()
+					for s.executeOnNewAllocation(event, taskGroup) {
+						log.Printf("Rescheduling %s", taskGroup)
 					}
 				}
 			}()
@@ -127,6 +93,75 @@ func (s *Scheduler) EventLoop() {
 	}
 }
 
+// executeOnNewAllocation runs the task group on a newly assigned executor.
+// It returns true if the task group needs to be rescheduled, because the
+// agent died or the executor was preempted, and the task group can be
+// restarted.
+func (s *Scheduler) executeOnNewAllocation(event SubmitTaskGroup, taskGroup *plan.TaskGroup) (reschedule bool) {
+	tasks := taskGroup.Tasks
+	lastTask := tasks[len(tasks)-1]
+
+	taskGroup.MarkWaiting()
+	pickedServerChan := make(chan market.Supply, 1)
+	s.Market.AddDemand(market.Requirement(taskGroup), event.Bid, pickedServerChan)
+
+	// get assigned executor location
+	supply := <-pickedServerChan
+	allocation := supply.Object.(*pb.Allocation)
+	s.Locality.add(s.localityOf(taskGroup, allocation.Location))
+	var preempted bool
+	defer func() {
+		// the resources on dead agents are gone, and the preempted
+		// resources are given back to the master for another queue
+		if preempted {
+			s.releaseLease(allocation.LeaseId)
+		} else if !s.isDeadAgent(allocation.Location) {
+			s.Market.ReturnSupply(supply)
+		}
+	}()
+
+	if needsInputFromDriver(tasks[0]) {
+		// tell the driver to write to me
+		for _, shard := range tasks[0].InputShards {
+			// println("registering", shard.Name(), "at", allocation.Location.URL())
+			s.SetShardLocation(shard, pb.DataLocation{
+				Name:     shard.Name(),
+				Location: allocation.Location,
+				OnDisk:   shard.Dataset.GetIsOnDiskIO(),
+			})
+		}
+	}
+
+	for _, shard := range lastTask.OutputShards {
+		// println("registering", shard.Name(), "at", allocation.Location.URL(), "onDisk", shard.Dataset.GetIsOnDiskIO())
+		s.SetShardLocation(shard, pb.DataLocation{
+			Name:     shard.Name(),
+			Location: allocation.Location,
+			OnDisk:   shard.Dataset.GetIsOnDiskIO(),
+		})
+	}
+
+	fn := func() error {
+		taskGroup.MarkStart()
+		err := s.remoteExecuteOnLocation(event.FlowContext, taskGroup, allocation, event.WaitGroup)
+		taskGroup.MarkStop(err)
+		if err == errPreempted {
+			// the allocation is taken, so retrying on it fails again
+			preempted = true
+			return util.NoRetry(err)
+		}
+		return err
+	}
+
+	if !isRestartableTasks(tasks) {
+		fn()
+		return false
+	}
+
+	util.Retry(fn)
+	return taskGroup.Status().Error != nil && (preempted || s.isDeadAgent(allocation.Location))
+}
+
 func needsInputFromDriver(task *flow.Task) bool {
 	for _, shard := range task.InputShards {
 		if shard.Dataset.Step.IsOnDriverSide {
@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"errors"
 	"log"
 	"os"
 	"sync"
@@ -9,12 +10,17 @@ import (
 	"github.com/chrislusf/gleam/distributed/netchan"
 	"github.com/chrislusf/gleam/distributed/plan"
 	"github.com/chrislusf/gleam/flow"
+	"github.com/chrislusf/gleam/instruction"
 	"github.com/chrislusf/gleam/pb"
 	"github.com/chrislusf/gleam/util"
 )
 
+// errPreempted tells the executor is stopped by the agent, to give the
+// allocation to another queue.
+var errPreempted = errors.New("executor is preempted")
+
 func (s *Scheduler) remoteExecuteOnLocation(flowContext *flow.FlowContext, taskGroup *plan.TaskGroup,
-	allocation *pb.Allocation, wg *sync.WaitGroup) error {
+	allocation *pb.Allocation, wg *sync.WaitGroup) (err error) {
 
 	// s.setupInputChannels(flowContext, tasks[0], allocation.Location, wg)
 
@@ -50,17 +56,23 @@ func (s *Scheduler) remoteExecuteOnLocation(flowContext *flow.FlowContext, taskG
 	lastInstruction.SetOutputLocations(outputLocations)
 
 	instructions.FlowHashCode = flowContext.HashCode
-	instructions.IsProfiling = false // enable this when profiling executors
+	instructions.IsProfiling = s.isProfiling(taskGroup)
+	instructions.Compression = s.Option.Compression
 
 	request := NewStartRequest(
 		taskGroup.String(),
 		s.Option.Module,
 		instructions,
 		allocation.Allocated,
-		os.Environ(),
+		s.Option.Envs,
+		s.Option.Config,
 		s.Option.DriverHost,
 		int32(s.Option.DriverPort),
 	)
+	request.StartRequest.Queue = s.Option.Queue
+	request.StartRequest.LeaseId = allocation.LeaseId
+	request.StartRequest.TaskGroupId = int32(taskGroup.Id)
+	request.StartRequest.Restartable = isRestartableTasks(taskGroup.Tasks)
 
 	status, isOld := s.getRemoteExecutorStatus(instructions.HashCode())
 	if isOld {
@@ -75,9 +87,13 @@ func (s *Scheduler) remoteExecuteOnLocation(flowContext *flow.FlowContext, taskG
 
 	defer func() {
 		status.StopTime = time.Now()
+		s.collectExecutorStatus(allocation, taskGroup, status)
+		if err != nil && status.Preempted {
+			err = errPreempted
+		}
 	}()
 
-	if err := RemoteDirectExecute(allocation.Location.URL(), request); err != nil {
+	if err := s.remoteExecute(status, allocation.Location, request); err != nil {
 		log.Printf("remote exeuction error %v: %v", err, request)
 		return err
 	}
@@ -85,6 +101,49 @@ func (s *Scheduler) remoteExecuteOnLocation(flowContext *flow.FlowContext, taskG
 	return nil
 }
 
+// collectExecutorStatus asks the agent for the memory actually used by the executor,
+// to help tune the memory estimation of the tasks, and for the instruction stats
+// and profiles.
+func (s *Scheduler) collectExecutorStatus(allocation *pb.Allocation, taskGroup *plan.TaskGroup, status *RemoteExecutorStatus) {
+	reply, err := RemoteDirectCommand(allocation.Location.URL(), NewCollectStatusRequest(taskGroup.RequestId))
+	if err != nil {
+		log.Printf("Failed to get executor status from %s: %v", allocation.Location.URL(), err)
+		return
+	}
+	response := reply.GetGetStatusResponse()
+	status.PeakMemoryMb = response.GetPeakMemoryMb()
+	status.Preempted = response.GetPreempted()
+	status.InputStatuses = response.GetInputStatuses()
+	status.OutputStatuses = response.GetOutputStatuses()
+	if response.GetPeakMemoryMb() > allocation.Allocated.GetMemoryMb() {
+		log.Printf("%s used %d MB memory, more than allocated %d MB", taskGroup, response.GetPeakMemoryMb(), allocation.Allocated.GetMemoryMb())
+	}
+	taskGroup.ParentStepGroup.RecordPeakMemory(response.GetPeakMemoryMb())
+	recordInstructionStats(taskGroup, response.GetInstructionStats())
+	if len(response.GetProfiles()) > 0 {
+		s.saveProfiles(taskGroup, response.GetProfiles())
+	}
+}
+
+// recordInstructionStats keeps the stats and running time of each instruction
+// on its task. The stats of a rerun task replace the earlier ones.
+func recordInstructionStats(taskGroup *plan.TaskGroup, stats []*pb.InstructionStat) {
+	for _, stat := range stats {
+		for _, task := range taskGroup.Tasks {
+			if task.Step.Id == int(stat.GetStepId()) && task.Id == int(stat.GetTaskId()) {
+				task.SetRun(unixNanoTime(stat.GetStartTime()), unixNanoTime(stat.GetStopTime()), instruction.StatsFromProto(stat))
+			}
+		}
+	}
+}
+
+func unixNanoTime(t int64) time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, t)
+}
+
 func (s *Scheduler) localExecute(flowContext *flow.FlowContext, task *flow.Task, wg *sync.WaitGroup) {
 	if task.Step.OutputDataset == nil {
 		s.localExecuteOutput(flowContext, task, wg)
@@ -102,7 +161,9 @@ func (s *Scheduler) localExecuteSource(flowContext *flow.FlowContext, task *flow
 		wg.Add(1)
 		go func(shard *flow.DatasetShard) {
 			// println(task.Step.Name, "writing to", shard.Name(), "at", location.URL())
-			if err := netchan.DialWriteChannel(wg, "driver_input", location.Location.URL(), shard.Name(), shard.Dataset.GetIsOnDiskIO(), shard.IncomingChan.Reader, len(shard.ReadingTasks)); err != nil {
+			shard.ReadyTime = time.Now()
+			defer func() { shard.CloseTime = time.Now() }()
+			if err := netchan.DialWriteChannel(wg, "driver_input", location.Location.URL(), shard.Name(), shard.Dataset.GetIsOnDiskIO(), s.Option.Compression, shard.IncomingChan.Reader, len(shard.ReadingTasks)); err != nil {
 				println("starting:", task.Step.Name, "output location:", location.Location.URL(), shard.Name(), "error:", err.Error())
 			}
 		}(shard)
@@ -121,7 +182,9 @@ func (s *Scheduler) localExecuteOutput(flowContext *flow.FlowContext, task *flow
 		wg.Add(1)
 		go func(shard *flow.DatasetShard) {
 			// println(task.Step.Name, "reading from", shard.Name(), "at", location.Location.URL(), "to", inChan, "onDisk", shard.Dataset.GetIsOnDiskIO())
-			if err := netchan.DialReadChannel(wg, "driver_output", location.Location.URL(), shard.Name(), shard.Dataset.GetIsOnDiskIO(), inChan.Writer); err != nil {
+			shard.ReadyTime = time.Now()
+			defer func() { shard.CloseTime = time.Now() }()
+			if err := netchan.DialReadChannel(wg, "driver_output", location.Location.URL(), shard.Name(), shard.Dataset.GetIsOnDiskIO(), s.Option.Compression, inChan.Writer); err != nil {
 				println("starting:", task.Step.Name, "input location:", location.Location.URL(), shard.Name(), "error:", err.Error())
 			}
 		}(shard)
@@ -1,12 +1,15 @@
 package scheduler
 
 import (
+	"fmt"
 	"log"
 	"math/rand"
+	"os"
 	"time"
 
 	"github.com/chrislusf/gleam/distributed/driver/scheduler/market"
 	"github.com/chrislusf/gleam/distributed/plan"
+	"github.com/chrislusf/gleam/distributed/security"
 	"github.com/chrislusf/gleam/pb"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -17,10 +20,18 @@ import (
 func (s *Scheduler) Fetch(demands []market.Demand) {
 	var request pb.ComputeRequest
 	request.DataCenter = s.Option.DataCenter
+	request.Queue = s.Option.Queue
+	request.User = s.Option.User
+	request.FlowHashCode = s.Option.FlowHashCode
+	request.FlowName = s.Option.FlowName
+	request.Driver = s.driverAddress()
 	for _, d := range demands {
 		taskGroup := d.Requirement.(*plan.TaskGroup)
 		requiredResource := taskGroup.RequiredResources()
 		request.ComputeResources = append(request.ComputeResources, requiredResource)
+		request.HostPreferences = append(request.HostPreferences, &pb.HostPreference{
+			Hosts: s.preferredHosts(taskGroup),
+		})
 	}
 
 	result, err := Assign(s.Master, &request)
@@ -35,6 +46,8 @@ func (s *Scheduler) Fetch(demands []market.Demand) {
 			if s.Option.DataCenter == "" {
 				s.Option.DataCenter = result.Allocations[0].Location.DataCenter
 			}
+			s.addLeases(result)
+			s.addHostRacks(result)
 			var allocatedMemory int64
 			for _, allocation := range result.Allocations {
 				s.Market.AddSupply(market.Supply{
@@ -47,9 +60,18 @@ func (s *Scheduler) Fetch(demands []market.Demand) {
 	}
 }
 
+// driverAddress is where the agents fetch the executable files from.
+func (s *Scheduler) driverAddress() string {
+	host := s.Option.DriverHost
+	if host == "" {
+		host, _ = os.Hostname()
+	}
+	return fmt.Sprintf("%s:%d", host, s.Option.DriverPort)
+}
+
 func Assign(master string, request *pb.ComputeRequest) (*pb.AllocationResult, error) {
 
-	conn, err := grpc.Dial(master, grpc.WithInsecure())
+	conn, err := grpc.Dial(master, security.GrpcDialOptions()...)
 	if err != nil {
 		log.Printf("fail to dial %s: %v", master, err)
 	}
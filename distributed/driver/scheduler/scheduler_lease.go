@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+
+	"github.com/chrislusf/gleam/distributed/security"
+	"github.com/chrislusf/gleam/pb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// addLeases keeps track of the leases of new allocations, to renew them.
+func (s *Scheduler) addLeases(result *pb.AllocationResult) {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, allocation := range result.Allocations {
+		if allocation.LeaseId != 0 {
+			s.leases[allocation.LeaseId] = true
+		}
+	}
+	if result.LeaseSeconds > 0 {
+		s.leaseDuration = time.Duration(result.LeaseSeconds) * time.Second
+	}
+}
+
+func (s *Scheduler) getLeases() (leaseIds []uint64, leaseDuration time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+
+	for id := range s.leases {
+		leaseIds = append(leaseIds, id)
+	}
+	return leaseIds, s.leaseDuration
+}
+
+// RenewLeasesLoop renews the leases several times within the lease duration.
+// If the driver dies, the master reclaims the resources after the leases
+// expire, and the agents kill the executors.
+func (s *Scheduler) RenewLeasesLoop() {
+	for {
+		leaseIds, leaseDuration := s.getLeases()
+		time.Sleep(leaseDuration / 3)
+		if len(leaseIds) == 0 {
+			continue
+		}
+		response, err := s.callLeases(leaseIds, false)
+		if err != nil {
+			log.Printf("%s Failed to renew leases: %v", s.Master, err)
+			continue
+		}
+		if response.FlowKilled {
+			log.Fatalf("%s Flow %d is killed.", s.Master, s.Option.FlowHashCode)
+		}
+		if len(response.DeadAgents) > 0 {
+			s.markDeadAgents(response.DeadAgents)
+		}
+		if len(response.ExpiredLeaseIds) > 0 {
+			log.Printf("%s Leases expired: %v", s.Master, response.ExpiredLeaseIds)
+			s.Lock()
+			for _, id := range response.ExpiredLeaseIds {
+				delete(s.leases, id)
+			}
+			s.Unlock()
+		}
+	}
+}
+
+// ReleaseLeases returns all allocations to the master.
+func (s *Scheduler) ReleaseLeases() {
+	leaseIds, _ := s.getLeases()
+	if len(leaseIds) == 0 {
+		return
+	}
+	if _, err := s.callLeases(leaseIds, true); err != nil {
+		log.Printf("%s Failed to release leases: %v", s.Master, err)
+		return
+	}
+	s.Lock()
+	for _, id := range leaseIds {
+		delete(s.leases, id)
+	}
+	s.Unlock()
+}
+
+// releaseLease returns one allocation to the master, e.g., after its
+// executor is preempted, so the master can give it to another queue.
+func (s *Scheduler) releaseLease(leaseId uint64) {
+	if leaseId == 0 {
+		return
+	}
+	if _, err := s.callLeases([]uint64{leaseId}, true); err != nil {
+		log.Printf("%s Failed to release lease %d: %v", s.Master, leaseId, err)
+	}
+	s.Lock()
+	delete(s.leases, leaseId)
+	s.Unlock()
+}
+
+func (s *Scheduler) callLeases(leaseIds []uint64, release bool) (*pb.LeaseResponse, error) {
+	conn, err := grpc.Dial(s.Master, security.GrpcDialOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := pb.NewGleamMasterClient(conn)
+
+	request := &pb.LeaseRequest{
+		LeaseIds:     leaseIds,
+		FlowHashCode: s.Option.FlowHashCode,
+	}
+	if release {
+		return client.ReleaseLeases(context.Background(), request)
+	}
+	return client.RenewLeases(context.Background(), request)
+}
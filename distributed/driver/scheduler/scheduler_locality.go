@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"sync/atomic"
+
+	"github.com/chrislusf/gleam/distributed/plan"
+	"github.com/chrislusf/gleam/flow"
+	"github.com/chrislusf/gleam/pb"
+)
+
+type Locality int
+
+const (
+	NoPreference Locality = iota
+	NodeLocal
+	RackLocal
+	Remote
+)
+
+// LocalityCounts counts the task groups by how close they ran to their inputs.
+type LocalityCounts struct {
+	NodeLocal int64
+	RackLocal int64
+	Remote    int64
+}
+
+func (c *LocalityCounts) add(locality Locality) {
+	switch locality {
+	case NodeLocal:
+		atomic.AddInt64(&c.NodeLocal, 1)
+	case RackLocal:
+		atomic.AddInt64(&c.RackLocal, 1)
+	case Remote:
+		atomic.AddInt64(&c.Remote, 1)
+	}
+}
+
+// localityFactor multiplies the cost of running away from the inputs.
+func localityFactor(locality Locality) float64 {
+	switch locality {
+	case RackLocal:
+		return 2
+	case Remote:
+		return 4
+	}
+	return 1
+}
+
+// preferredHosts returns the hosts with local access to the task group's
+// inputs: the preferred hosts of input splits, and the locations of
+// input shards already written by executors.
+func (s *Scheduler) preferredHosts(tg *plan.TaskGroup) (hosts []string) {
+	firstTask := tg.Tasks[0]
+	hosts = append(hosts, firstTask.PreferredHosts...)
+	for _, location := range s.inputLocations(firstTask) {
+		hosts = append(hosts, location.Server)
+	}
+	return
+}
+
+func (s *Scheduler) inputLocations(task *flow.Task) (locations []*pb.Location) {
+	for _, input := range task.InputShards {
+		if input.Dataset.Step.IsOnDriverSide {
+			continue
+		}
+		if dataLocation, found := s.getShardLocation(input); found {
+			locations = append(locations, dataLocation.Location)
+		}
+	}
+	return
+}
+
+// localityOf checks how close the location is to the task group's inputs.
+func (s *Scheduler) localityOf(tg *plan.TaskGroup, loc *pb.Location) Locality {
+	firstTask := tg.Tasks[0]
+	inputLocations := s.inputLocations(firstTask)
+	if len(firstTask.PreferredHosts) == 0 && len(inputLocations) == 0 {
+		return NoPreference
+	}
+
+	for _, host := range firstTask.PreferredHosts {
+		if loc.IsOnHost(host) {
+			return NodeLocal
+		}
+	}
+	for _, input := range inputLocations {
+		if input.DataCenter == loc.DataCenter && input.Server == loc.Server {
+			return NodeLocal
+		}
+	}
+
+	for _, host := range firstTask.PreferredHosts {
+		if rack, found := s.rackOfHost(host); found && rack == loc.Rack {
+			return RackLocal
+		}
+	}
+	for _, input := range inputLocations {
+		if input.DataCenter == loc.DataCenter && input.Rack == loc.Rack {
+			return RackLocal
+		}
+	}
+	return Remote
+}
+
+// addHostRacks remembers the racks of the allocated agents.
+func (s *Scheduler) addHostRacks(result *pb.AllocationResult) {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, allocation := range result.Allocations {
+		s.hostRacks[allocation.Location.Server] = allocation.Location.Rack
+	}
+}
+
+func (s *Scheduler) rackOfHost(host string) (string, bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	for server, rack := range s.hostRacks {
+		location := pb.Location{Server: server}
+		if location.IsOnHost(host) {
+			return rack, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chrislusf/gleam/distributed/plan"
+	"github.com/chrislusf/gleam/pb"
+)
+
+// isProfiling tells whether to profile the executor of the task group,
+// which runs any of the steps to profile.
+func (s *Scheduler) isProfiling(taskGroup *plan.TaskGroup) bool {
+	if s.Option.ProfileDir == "" {
+		return false
+	}
+	if len(s.Option.ProfileSteps) == 0 {
+		return true
+	}
+	for _, task := range taskGroup.Tasks {
+		for _, name := range s.Option.ProfileSteps {
+			if name == task.Step.Name || name == fmt.Sprintf("%s%d", task.Step.Name, task.Step.Id) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// saveProfiles writes the profiles of the executor into the profile
+// directory, named by the steps and shards of the task group, e.g.,
+// "Map3.0-LocalSort4.0.cpu.pprof". The profiles of a rerun task group
+// replace the earlier ones.
+func (s *Scheduler) saveProfiles(taskGroup *plan.TaskGroup, profiles []*pb.Profile) {
+	if err := os.MkdirAll(s.Option.ProfileDir, 0755); err != nil {
+		log.Printf("Failed to create profile directory %s: %v", s.Option.ProfileDir, err)
+		return
+	}
+	var names []string
+	for _, task := range taskGroup.Tasks {
+		names = append(names, fmt.Sprintf("%s%d.%d", task.Step.Name, task.Step.Id, task.Id))
+	}
+	for _, profile := range profiles {
+		fileName := filepath.Join(s.Option.ProfileDir, fmt.Sprintf("%s.%s.pprof", strings.Join(names, "-"), profile.GetName()))
+		if err := ioutil.WriteFile(fileName, profile.GetData(), 0644); err != nil {
+			log.Printf("Failed to save profile of %s: %v", taskGroup, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"github.com/chrislusf/gleam/distributed/security"
+	"github.com/chrislusf/gleam/pb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// ReportFlowProgress sends the progress of the flow to the master,
+// identifying the flow the same way as its resource requests.
+// The master replies where the flow's shards on drained agents moved to.
+func (s *Scheduler) ReportFlowProgress(progress *pb.FlowProgress) error {
+	progress.FlowHashCode = s.Option.FlowHashCode
+	progress.Name = s.Option.FlowName
+	progress.User = s.Option.User
+	progress.Queue = s.Option.Queue
+	progress.Driver = s.driverAddress()
+
+	conn, err := grpc.Dial(s.Master, security.GrpcDialOptions()...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := pb.NewGleamMasterClient(conn)
+
+	response, err := client.ReportFlowProgress(context.Background(), progress)
+	if err != nil {
+		return err
+	}
+	for _, location := range response.GetMigratedShards() {
+		if _, found := s.shardLocator.GetShardLocation(location.Name); found {
+			s.shardLocator.SetShardLocation(location.Name, *location)
+		}
+	}
+	return nil
+}
+
+// ExecutorLocation returns where the request is running or ran last.
+func (s *Scheduler) ExecutorLocation(requestId uint32) *pb.Location {
+	s.Lock()
+	defer s.Unlock()
+
+	status, found := s.RemoteExecutorStatuses[requestId]
+	if !found || status.Allocation == nil {
+		return nil
+	}
+	return status.Allocation.Location
+}
+
+// ExecutorStatus returns a copy of the status of the request's execution.
+func (s *Scheduler) ExecutorStatus(requestId uint32) (status RemoteExecutorStatus, found bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	current, found := s.RemoteExecutorStatuses[requestId]
+	if !found {
+		return
+	}
+	return *current, true
+}
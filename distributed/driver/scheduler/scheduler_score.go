@@ -14,9 +14,15 @@ func (s *Scheduler) Score(r market.Requirement, bid float64, obj market.Object)
 	if memCost > alloc.Allocated.MemoryMb {
 		return -1
 	}
+	required := tg.RequiredCompute()
+	if !alloc.Allocated.Accommodates(required) {
+		return -1
+	}
 
 	firstTask := tg.Tasks[0]
 	cost := float64(alloc.Allocated.MemoryMb-memCost) * 10
+	// a spare cpu core costs as much as 1GB spare memory
+	cost += float64(alloc.Allocated.CpuCount-required.CpuCount) * 1024 * 10
 	for _, input := range firstTask.InputShards {
 		dataLocation, found := s.getShardLocation(input)
 		if !found {
@@ -25,6 +31,11 @@ func (s *Scheduler) Score(r market.Requirement, bid float64, obj market.Object)
 		}
 		cost += dataLocation.Location.Distance(loc)
 	}
+	cost = (cost + 1) * localityFactor(s.localityOf(tg, loc))
+	if isCPUHeavy(required) && alloc.Allocated.CpuLevel > 1 {
+		// prefer faster cpus for cpu heavy task groups
+		cost /= float64(alloc.Allocated.CpuLevel)
+	}
 	return float64(bid) / cost
 }
 
@@ -36,3 +47,7 @@ func memoryCost(tg *plan.TaskGroup) (cost int64) {
 	}
 	return
 }
+
+func isCPUHeavy(required pb.ComputeResource) bool {
+	return required.CpuCount > 1 || required.CpuLevel > 1
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"sync"
+	"sync/atomic"
 
 	"github.com/chrislusf/gleam/distributed/netchan"
 	"github.com/chrislusf/gleam/instruction"
@@ -32,6 +33,11 @@ type Executor struct {
 	Option       *ExecutorOption
 	Master       string
 	instructions *pb.InstructionSet
+	stats        []*instructionStats
+
+	sync.Mutex
+	inputStatuses  []*util.ChannelStatus
+	outputStatuses []*util.ChannelStatus
 }
 
 func NewExecutor(option *ExecutorOption, instructions *pb.InstructionSet) *Executor {
@@ -48,6 +54,15 @@ func (exe *Executor) ExecuteInstructionSet() error {
 	ioErrChan := make(chan error, 2*len(exe.instructions.GetInstructions()))
 	finishedChan := make(chan bool, 1)
 
+	exe.stats = nil
+	exe.inputStatuses, exe.outputStatuses = nil, nil
+	for _, instruction := range exe.instructions.GetInstructions() {
+		exe.stats = append(exe.stats, &instructionStats{
+			instruction: instruction,
+			isScript:    instruction.GetScript() != nil,
+		})
+	}
+
 	prevIsPipe := false
 	prevOutputChan := util.NewPiper()
 	for index, instruction := range exe.instructions.GetInstructions() {
@@ -61,6 +76,7 @@ func (exe *Executor) ExecuteInstructionSet() error {
 				index == 0,
 				index == len(exe.instructions.GetInstructions())-1,
 				int(exe.instructions.GetReaderCount()),
+				exe.stats[index],
 			)
 		}(index, instruction, prevIsPipe, inputChan, outputChan)
 		prevOutputChan = outputChan
@@ -91,8 +107,8 @@ func (exe *Executor) ExecuteInstructionSet() error {
 	return nil
 }
 
-func setupReaders(wg *sync.WaitGroup, ioErrChan chan error,
-	i *pb.Instruction, inPiper *util.Piper, isFirst bool) (readers []io.Reader) {
+func (exe *Executor) setupReaders(wg *sync.WaitGroup, ioErrChan chan error,
+	i *pb.Instruction, inPiper *util.Piper, isFirst bool, compression string) (readers []io.Reader) {
 
 	if !isFirst {
 		readers = append(readers, inPiper.Reader)
@@ -101,8 +117,11 @@ func setupReaders(wg *sync.WaitGroup, ioErrChan chan error,
 			wg.Add(1)
 			inChan := util.NewPiper()
 			// println(i.GetName(), "connecting to", inputLocation.Address(), "to read", inputLocation.GetName())
+			status := exe.newChannelStatus(&exe.inputStatuses, inputLocation.GetName())
 			go func(inputLocation *pb.DatasetShardLocation) {
-				err := netchan.DialReadChannel(wg, i.GetName(), inputLocation.Address(), inputLocation.GetName(), inputLocation.GetOnDisk(), inChan.Writer)
+				status.ReportStart()
+				defer status.ReportClose()
+				err := netchan.DialReadChannel(wg, i.GetName(), inputLocation.Address(), inputLocation.GetName(), inputLocation.GetOnDisk(), compression, &channelWriter{inChan.Writer, status})
 				if err != nil {
 					ioErrChan <- fmt.Errorf("Failed %s reading %s from %s: %v", i.GetName(), inputLocation.GetName(), inputLocation.Address(), err)
 				}
@@ -112,8 +131,8 @@ func setupReaders(wg *sync.WaitGroup, ioErrChan chan error,
 	}
 	return
 }
-func setupWriters(wg *sync.WaitGroup, ioErrChan chan error,
-	i *pb.Instruction, outPiper *util.Piper, isLast bool, readerCount int) (writers []io.Writer) {
+func (exe *Executor) setupWriters(wg *sync.WaitGroup, ioErrChan chan error,
+	i *pb.Instruction, outPiper *util.Piper, isLast bool, readerCount int, compression string) (writers []io.Writer) {
 
 	if !isLast {
 		writers = append(writers, outPiper.Writer)
@@ -122,8 +141,11 @@ func setupWriters(wg *sync.WaitGroup, ioErrChan chan error,
 			wg.Add(1)
 			outChan := util.NewPiper()
 			// println(i.GetName(), "connecting to", outputLocation.Address(), "to write", outputLocation.GetName(), "readerCount", readerCount)
+			status := exe.newChannelStatus(&exe.outputStatuses, outputLocation.GetName())
 			go func(outputLocation *pb.DatasetShardLocation) {
-				err := netchan.DialWriteChannel(wg, i.GetName(), outputLocation.Address(), outputLocation.GetName(), outputLocation.GetOnDisk(), outChan.Reader, readerCount)
+				status.ReportStart()
+				defer status.ReportClose()
+				err := netchan.DialWriteChannel(wg, i.GetName(), outputLocation.Address(), outputLocation.GetName(), outputLocation.GetOnDisk(), compression, &channelReader{outChan.Reader, status}, readerCount)
 				if err != nil {
 					ioErrChan <- fmt.Errorf("Failed %s writing %s to %s: %v", i.GetName(), outputLocation.GetName(), outputLocation.Address(), err)
 				}
@@ -135,12 +157,18 @@ func setupWriters(wg *sync.WaitGroup, ioErrChan chan error,
 }
 
 func (exe *Executor) ExecuteInstruction(wg *sync.WaitGroup, ioErrChan, exeErrChan chan error,
-	inChan, outChan *util.Piper, prevIsPipe bool, i *pb.Instruction, isFirst, isLast bool, readerCount int) {
+	inChan, outChan *util.Piper, prevIsPipe bool, i *pb.Instruction, isFirst, isLast bool, readerCount int,
+	stats *instructionStats) {
 
 	defer wg.Done()
 
-	readers := setupReaders(wg, ioErrChan, i, inChan, isFirst)
-	writers := setupWriters(wg, ioErrChan, i, outChan, isLast, readerCount)
+	readers := exe.setupReaders(wg, ioErrChan, i, inChan, isFirst, exe.instructions.GetCompression())
+	writers := exe.setupWriters(wg, ioErrChan, i, outChan, isLast, readerCount, exe.instructions.GetCompression())
+
+	readers = countReaders(readers, &stats.input, prevIsPipe)
+	writers = countWriters(writers, &stats.output, i.GetScript().GetIsPipe())
+	stats.start()
+	defer stats.stop()
 
 	defer func() {
 		for _, writer := range writers {
@@ -152,9 +180,7 @@ func (exe *Executor) ExecuteInstruction(wg *sync.WaitGroup, ioErrChan, exeErrCha
 
 	util.BufWrites(writers, func(writers []io.Writer) {
 		if f := instruction.InstructionRunner.GetInstructionFunction(i); f != nil {
-			//TODO use the stats
-			stats := &instruction.Stats{}
-			err := f(readers, writers, stats)
+			err := f(readers, writers, &stats.stats)
 			if err != nil {
 				// println(i.GetName(), "running error", err.Error())
 				exeErrChan <- fmt.Errorf("Failed executing %s: %v", i.GetName(), err)
@@ -171,6 +197,9 @@ func (exe *Executor) ExecuteInstruction(wg *sync.WaitGroup, ioErrChan, exeErrCha
 			)
 			wg.Add(1)
 			util.Execute(wg, i.GetName(), command, readers[0], writers[0], prevIsPipe, i.GetScript().GetIsPipe(), false, os.Stderr)
+			if command.ProcessState != nil {
+				atomic.StoreInt64(&stats.cpuTime, int64(command.ProcessState.UserTime()+command.ProcessState.SystemTime()))
+			}
 
 		} else {
 			panic("what is this? " + i.String())
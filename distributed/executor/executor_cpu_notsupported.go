@@ -0,0 +1,12 @@
+// +build !linux
+
+package executor
+
+import (
+	"time"
+)
+
+// processCPUTime is only measured on linux.
+func processCPUTime() time.Duration {
+	return 0
+}
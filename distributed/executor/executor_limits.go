@@ -0,0 +1,29 @@
+package executor
+
+import (
+	"strconv"
+)
+
+// Limits are the rlimits of an executor. The agent passes them as command
+// line flags, and the executor applies them to itself before it reads its
+// instructions, so no instruction or script ever runs without the limits.
+// The scripts started by the executor inherit the same limits.
+type Limits struct {
+	AddressSpaceBytes uint64 // 0 means unlimited
+	OpenFiles         uint64
+	CPUSeconds        uint64
+}
+
+// Args returns the command line flags to pass the limits to "gleam execute".
+func (l Limits) Args() (args []string) {
+	if l.AddressSpaceBytes > 0 {
+		args = append(args, "--limit.addressSpace", strconv.FormatUint(l.AddressSpaceBytes, 10))
+	}
+	if l.OpenFiles > 0 {
+		args = append(args, "--limit.openFiles", strconv.FormatUint(l.OpenFiles, 10))
+	}
+	if l.CPUSeconds > 0 {
+		args = append(args, "--limit.cpuSeconds", strconv.FormatUint(l.CPUSeconds, 10))
+	}
+	return
+}
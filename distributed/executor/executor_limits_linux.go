@@ -0,0 +1,28 @@
+package executor
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// Apply sets the limits on the current process.
+func (l Limits) Apply() error {
+	set := func(resource int, value uint64) error {
+		if value == 0 {
+			return nil
+		}
+		rlimit := &unix.Rlimit{Cur: value, Max: value}
+		if err := unix.Setrlimit(resource, rlimit); err != nil {
+			return fmt.Errorf("setrlimit %d: %v", resource, err)
+		}
+		return nil
+	}
+	if err := set(unix.RLIMIT_AS, l.AddressSpaceBytes); err != nil {
+		return err
+	}
+	if err := set(unix.RLIMIT_NOFILE, l.OpenFiles); err != nil {
+		return err
+	}
+	return set(unix.RLIMIT_CPU, l.CPUSeconds)
+}
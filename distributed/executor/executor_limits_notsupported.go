@@ -0,0 +1,7 @@
+// +build !linux
+
+package executor
+
+func (l Limits) Apply() error {
+	return nil
+}
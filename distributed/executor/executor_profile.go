@@ -0,0 +1,41 @@
+package executor
+
+import (
+	"bytes"
+	"runtime"
+	"runtime/pprof"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+// Profiler records the CPU profile of the executor while it runs, and
+// the heap profile when it stops. The profiles are kept in memory, to be
+// reported to the agent along with the instruction stats.
+type Profiler struct {
+	cpu bytes.Buffer
+}
+
+func StartProfiling() (*Profiler, error) {
+	p := &Profiler{}
+	if err := pprof.StartCPUProfile(&p.cpu); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Stop returns the profiles, or nothing if not profiling.
+func (p *Profiler) Stop() (profiles []*pb.Profile) {
+	if p == nil {
+		return nil
+	}
+	pprof.StopCPUProfile()
+	profiles = append(profiles, &pb.Profile{Name: "cpu", Data: p.cpu.Bytes()})
+
+	var heap bytes.Buffer
+	// the heap profile shows the allocations up to the last GC
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(&heap); err == nil {
+		profiles = append(profiles, &pb.Profile{Name: "heap", Data: heap.Bytes()})
+	}
+	return profiles
+}
@@ -0,0 +1,26 @@
+package executor
+
+import (
+	"testing"
+)
+
+func TestProfiler(t *testing.T) {
+	var p *Profiler
+	if profiles := p.Stop(); profiles != nil {
+		t.Errorf("expecting no profiles without profiling, got %v", profiles)
+	}
+
+	p, err := StartProfiling()
+	if err != nil {
+		t.Fatalf("failed to start profiling: %v", err)
+	}
+	profiles := p.Stop()
+	if len(profiles) != 2 || profiles[0].Name != "cpu" || profiles[1].Name != "heap" {
+		t.Fatalf("expecting cpu and heap profiles, got %v", profiles)
+	}
+	for _, profile := range profiles {
+		if len(profile.Data) == 0 {
+			t.Errorf("expecting %s profile data", profile.Name)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package executor
+
+import (
+	"bytes"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/chrislusf/gleam/instruction"
+	"github.com/chrislusf/gleam/pb"
+	"github.com/chrislusf/gleam/util"
+)
+
+// ioCounter counts the rows and bytes passing through the readers or
+// writers of one instruction. The counts are accessed atomically.
+type ioCounter struct {
+	rows  int64
+	bytes int64
+}
+
+// countReaders wraps the readers to count rows and bytes.
+// Rows are lines if isLines, or else length prefixed messages.
+func countReaders(readers []io.Reader, counter *ioCounter, isLines bool) (counted []io.Reader) {
+	for _, r := range readers {
+		counted = append(counted, &countingReader{r, newRowCounter(counter, isLines)})
+	}
+	return
+}
+
+// countWriters wraps the writers to count rows and bytes.
+// The wrappers keep the writers closable.
+func countWriters(writers []io.Writer, counter *ioCounter, isLines bool) (counted []io.Writer) {
+	for _, w := range writers {
+		counted = append(counted, &countingWriter{w, newRowCounter(counter, isLines)})
+	}
+	return
+}
+
+type countingReader struct {
+	io.Reader
+	counter *rowCounter
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.counter.count(p[:n])
+	return n, err
+}
+
+type countingWriter struct {
+	io.Writer
+	counter *rowCounter
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.counter.count(p[:n])
+	return n, err
+}
+
+func (w *countingWriter) Close() error {
+	if c, ok := w.Writer.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// rowCounter follows the rows in one stream, which can be split anywhere.
+type rowCounter struct {
+	*ioCounter
+	isLines  bool
+	messages util.MessageCounter
+}
+
+func newRowCounter(counter *ioCounter, isLines bool) *rowCounter {
+	return &rowCounter{ioCounter: counter, isLines: isLines}
+}
+
+func (c *rowCounter) count(p []byte) {
+	atomic.AddInt64(&c.bytes, int64(len(p)))
+	if c.isLines {
+		atomic.AddInt64(&c.rows, int64(bytes.Count(p, []byte{'\n'})))
+		return
+	}
+	atomic.AddInt64(&c.rows, c.messages.Count(p))
+}
+
+// channelReader counts the bytes sent to a network channel.
+type channelReader struct {
+	io.Reader
+	status *util.ChannelStatus
+}
+
+func (r *channelReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.status.ReportAdd(n)
+	return n, err
+}
+
+// channelWriter counts the bytes received from a network channel.
+type channelWriter struct {
+	io.WriteCloser
+	status *util.ChannelStatus
+}
+
+func (w *channelWriter) Write(p []byte) (int, error) {
+	n, err := w.WriteCloser.Write(p)
+	w.status.ReportAdd(n)
+	return n, err
+}
+
+func (exe *Executor) newChannelStatus(statuses *[]*util.ChannelStatus, name string) *util.ChannelStatus {
+	status := util.NewChannelStatus()
+	status.Name = name
+	exe.Lock()
+	*statuses = append(*statuses, status)
+	exe.Unlock()
+	return status
+}
+
+// ChannelStatuses returns the network channels read and written so far.
+func (exe *Executor) ChannelStatuses() (inputs, outputs []*util.ChannelStatus) {
+	exe.Lock()
+	defer exe.Unlock()
+	return exe.inputStatuses, exe.outputStatuses
+}
+
+// instructionStats collects the metrics of one instruction.
+type instructionStats struct {
+	instruction *pb.Instruction
+	input       ioCounter
+	output      ioCounter
+	stats       instruction.Stats // reported by the instruction function
+	startTime   int64             // unix nanoseconds, accessed atomically
+	stopTime    int64
+	cpuTime     int64 // of the script process
+	isScript    bool
+}
+
+func (s *instructionStats) start() {
+	atomic.StoreInt64(&s.startTime, time.Now().UnixNano())
+}
+
+func (s *instructionStats) stop() {
+	atomic.StoreInt64(&s.stopTime, time.Now().UnixNano())
+}
+
+func (s *instructionStats) wallTime() time.Duration {
+	startTime, stopTime := atomic.LoadInt64(&s.startTime), atomic.LoadInt64(&s.stopTime)
+	if startTime == 0 {
+		return 0
+	}
+	if stopTime == 0 {
+		stopTime = time.Now().UnixNano()
+	}
+	return time.Duration(stopTime - startTime)
+}
+
+// Stats returns the metrics of the instructions run so far.
+// The scripts report their own cpu time. The instructions running inside
+// the executor process share its cpu time, in proportion to their wall time.
+func (exe *Executor) Stats() (stats []*pb.InstructionStat) {
+	var inProcessWallTime time.Duration
+	for _, s := range exe.stats {
+		if !s.isScript {
+			inProcessWallTime += s.wallTime()
+		}
+	}
+	inProcessCPUTime := processCPUTime()
+
+	for _, s := range exe.stats {
+		stat := &pb.InstructionStat{
+			StepId:      s.instruction.GetStepId(),
+			TaskId:      s.instruction.GetTaskId(),
+			Name:        s.instruction.GetName(),
+			InputRows:   atomic.LoadInt64(&s.input.rows),
+			InputBytes:  atomic.LoadInt64(&s.input.bytes),
+			OutputRows:  atomic.LoadInt64(&s.output.rows),
+			OutputBytes: atomic.LoadInt64(&s.output.bytes),
+			WallTime:    int64(s.wallTime()),
+			CpuTime:     atomic.LoadInt64(&s.cpuTime),
+			SpillBytes:  atomic.LoadInt64(&s.stats.SpillBytes),
+			StartTime:   atomic.LoadInt64(&s.startTime),
+			StopTime:    atomic.LoadInt64(&s.stopTime),
+		}
+		if !s.isScript && inProcessWallTime > 0 {
+			stat.CpuTime = int64(float64(inProcessCPUTime) * float64(s.wallTime()) / float64(inProcessWallTime))
+		}
+		stats = append(stats, stat)
+	}
+	return
+}
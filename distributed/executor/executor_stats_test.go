@@ -0,0 +1,50 @@
+package executor
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/chrislusf/gleam/util"
+)
+
+func TestCountingMessages(t *testing.T) {
+	var data bytes.Buffer
+	util.WriteMessage(&data, []byte("hello"))
+	util.WriteMessage(&data, []byte{})
+	util.WriteMessage(&data, []byte("world!"))
+	util.WriteEOFMessage(&data)
+
+	// read 3 bytes at a time, splitting the length headers
+	var counter ioCounter
+	readers := countReaders([]io.Reader{&smallReader{&data}}, &counter, false)
+	n, err := io.Copy(ioutil.Discard, readers[0])
+	if err != nil {
+		t.Fatalf("read error: %v", err)
+	}
+	if counter.rows != 3 || counter.bytes != n {
+		t.Errorf("expecting 3 rows and %d bytes, got %d rows and %d bytes", n, counter.rows, counter.bytes)
+	}
+}
+
+func TestCountingLines(t *testing.T) {
+	var counter ioCounter
+	writers := countWriters([]io.Writer{ioutil.Discard}, &counter, true)
+	writers[0].Write([]byte("a\tb\nc"))
+	writers[0].Write([]byte("\nd\n"))
+	if counter.rows != 3 || counter.bytes != 8 {
+		t.Errorf("expecting 3 rows and 8 bytes, got %d rows and %d bytes", counter.rows, counter.bytes)
+	}
+}
+
+type smallReader struct {
+	r io.Reader
+}
+
+func (r *smallReader) Read(p []byte) (int, error) {
+	if len(p) > 3 {
+		p = p[:3]
+	}
+	return r.r.Read(p)
+}
@@ -8,15 +8,18 @@ import (
 	"runtime"
 	"runtime/pprof"
 	"strconv"
-	"strings"
 	"sync"
+	"time"
 
 	kingpin "gopkg.in/alecthomas/kingpin.v2"
 
 	a "github.com/chrislusf/gleam/distributed/agent"
+	"github.com/chrislusf/gleam/distributed/driver"
+	"github.com/chrislusf/gleam/distributed/driver/scheduler"
 	exe "github.com/chrislusf/gleam/distributed/executor"
 	m "github.com/chrislusf/gleam/distributed/master"
 	"github.com/chrislusf/gleam/distributed/netchan"
+	"github.com/chrislusf/gleam/distributed/security"
 	"github.com/chrislusf/gleam/pb"
 	"github.com/chrislusf/gleam/util"
 	"github.com/chrislusf/gleam/util/on_interrupt"
@@ -26,15 +29,36 @@ import (
 var (
 	app = kingpin.New("gleamd", "distributed gleam, acts as master, agent, or executor")
 
-	master        = app.Command("master", "Start a master process")
-	masterAddress = master.Flag("address", "listening address host:port").Default(":45326").String()
+	master       = app.Command("master", "Start a master process")
+	masterOption = &m.MasterOption{
+		Address:       master.Flag("address", "listening address host:port").Default(":45326").String(),
+		TokenFile:     master.Flag("auth.tokenFile", "file of accepted tokens and their permissions, requires TLS. Authentication is disabled if empty.").String(),
+		QueueFile:     master.Flag("queue.file", "file of queues with their weights, min and max shares. Queues are created on demand if empty.").String(),
+		Preemption:    master.Flag("queue.preemption", "stop executors of queues over their fair share for starving queues").Default("false").Bool(),
+		LeaseDuration: master.Flag("lease.duration", "allocations expire unless the driver renews them within this duration").Default("1m").Duration(),
 
-	executor     = app.Command("execute", "Execute an instruction set")
-	executorNote = executor.Flag("note", "description").String()
+		HeartbeatInterval:       master.Flag("heartbeat.interval", "heartbeat interval of agents").Default("10s").Duration(),
+		SuspectMissedHeartbeats: master.Flag("heartbeat.suspectAfter", "agents missing this many heartbeats get no new allocations").Default("3").Int(),
+		DeadMissedHeartbeats:    master.Flag("heartbeat.deadAfter", "agents missing this many heartbeats are removed").Default("6").Int(),
+
+		MaxCPUUsage:   master.Flag("agent.maxCpuUsage", "skip agents with measured cpu usage, including other processes, above this fraction. 0 for no limit.").Default("0.95").Float64(),
+		MinFreeDiskMB: master.Flag("agent.minFreeDiskMB", "skip agents without a data directory having this much free disk space").Default("1024").Int64(),
+
+		HistoryDir:     master.Flag("history.dir", "directory to keep the run records of finished flows. History is disabled if empty.").String(),
+		HistoryMaxRuns: master.Flag("history.maxRuns", "keep the run records of this many latest flows. 0 for no limit.").Default("1000").Int(),
+	}
+	masterTLSOption = tlsFlags(master)
+
+	executor          = app.Command("execute", "Execute an instruction set")
+	executorNote      = executor.Flag("note", "description").String()
+	executorTLSOption = tlsFlags(executor)
+	executorToken     = executor.Flag("auth.token", "token to access agents").Envar(security.TokenEnvironmentVariable).String()
+	executorAgent     = executor.Flag("agent", "agent host:port to report the instruction stats to").String()
+	executorLimits    = limitsFlags(executor)
 
 	agent       = app.Command("agent", "Agent that can accept read, write requests, manage executors")
 	agentOption = &a.AgentServerOption{
-		Dir:          agent.Flag("dir", "agent folder to store computed data").Default(os.TempDir()).String(),
+		Dir:          agent.Flag("dir", "agent folders to store computed data, separated by comma").Default(os.TempDir()).String(),
 		Host:         agent.Flag("host", "agent listening host address. Required in 2-way SSL mode.").Default("localhost").String(),
 		Port:         agent.Flag("port", "agent listening port").Default("45327").Int32(),
 		Master:       agent.Flag("master", "master address").Default("localhost:45326").String(),
@@ -43,31 +67,123 @@ var (
 		MaxExecutor:  agent.Flag("executor.max", "upper limit of executors").Default(strconv.Itoa(runtime.NumCPU())).Int32(),
 		CPULevel:     agent.Flag("executor.cpu.level", "relative computing power of single cpu core").Default("1").Int32(),
 		MemoryMB:     agent.Flag("memory", "memory limit in MB").Default("1024").Int64(),
+		DiskMB:       agent.Flag("disk", "disk space in MB for datasets. 0 for the free space of the data directories.").Default("0").Int64(),
 		CleanRestart: agent.Flag("clean.restart", "clean up previous dataset files").Default("true").Bool(),
+		TLS:          tlsFlags(agent),
+		TokenFile:    agent.Flag("auth.tokenFile", "file of accepted tokens and their permissions, requires TLS. Authentication is disabled if empty.").String(),
+
+		MaxOpenFiles:           agent.Flag("executor.maxOpenFiles", "open files limit of one executor. 0 for no limit.").Default("4096").Int64(),
+		MaxCPUSeconds:          agent.Flag("executor.maxCpuSeconds", "cpu time limit of one executor. 0 for no limit.").Default("0").Int64(),
+		AddressSpaceOverheadMB: agent.Flag("executor.addressSpace.overheadMB", "address space limit of one executor is allocated memory plus this. 0 for no limit.").Default("4096").Int64(),
+		MemoryLimitRatio:       agent.Flag("executor.memory.limitRatio", "kill executors using more memory than allocated times this ratio. 0 for no limit.").Default("2").Float64(),
+		MemoryOverheadMB:       agent.Flag("executor.memory.overheadMB", "executors can always use allocated memory plus this before being killed, for the executor itself and its scripts").Default("256").Int64(),
+
+		LogMaxSizeMB:  agent.Flag("log.maxSizeMB", "rotate the log of an executor over this size").Default("64").Int64(),
+		LogMaxBackups: agent.Flag("log.maxBackups", "rotated files kept for each executor log").Default("3").Int(),
+		LogRetention:  agent.Flag("log.retention", "remove the executor logs of flows not written for this long. 0 to keep them.").Default("72h").Duration(),
 	}
+	agentToken = agent.Flag("auth.token", "token to access master, requires admin permission").String()
 	cpuProfile = agent.Flag("cpuprofile", "cpu profile output file").Default("").String()
 
+	drain             = app.Command("drain", "Drain an agent: no new executors, wait for running ones, then stop the agent")
+	drainAgentAddress = drain.Flag("agent", "agent host:port").Default("localhost:45327").String()
+	drainMigrate      = drain.Flag("migrate", "copy the dataset shards on disk of running flows to other agents").Default("false").Bool()
+	drainTimeout      = drain.Flag("timeout", "give up if executors are still running after this long. 0 to wait until they finish.").Default("0").Duration()
+	drainTLSOption    = tlsFlags(drain)
+	drainToken        = drain.Flag("auth.token", "token to access the agent, requires admin permission").String()
+
 	writer             = app.Command("write", "Write data to a topic, input from console")
 	writeTopic         = writer.Flag("topic", "Name of a topic").Required().String()
 	writerAgentAddress = writer.Flag("agent", "agent host:port").Default("localhost:45327").String()
 	writeToDisk        = writer.Flag("onDisk", "write to memory").Default("false").Bool()
+	writerTLSOption    = tlsFlags(writer)
+	writerToken        = writer.Flag("auth.token", "token to access the agent").String()
 
 	reader             = app.Command("read", "Read data from a topic, output to console")
 	readTopic          = reader.Flag("topic", "Name of a source topic").Required().String()
 	readerAgentAddress = reader.Flag("agent", "agent host:port").Default("localhost:45327").String()
 	readFromDisk       = reader.Flag("onDisk", "read from memory").Default("false").Bool()
+	readerTLSOption    = tlsFlags(reader)
+	readerToken        = reader.Flag("auth.token", "token to access the agent").String()
 )
 
+// reportExecutorStats sends the instruction stats, network channels and
+// profiles to the agent, which passes them on to the driver. Failures are not printed,
+// since any output of the executor is treated as errors by the driver.
+func reportExecutorStats(agentAddress string, instructions *pb.InstructionSet, executor *exe.Executor, profiles []*pb.Profile, err error) {
+	if agentAddress == "" {
+		return
+	}
+	inputs, outputs := executor.ChannelStatuses()
+	report := &pb.LocalStatusReportRequest{
+		StartRequestHash: instructions.HashCode(),
+		InputStatuses:    driver.ToProto(inputs),
+		OutputStatuses:   driver.ToProto(outputs),
+		InstructionStats: executor.Stats(),
+		Profiles:         profiles,
+	}
+	if err != nil {
+		report.Error = err.Error()
+	}
+	scheduler.RemoteDirectCommand(agentAddress, &pb.ControlMessage{
+		LocalStatusReportRequest: report,
+	})
+}
+
+func tlsFlags(cmd *kingpin.CmdClause) *security.TLSOption {
+	option := &security.TLSOption{}
+	cmd.Flag("tls.ca", "CA certificate file to verify peers").StringVar(&option.CAFile)
+	cmd.Flag("tls.cert", "certificate file. Enables TLS if set.").StringVar(&option.CertFile)
+	cmd.Flag("tls.key", "private key file of the certificate").StringVar(&option.KeyFile)
+	cmd.Flag("tls.requireClientCert", "require and verify client certificates, i.e., 2-way SSL").BoolVar(&option.RequireClientCert)
+	return option
+}
+
+func limitsFlags(cmd *kingpin.CmdClause) *exe.Limits {
+	limits := &exe.Limits{}
+	cmd.Flag("limit.addressSpace", "address space limit in bytes. 0 for no limit.").Uint64Var(&limits.AddressSpaceBytes)
+	cmd.Flag("limit.openFiles", "open files limit. 0 for no limit.").Uint64Var(&limits.OpenFiles)
+	cmd.Flag("limit.cpuSeconds", "cpu time limit in seconds. 0 for no limit.").Uint64Var(&limits.CPUSeconds)
+	return limits
+}
+
+func setupTLS(option *security.TLSOption) {
+	if err := security.SetupTLS(option); err != nil {
+		log.Fatalf("Failed to setup TLS: %v", err)
+	}
+}
+
+// setupSecurity sets up TLS, and then the token sent to master and agents.
+func setupSecurity(option *security.TLSOption, token string) {
+	setupTLS(option)
+	if err := security.SetClientToken(token); err != nil {
+		log.Fatalf("Failed to set token: %v", err)
+	}
+}
+
 func main() {
 
-	switch kingpin.MustParse(app.Parse(os.Args[1:])) {
+	command := kingpin.MustParse(app.Parse(os.Args[1:]))
+	if runAdminCommand(command) {
+		return
+	}
+
+	switch command {
 
 	case master.FullCommand():
-		println("master listening on", *masterAddress)
-		m.RunMaster(*masterAddress)
+		setupTLS(masterTLSOption)
+		println("master listening on", *masterOption.Address)
+		m.RunMaster(masterOption)
 
 	case executor.FullCommand():
 
+		// before reading the instructions, so no instruction runs without limits
+		if err := executorLimits.Apply(); err != nil {
+			log.Fatalf("Failed to limit executor resources: %v", err)
+		}
+
+		setupSecurity(executorTLSOption, *executorToken)
+
 		rawData, err := ioutil.ReadAll(os.Stdin)
 		if err != nil {
 			log.Fatalf("failed to read stdin: %v", err)
@@ -77,39 +193,67 @@ func main() {
 			log.Fatal("unmarshaling instructions error: ", err)
 		}
 
+		// the profiles go back to the driver with the stats, if profiling
+		// can not start, the task runs without profiling
+		var profiler *exe.Profiler
 		if instructions.IsProfiling {
-			f, err := os.Create(fmt.Sprintf("exe-%d-%s.pprof", instructions.GetFlowHashCode(), strings.Join(instructions.InstructionNames(), "-")))
-			if err != nil {
-				log.Fatal(err)
-			}
-			pprof.StartCPUProfile(f)
-			defer pprof.StopCPUProfile()
+			profiler, _ = exe.StartProfiling()
 		}
 
-		if err := exe.NewExecutor(nil, &instructions).ExecuteInstructionSet(); err != nil {
+		executor := exe.NewExecutor(nil, &instructions)
+		err = executor.ExecuteInstructionSet()
+		reportExecutorStats(*executorAgent, &instructions, executor, profiler.Stop(), err)
+		if err != nil {
 			log.Fatalf("Failed task %s: %v", *executorNote, err)
 		}
 
 	case writer.FullCommand():
 
+		setupSecurity(writerTLSOption, *writerToken)
+
 		inChan := util.NewPiper()
 		var wg sync.WaitGroup
 		wg.Add(1)
-		go netchan.DialWriteChannel(&wg, "stdin", *writerAgentAddress, *writeTopic, *writeToDisk, inChan.Reader, 1)
+		go netchan.DialWriteChannel(&wg, "stdin", *writerAgentAddress, *writeTopic, *writeToDisk, util.CompressionNone, inChan.Reader, 1)
 		wg.Add(1)
 		go util.LineReaderToChannel(&wg, "stdin", os.Stdin, inChan.Writer, true, os.Stderr)
 		wg.Wait()
 
 	case reader.FullCommand():
 
+		setupSecurity(readerTLSOption, *readerToken)
+
 		outChan := util.NewPiper()
 		var wg sync.WaitGroup
 		wg.Add(1)
-		go netchan.DialReadChannel(&wg, "stdout", *readerAgentAddress, *readTopic, *readFromDisk, outChan.Writer)
+		go netchan.DialReadChannel(&wg, "stdout", *readerAgentAddress, *readTopic, *readFromDisk, util.CompressionNone, outChan.Writer)
 		wg.Add(1)
 		util.ChannelToLineWriter(&wg, "stdout", outChan.Reader, os.Stdout, os.Stderr)
 		wg.Wait()
 
+	case drain.FullCommand():
+
+		setupSecurity(drainTLSOption, *drainToken)
+
+		response, err := scheduler.RemoteDirectCommand(*drainAgentAddress, &pb.ControlMessage{
+			DrainRequest: &pb.DrainRequest{
+				MigrateShards:  *drainMigrate,
+				TimeoutSeconds: int64(*drainTimeout / time.Second),
+			},
+		})
+		if response != nil {
+			for _, shard := range response.GetDrainResponse().GetMigratedShards() {
+				fmt.Printf("%s moved to %s\n", shard.Name, shard.Location.URL())
+			}
+		}
+		if err != nil {
+			log.Fatalf("Failed to drain agent %s: %v", *drainAgentAddress, err)
+		}
+		if drainError := response.GetDrainResponse().GetError(); drainError != "" {
+			log.Fatalf("Failed to drain agent %s: %s", *drainAgentAddress, drainError)
+		}
+		fmt.Printf("agent %s is drained\n", *drainAgentAddress)
+
 	case agent.FullCommand():
 
 		if *cpuProfile != "" {
@@ -126,6 +270,7 @@ func main() {
 			})
 		}
 
+		setupSecurity(agentOption.TLS, *agentToken)
 		a.RunAgentServer(agentOption)
 	}
 }
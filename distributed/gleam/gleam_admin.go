@@ -0,0 +1,314 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+
+	"github.com/chrislusf/gleam/distributed/driver/scheduler"
+	"github.com/chrislusf/gleam/distributed/security"
+	"github.com/chrislusf/gleam/pb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// cluster administration commands
+var (
+	status       = app.Command("status", "Show the resources and allocations of data centers, racks and agents")
+	statusOption = adminFlags(status, "master", "localhost:45326")
+
+	flows       = app.Command("flows", "List running and recently finished flows, with their allocations and progress")
+	flowsOption = adminFlags(flows, "master", "localhost:45326")
+
+	history       = app.Command("history", "List the finished flows kept by the master, show the run record of a flow, or compare two runs")
+	historyFlows  = history.Arg("flows", "hash codes of the runs to show or compare, or a flow name to list its runs").Strings()
+	historyLimit  = history.Flag("limit", "list at most this many latest runs. 0 for all.").Default("20").Int()
+	historyOption = adminFlags(history, "master", "localhost:45326")
+
+	logs          = app.Command("logs", "Show the executor logs of a flow, kept by the agents running it")
+	logsFlow      = logs.Arg("flow", "flow hash code or name").Required().String()
+	logsTaskGroup = logs.Flag("taskgroup", "only the logs of these task groups").Int32List()
+	logsAgent     = logs.Flag("agent", "read the logs from this agent host:port, instead of the agents found by the master").String()
+	logsTail      = logs.Flag("tail", "the last bytes of each log. 0 for the whole log.").Default("65536").Int64()
+	logsOption    = adminFlags(logs, "master", "localhost:45326")
+
+	kill       = app.Command("kill", "Kill a running flow, by its hash code or name")
+	killFlow   = kill.Arg("flow", "flow hash code or name").Required().String()
+	killOption = adminFlags(kill, "master", "localhost:45326")
+
+	datasets       = app.Command("datasets", "List named dataset shards stored on an agent")
+	datasetsOption = adminFlags(datasets, "agent", "localhost:45327")
+
+	rm       = app.Command("rm", "Delete a named dataset shard from an agent")
+	rmShard  = rm.Arg("shard", "dataset shard name").Required().String()
+	rmOption = adminFlags(rm, "agent", "localhost:45327")
+)
+
+type adminOption struct {
+	Address *string
+	JSON    *bool
+	TLS     *security.TLSOption
+	Token   *string
+}
+
+// adminFlags adds the flags to reach the master or an agent.
+func adminFlags(cmd *kingpin.CmdClause, server, defaultAddress string) *adminOption {
+	return &adminOption{
+		Address: cmd.Flag(server, server+" host:port").Default(defaultAddress).String(),
+		JSON:    cmd.Flag("json", "output in JSON for scripting").Default("false").Bool(),
+		TLS:     tlsFlags(cmd),
+		Token:   cmd.Flag("auth.token", "token to access the "+server).String(),
+	}
+}
+
+func (option *adminOption) setup() {
+	setupSecurity(option.TLS, *option.Token)
+}
+
+// runAdminCommand runs the administration command, if it is one.
+func runAdminCommand(command string) bool {
+	switch command {
+	case status.FullCommand():
+		statusOption.setup()
+		withMaster(*statusOption.Address, func(client pb.GleamMasterClient) {
+			topology, err := client.GetTopology(context.Background(), &pb.TopologyRequest{})
+			if err != nil {
+				log.Fatalf("Failed to get topology from %s: %v", *statusOption.Address, err)
+			}
+			if *statusOption.JSON {
+				printJSON(topology)
+				return
+			}
+			printTopology(topology)
+		})
+
+	case flows.FullCommand():
+		flowsOption.setup()
+		withMaster(*flowsOption.Address, func(client pb.GleamMasterClient) {
+			response, err := client.ListFlows(context.Background(), &pb.ListFlowsRequest{})
+			if err != nil {
+				log.Fatalf("Failed to list flows on %s: %v", *flowsOption.Address, err)
+			}
+			if *flowsOption.JSON {
+				printJSON(response)
+				return
+			}
+			printFlows(response.GetFlows())
+		})
+
+	case history.FullCommand():
+		historyOption.setup()
+		withMaster(*historyOption.Address, func(client pb.GleamMasterClient) {
+			runHistoryCommand(client, *historyFlows)
+		})
+
+	case logs.FullCommand():
+		logsOption.setup()
+		withMaster(*logsOption.Address, func(client pb.GleamMasterClient) {
+			runLogsCommand(client)
+		})
+
+	case kill.FullCommand():
+		killOption.setup()
+		withMaster(*killOption.Address, func(client pb.GleamMasterClient) {
+			hashCode, err := resolveFlow(client, *killFlow)
+			if err != nil {
+				log.Fatal(err)
+			}
+			response, err := client.KillFlow(context.Background(), &pb.KillFlowRequest{
+				FlowHashCode: hashCode,
+			})
+			if err != nil {
+				log.Fatalf("Failed to kill flow %s: %v", *killFlow, err)
+			}
+			if *killOption.JSON {
+				printJSON(response)
+				return
+			}
+			fmt.Printf("flow %d is killed, %d leases released\n", hashCode, response.GetKilledLeases())
+		})
+
+	case datasets.FullCommand():
+		datasetsOption.setup()
+		response, err := scheduler.RemoteDirectCommand(*datasetsOption.Address, &pb.ControlMessage{
+			ListDatasetShardsRequest: &pb.ListDatasetShardsRequest{},
+		})
+		if err != nil {
+			log.Fatalf("Failed to list dataset shards on %s: %v", *datasetsOption.Address, err)
+		}
+		if *datasetsOption.JSON {
+			printJSON(response.GetListDatasetShardsResponse())
+			return true
+		}
+		printDatasetShards(response.GetListDatasetShardsResponse().GetShards())
+
+	case rm.FullCommand():
+		rmOption.setup()
+		response, err := scheduler.RemoteDirectCommand(*rmOption.Address, &pb.ControlMessage{
+			DeleteDatasetShardRequest: &pb.DeleteDatasetShardRequest{
+				Name: *rmShard,
+			},
+		})
+		if err == nil && response.GetDeleteDatasetShardResponse().GetError() != "" {
+			err = errors.New(response.GetDeleteDatasetShardResponse().GetError())
+		}
+		if err != nil {
+			log.Fatalf("Failed to delete %s on %s: %v", *rmShard, *rmOption.Address, err)
+		}
+		if *rmOption.JSON {
+			printJSON(response.GetDeleteDatasetShardResponse())
+			return true
+		}
+		fmt.Printf("%s is deleted from %s\n", *rmShard, *rmOption.Address)
+
+	default:
+		return false
+	}
+	return true
+}
+
+func withMaster(master string, fn func(client pb.GleamMasterClient)) {
+	conn, err := grpc.Dial(master, security.GrpcDialOptions()...)
+	if err != nil {
+		log.Fatalf("Failed to connect to master %s: %v", master, err)
+	}
+	defer conn.Close()
+
+	fn(pb.NewGleamMasterClient(conn))
+}
+
+// resolveFlow finds the flow hash code, given the hash code or the unique name of a running flow.
+func resolveFlow(client pb.GleamMasterClient, flow string) (uint32, error) {
+	if hashCode, err := strconv.ParseUint(flow, 10, 32); err == nil {
+		return uint32(hashCode), nil
+	}
+	response, err := client.ListFlows(context.Background(), &pb.ListFlowsRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("Failed to list flows: %v", err)
+	}
+	var found []uint32
+	for _, f := range response.GetFlows() {
+		if f.GetName() == flow {
+			found = append(found, f.GetFlowHashCode())
+		}
+	}
+	switch len(found) {
+	case 0:
+		return 0, fmt.Errorf("flow %s is not found", flow)
+	case 1:
+		return found[0], nil
+	}
+	return 0, fmt.Errorf("%d flows are named %s, pick one by its hash code: %v", len(found), flow, found)
+}
+
+func printJSON(v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode JSON: %v", err)
+	}
+	fmt.Printf("%s\n", data)
+}
+
+func printTopology(topology *pb.TopologyResponse) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "NAME\tSTATE\tCPU\tMEMORY MB\tDISK MB\tLOAD\tHEARTBEAT\n")
+	fmt.Fprintf(w, "%s\t\t%s\n", "total", resourceUsage(topology.GetResource(), topology.GetAllocated()))
+	for _, dc := range topology.GetDataCenters() {
+		fmt.Fprintf(w, "%s\t\t%s\n", dc.GetName(), resourceUsage(dc.GetResource(), dc.GetAllocated()))
+		for _, rack := range dc.GetRacks() {
+			fmt.Fprintf(w, "  %s\t\t%s\n", rack.GetName(), resourceUsage(rack.GetResource(), rack.GetAllocated()))
+			for _, a := range rack.GetAgents() {
+				state := a.GetState()
+				if a.GetDraining() {
+					state += ",draining"
+				}
+				fmt.Fprintf(w, "    %s\t%s\t%s\t%s\t%s\n",
+					a.GetLocation().URL(), state,
+					resourceUsage(a.GetResource(), a.GetAllocated()),
+					loadOf(a.GetLoad()),
+					since(a.GetLastHeartbeat()))
+			}
+		}
+	}
+	w.Flush()
+}
+
+// resourceUsage formats the allocated and total resources as the cpu, memory and disk columns.
+func resourceUsage(resource, allocated *pb.ComputeResource) string {
+	return fmt.Sprintf("%d/%d\t%d/%d\t%d/%d",
+		allocated.GetCpuCount(), resource.GetCpuCount(),
+		allocated.GetMemoryMb(), resource.GetMemoryMb(),
+		allocated.GetDiskMb(), resource.GetDiskMb())
+}
+
+func loadOf(load *pb.AgentLoad) string {
+	if load == nil {
+		return "-"
+	}
+	return fmt.Sprintf("cpu %.0f%%, %d executors", load.GetCpuUsage()*100, load.GetRunningExecutors())
+}
+
+// printFlows shows the allocations of each flow in total. The allocations
+// on each agent are in the JSON output.
+func printFlows(flows []*pb.FlowStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "FLOW\tNAME\tUSER\tQUEUE\tDRIVER\tSTATE\tSTARTED\tTASK GROUPS\tLEASES\tCPU\tMEMORY MB\n")
+	for _, f := range flows {
+		var allocated pb.ComputeResource
+		for _, a := range f.GetAllocations() {
+			allocated = allocated.Plus(*a.GetAllocated())
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\n",
+			f.GetFlowHashCode(), f.GetName(), f.GetUser(), f.GetQueue(), f.GetDriver(),
+			f.GetState(), since(f.GetStartTime()), taskGroupProgress(f), len(f.GetAllocations()),
+			allocated.GetCpuCount(), allocated.GetMemoryMb())
+	}
+	w.Flush()
+}
+
+// taskGroupProgress formats the completed and total task groups, with the
+// running and failed ones if any.
+func taskGroupProgress(f *pb.FlowStatus) string {
+	if f.GetTaskGroups() == 0 {
+		return "-"
+	}
+	progress := fmt.Sprintf("%d/%d", f.GetCompletedTaskGroups(), f.GetTaskGroups())
+	if f.GetRunningTaskGroups() > 0 {
+		progress += fmt.Sprintf(", %d running", f.GetRunningTaskGroups())
+	}
+	if f.GetFailedTaskGroups() > 0 {
+		progress += fmt.Sprintf(", %d failed", f.GetFailedTaskGroups())
+	}
+	return progress
+}
+
+func printDatasetShards(shards []*pb.DatasetShardStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "NAME\tSTORAGE\tBYTES\tCOMPRESSION\tCREATED\tLAST WRITE\tLAST READ\n")
+	for _, shard := range shards {
+		storage := "memory"
+		if shard.GetOnDisk() {
+			storage = "disk"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+			shard.GetName(), storage, shard.GetSize(), shard.GetCompression(),
+			since(shard.GetCreateTime()), since(shard.GetLastWriteTime()),
+			since(shard.GetLastReadTime()))
+	}
+	w.Flush()
+}
+
+// since formats how long ago the unix time was, or "-" if not set.
+func since(unixTime int64) string {
+	if unixTime == 0 {
+		return "-"
+	}
+	return (time.Since(time.Unix(unixTime, 0)) / time.Second * time.Second).String() + " ago"
+}
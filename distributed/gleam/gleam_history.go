@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+	"golang.org/x/net/context"
+)
+
+// runHistoryCommand lists the runs kept by the master, optionally of one
+// flow name, or shows one run, or compares two runs step by step.
+func runHistoryCommand(client pb.GleamMasterClient, flows []string) {
+	if len(flows) == 0 || (len(flows) == 1 && !isHashCode(flows[0])) {
+		request := &pb.ListHistoryRequest{Limit: int32(*historyLimit)}
+		if len(flows) == 1 {
+			request.Name = flows[0]
+		}
+		response, err := client.ListHistory(context.Background(), request)
+		if err != nil {
+			log.Fatalf("Failed to list history on %s: %v", *historyOption.Address, err)
+		}
+		if *historyOption.JSON {
+			printJSON(response)
+			return
+		}
+		printHistory(response.GetFlows())
+		return
+	}
+	if len(flows) > 2 {
+		log.Fatalf("Expecting one run to show or two runs to compare, but got %d", len(flows))
+	}
+
+	var runs []*pb.FlowProgress
+	for _, flow := range flows {
+		hashCode, err := strconv.ParseUint(flow, 10, 32)
+		if err != nil {
+			log.Fatalf("Invalid flow hash code %s", flow)
+		}
+		run, err := client.GetHistory(context.Background(), &pb.GetHistoryRequest{
+			FlowHashCode: uint32(hashCode),
+		})
+		if err != nil {
+			log.Fatalf("Failed to get flow %s from history: %v", flow, err)
+		}
+		runs = append(runs, run)
+	}
+	if *historyOption.JSON {
+		if len(runs) == 1 {
+			printJSON(runs[0])
+		} else {
+			printJSON(runs)
+		}
+		return
+	}
+	if len(runs) == 1 {
+		printRun(runs[0])
+		return
+	}
+	compareRuns(runs[0], runs[1])
+}
+
+func isHashCode(flow string) bool {
+	_, err := strconv.ParseUint(flow, 10, 32)
+	return err == nil
+}
+
+func printHistory(flows []*pb.FlowStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "FLOW\tNAME\tUSER\tSTATE\tSTARTED\tDURATION\tTASK GROUPS\tERROR\n")
+	for _, f := range flows {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			f.GetFlowHashCode(), f.GetName(), f.GetUser(), f.GetState(),
+			startedAt(f.GetStartTime()), duration(f.GetStartTime(), f.GetStopTime()),
+			taskGroupProgress(f), firstLine(f.GetError()))
+	}
+	w.Flush()
+}
+
+// printRun shows the stats of each step, and where and how long each task
+// group ran. The plan and the stats of each task are in the JSON output.
+func printRun(run *pb.FlowProgress) {
+	fmt.Printf("flow %d %s by %s on %s, %s\n", run.GetFlowHashCode(), run.GetName(), run.GetUser(), run.GetDriver(), run.GetState())
+	fmt.Printf("started %s, took %s\n", startedAt(run.GetStartTime()), duration(run.GetStartTime(), run.GetStopTime()))
+	if run.GetError() != "" {
+		fmt.Printf("error: %s\n", run.GetError())
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "STEP\tTASKS\tINPUT ROWS\tOUTPUT ROWS\tOUTPUT BYTES\tWALL TIME\tCPU TIME\n")
+	for _, step := range run.GetSteps() {
+		stats := step.GetStats()
+		fmt.Fprintf(w, "%s%d\t%d\t%d\t%d\t%d\t%s\t%s\n",
+			step.GetName(), step.GetId(), step.GetTaskCount(),
+			stats.GetInputRows(), stats.GetOutputRows(), stats.GetOutputBytes(),
+			time.Duration(stats.GetWallTime()), time.Duration(stats.GetCpuTime()))
+	}
+	w.Flush()
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "TASK GROUP\tSTEPS\tSTATE\tLOCATION\tDURATION\tOUTPUT ROWS\tERROR\n")
+	for _, tg := range run.GetTaskGroups() {
+		location := "-"
+		if tg.GetLocation() != nil {
+			location = tg.GetLocation().URL()
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			tg.GetId(), strings.Join(tg.GetSteps(), ","), tg.GetState(), location,
+			duration(tg.GetStartTime(), tg.GetStopTime()), tg.GetOutputRows(), firstLine(tg.GetError()))
+	}
+	w.Flush()
+}
+
+// compareRuns shows the stats of the steps of two runs side by side. The
+// steps are matched by their ids, which are the same for the same flow.
+func compareRuns(a, b *pb.FlowProgress) {
+	fmt.Printf("flow %d %s, %s, took %s\n", a.GetFlowHashCode(), a.GetName(), a.GetState(), duration(a.GetStartTime(), a.GetStopTime()))
+	fmt.Printf("flow %d %s, %s, took %s\n", b.GetFlowHashCode(), b.GetName(), b.GetState(), duration(b.GetStartTime(), b.GetStopTime()))
+
+	bSteps := make(map[int32]*pb.StepProgress)
+	for _, step := range b.GetSteps() {
+		bSteps[step.GetId()] = step
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "STEP\tOUTPUT ROWS\t\tCHANGE\tWALL TIME\t\tCHANGE\n")
+	for _, step := range a.GetSteps() {
+		aStats := step.GetStats()
+		bStats := bSteps[step.GetId()].GetStats()
+		fmt.Fprintf(w, "%s%d\t%d\t%d\t%s\t%s\t%s\t%s\n",
+			step.GetName(), step.GetId(),
+			aStats.GetOutputRows(), bStats.GetOutputRows(), change(aStats.GetOutputRows(), bStats.GetOutputRows()),
+			time.Duration(aStats.GetWallTime()), time.Duration(bStats.GetWallTime()), change(aStats.GetWallTime(), bStats.GetWallTime()))
+	}
+	w.Flush()
+}
+
+// change formats how much b differs from a, in percentage.
+func change(a, b int64) string {
+	if a == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", float64(b-a)*100/float64(a))
+}
+
+func startedAt(unixTime int64) string {
+	if unixTime == 0 {
+		return "-"
+	}
+	return time.Unix(unixTime, 0).Format("2006-01-02 15:04:05")
+}
+
+// duration formats the time between the unix times, or "-" if either is not set.
+func duration(start, stop int64) string {
+	if start == 0 || stop == 0 {
+		return "-"
+	}
+	return (time.Duration(stop-start) * time.Second).String()
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i] + "..."
+	}
+	return s
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/chrislusf/gleam/distributed/driver/scheduler"
+	"github.com/chrislusf/gleam/pb"
+	"golang.org/x/net/context"
+)
+
+// runLogsCommand reads the executor logs of the flow from the agents that
+// ran its task groups, as kept in the history, or that hold its leases.
+func runLogsCommand(client pb.GleamMasterClient) {
+	hashCode, err := resolveLoggedFlow(client, *logsFlow)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	agents := []string{*logsAgent}
+	if *logsAgent == "" {
+		agents = flowAgents(client, hashCode)
+	}
+	if len(agents) == 0 {
+		log.Fatalf("No agents are found running flow %d, try --agent", hashCode)
+	}
+
+	type agentLog struct {
+		Agent         string `json:"agent"`
+		TaskGroupId   int32  `json:"taskGroupId"`
+		Name          string `json:"name"`
+		Size          int64  `json:"size"`
+		LastWriteTime int64  `json:"lastWriteTime"`
+		Content       string `json:"content"`
+	}
+	var agentLogs []agentLog
+	for _, agent := range agents {
+		response, err := scheduler.RemoteDirectCommand(agent, &pb.ControlMessage{
+			ReadLogRequest: &pb.ReadLogRequest{
+				FlowHashCode: hashCode,
+				TaskGroupIds: *logsTaskGroup,
+				TailBytes:    *logsTail,
+			},
+		})
+		if err == nil && response.GetReadLogResponse().GetError() != "" {
+			err = fmt.Errorf("%s: %s", agent, response.GetReadLogResponse().GetError())
+		}
+		if err != nil {
+			log.Printf("Failed to read logs of flow %d on %s: %v", hashCode, agent, err)
+			continue
+		}
+		for _, l := range response.GetReadLogResponse().GetLogs() {
+			agentLogs = append(agentLogs, agentLog{
+				Agent:         agent,
+				TaskGroupId:   l.GetTaskGroupId(),
+				Name:          l.GetName(),
+				Size:          l.GetSize(),
+				LastWriteTime: l.GetLastWriteTime(),
+				Content:       string(l.GetContent()),
+			})
+		}
+	}
+
+	if *logsOption.JSON {
+		printJSON(agentLogs)
+		return
+	}
+	for _, l := range agentLogs {
+		fmt.Printf("==> %s task group %d, %d bytes, last written %s <==\n",
+			l.Agent, l.TaskGroupId, l.Size, since(l.LastWriteTime))
+		os.Stdout.WriteString(l.Content)
+		if l.Content != "" && l.Content[len(l.Content)-1] != '\n' {
+			fmt.Println()
+		}
+	}
+}
+
+// resolveLoggedFlow finds the flow hash code, given the hash code, the
+// name of a running flow, or the name of the latest run in the history.
+func resolveLoggedFlow(client pb.GleamMasterClient, flow string) (uint32, error) {
+	hashCode, err := resolveFlow(client, flow)
+	if err == nil || isHashCode(flow) {
+		return hashCode, err
+	}
+	response, historyErr := client.ListHistory(context.Background(), &pb.ListHistoryRequest{
+		Name:  flow,
+		Limit: 1,
+	})
+	if historyErr != nil || len(response.GetFlows()) == 0 {
+		return 0, err
+	}
+	return response.GetFlows()[0].GetFlowHashCode(), nil
+}
+
+// flowAgents lists the agents where the task groups ran, as kept in the
+// history, or where the flow holds leases. With --taskgroup, only the
+// agents of the task groups are listed if the history has them.
+func flowAgents(client pb.GleamMasterClient, hashCode uint32) (agents []string) {
+	found := make(map[string]bool)
+	add := func(location *pb.Location) {
+		if location != nil && !found[location.URL()] {
+			found[location.URL()] = true
+			agents = append(agents, location.URL())
+		}
+	}
+
+	wanted := make(map[int32]bool)
+	for _, id := range *logsTaskGroup {
+		wanted[id] = true
+	}
+	if run, err := client.GetHistory(context.Background(), &pb.GetHistoryRequest{
+		FlowHashCode: hashCode,
+	}); err == nil {
+		for _, tg := range run.GetTaskGroups() {
+			if len(wanted) == 0 || wanted[tg.GetId()] {
+				add(tg.GetLocation())
+			}
+		}
+	}
+
+	if response, err := client.ListFlows(context.Background(), &pb.ListFlowsRequest{}); err == nil {
+		for _, f := range response.GetFlows() {
+			if f.GetFlowHashCode() != hashCode {
+				continue
+			}
+			for _, a := range f.GetAllocations() {
+				add(a.GetLocation())
+			}
+		}
+	}
+
+	sort.Strings(agents)
+	return agents
+}
@@ -0,0 +1,48 @@
+package master
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+// isAllocatable checks whether new executors can be started on the agent.
+func (t *Topology) isAllocatable(agent *AgentInformation) bool {
+	return agent.State == AgentAlive && !agent.Draining && !t.isOverloaded(agent)
+}
+
+// drainAgent stops allocating on the agent, and returns the other agents
+// in the same data center to take over its dataset shards, the ones with
+// the most free disk space first.
+func (t *Topology) drainAgent(location *pb.Location) (targets []*pb.Location, err error) {
+	agent, found := t.findAgentInformation(location)
+	if !found {
+		return nil, fmt.Errorf("agent %s is not found in %s/%s", location.URL(), location.DataCenter, location.Rack)
+	}
+	agent.Draining = true
+
+	dc, _ := t.GetDataCenter(location.DataCenter)
+	var candidates []*AgentInformation
+	for _, rack := range dc.GetRacks() {
+		for _, other := range rack.GetAgents() {
+			if other != agent && other.State == AgentAlive && !other.Draining {
+				candidates = append(candidates, other)
+			}
+		}
+	}
+	sort.Sort(byFreeDisk(candidates))
+	for _, candidate := range candidates {
+		target := candidate.Location
+		targets = append(targets, &target)
+	}
+	return targets, nil
+}
+
+type byFreeDisk []*AgentInformation
+
+func (s byFreeDisk) Len() int      { return len(s) }
+func (s byFreeDisk) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byFreeDisk) Less(i, j int) bool {
+	return freeDiskMb(s[i]) > freeDiskMb(s[j])
+}
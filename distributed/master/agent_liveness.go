@@ -0,0 +1,71 @@
+package master
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+const (
+	AgentAlive   = "alive"
+	AgentSuspect = "suspect"
+
+	// tell the drivers about dead agents for this long
+	deadAgentMemory = 10 * time.Minute
+)
+
+type deadAgent struct {
+	Location pb.Location
+	DeadTime time.Time
+}
+
+// checkHeartbeats marks agents missing heartbeats as suspect, so they get
+// no new allocations, and removes them as dead if the heartbeats are still
+// missing later. The leases on dead agents expire immediately.
+func (t *Topology) checkHeartbeats(now time.Time, suspectAfter, deadAfter time.Duration) {
+	for _, dc := range t.GetDataCenters() {
+		for _, rack := range dc.GetRacks() {
+			for _, agent := range rack.GetAgents() {
+				silence := now.Sub(agent.LastHeartBeat)
+				switch {
+				case silence > deadAfter:
+					fmt.Printf("dead agent: %v, no heartbeat for %v\n", agent.Location, silence)
+					t.deleteAgentInformation(&agent.Location)
+					t.deadAgents[agent.Location.URL()] = deadAgent{agent.Location, now}
+					t.expireLeasesOn(&agent.Location, now)
+				case silence > suspectAfter && agent.State != AgentSuspect:
+					fmt.Printf("suspect agent: %v, no heartbeat for %v\n", agent.Location, silence)
+					agent.State = AgentSuspect
+				}
+			}
+		}
+	}
+	for url, dead := range t.deadAgents {
+		if now.Sub(dead.DeadTime) > deadAgentMemory {
+			delete(t.deadAgents, url)
+		}
+	}
+}
+
+func (t *Topology) expireLeasesOn(location *pb.Location, now time.Time) {
+	for _, lease := range t.leases.active {
+		if lease.Location.URL() == location.URL() {
+			lease.Expiration = now
+		}
+	}
+	t.reclaimExpiredLeases(now)
+}
+
+// reviveAgent forgets the agent was dead when its heartbeat comes back.
+func (t *Topology) reviveAgent(location *pb.Location) {
+	delete(t.deadAgents, location.URL())
+}
+
+func (t *Topology) getDeadAgents() (locations []*pb.Location) {
+	for _, dead := range t.deadAgents {
+		location := dead.Location
+		locations = append(locations, &location)
+	}
+	return
+}
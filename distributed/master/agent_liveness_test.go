@@ -0,0 +1,41 @@
+package master
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+func TestHeartbeatTimeout(t *testing.T) {
+
+	tp := NewTopology()
+	location := addTestAgent(tp)
+	allocation := &pb.Allocation{
+		Location:  location,
+		Allocated: &pb.ComputeResource{CpuCount: 1, MemoryMb: 512},
+	}
+	tp.newLease(allocation, DefaultQueueName, FlowInfo{})
+
+	now := time.Now()
+	tp.checkHeartbeats(now.Add(15*time.Second), 10*time.Second, 30*time.Second)
+	agent, found := tp.findAgentInformation(location)
+	if !found || agent.State != AgentSuspect {
+		t.Fatalf("expecting suspect agent, got %+v", agent)
+	}
+	dc, _ := tp.GetDataCenter("dc")
+	if allocations := tp.findServers(dc, DefaultQueueName, []*pb.ComputeResource{allocation.Allocated}); len(allocations) != 0 {
+		t.Errorf("expecting no allocation on suspect agent, got %v", allocations)
+	}
+
+	tp.checkHeartbeats(now.Add(45*time.Second), 10*time.Second, 30*time.Second)
+	if _, found := tp.findAgentInformation(location); found {
+		t.Errorf("expecting dead agent removed")
+	}
+	if dead := tp.getDeadAgents(); len(dead) != 1 {
+		t.Errorf("expecting 1 dead agent, got %v", dead)
+	}
+	if expired := tp.renewLeases([]uint64{allocation.LeaseId}); len(expired) != 1 {
+		t.Errorf("expecting lease on dead agent expired, got %v", expired)
+	}
+}
@@ -0,0 +1,58 @@
+package master
+
+import (
+	"github.com/chrislusf/gleam/pb"
+)
+
+// LoadLimits avoids allocating on agents busy with other processes,
+// according to the usage measured by the agents.
+type LoadLimits struct {
+	MaxCPUUsage   float64 // 0 means no limit
+	MinFreeDiskMB int64   // at least one data directory needs this much free space
+}
+
+func (t *Topology) isOverloaded(agent *AgentInformation) bool {
+	load := agent.Load
+	if load == nil {
+		return false
+	}
+	if t.LoadLimits.MaxCPUUsage > 0 && load.CpuUsage > t.LoadLimits.MaxCPUUsage {
+		return true
+	}
+	if t.LoadLimits.MinFreeDiskMB > 0 && len(load.Disks) > 0 {
+		for _, disk := range load.Disks {
+			if disk.FreeMb >= t.LoadLimits.MinFreeDiskMB {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// availableOn is the unallocated resource of the agent,
+// limited by the memory and disk actually free on the machine.
+func availableOn(agent *AgentInformation) pb.ComputeResource {
+	available := agent.Resource.Minus(agent.Allocated)
+	if agent.Load == nil {
+		return available
+	}
+	if agent.Load.FreeMemoryMb > 0 && agent.Load.FreeMemoryMb < available.MemoryMb {
+		available.MemoryMb = agent.Load.FreeMemoryMb
+	}
+	if len(agent.Load.Disks) > 0 && freeDiskMb(agent) < available.DiskMb {
+		available.DiskMb = freeDiskMb(agent)
+	}
+	return available
+}
+
+// freeDiskMb is the measured free space of all data directories of the agent.
+func freeDiskMb(agent *AgentInformation) (freeMb int64) {
+	if agent.Load == nil {
+		return 0
+	}
+	for _, disk := range agent.Load.Disks {
+		freeMb += disk.FreeMb
+	}
+	return
+}
@@ -0,0 +1,199 @@
+package master
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chrislusf/gleam/distributed/security"
+	"github.com/chrislusf/gleam/pb"
+	"golang.org/x/net/context"
+)
+
+const (
+	// forget the flows whose drivers have not reported for this long
+	flowProgressMemory = time.Hour
+
+	// drivers report every few seconds while their flows run
+	lostFlowTimeout = time.Minute
+)
+
+// FlowProgresses keeps the latest progress reported by each driver,
+// and the flows' shards migrated off drained agents.
+type FlowProgresses struct {
+	reported map[uint32]*reportedFlow
+	migrated map[uint32]map[string]*pb.DataLocation
+}
+
+type reportedFlow struct {
+	progress   *pb.FlowProgress
+	reportedAt time.Time
+	finished   bool // passed on to the history
+}
+
+func NewFlowProgresses() *FlowProgresses {
+	return &FlowProgresses{
+		reported: make(map[uint32]*reportedFlow),
+		migrated: make(map[uint32]map[string]*pb.DataLocation),
+	}
+}
+
+func (p *FlowProgresses) report(progress *pb.FlowProgress, now time.Time) {
+	p.reported[progress.GetFlowHashCode()] = &reportedFlow{
+		progress:   progress,
+		reportedAt: now,
+	}
+}
+
+// get returns the reported progress. A running flow whose driver stopped
+// reporting is failed.
+func (p *FlowProgresses) get(hashCode uint32, now time.Time) (*pb.FlowProgress, bool) {
+	r, found := p.reported[hashCode]
+	if !found {
+		return nil, false
+	}
+	progress := r.progress
+	if progress.State == pb.StateRunning && now.Sub(r.reportedAt) > lostFlowTimeout {
+		lost := *progress
+		lost.State = pb.StateFailed
+		lost.StopTime = r.reportedAt.Unix()
+		lost.Error = fmt.Sprintf("driver %s stopped reporting", progress.Driver)
+		progress = &lost
+	}
+	return progress, true
+}
+
+// finished returns the flows stopped, or lost, since the last call.
+func (p *FlowProgresses) finished(now time.Time) (finished []*pb.FlowProgress) {
+	for hashCode, r := range p.reported {
+		if r.finished {
+			continue
+		}
+		if progress, _ := p.get(hashCode, now); progress.State != pb.StateRunning {
+			r.finished = true
+			finished = append(finished, progress)
+		}
+	}
+	return finished
+}
+
+func (p *FlowProgresses) expire(now time.Time) {
+	for hashCode, r := range p.reported {
+		if now.Sub(r.reportedAt) > flowProgressMemory {
+			delete(p.reported, hashCode)
+			delete(p.migrated, hashCode)
+		}
+	}
+}
+
+// migrate keeps the new locations of the shards, to tell their drivers.
+func (p *FlowProgresses) migrate(locations []*pb.DataLocation) {
+	for _, location := range locations {
+		var hashCode uint32
+		if _, err := fmt.Sscanf(location.Name, "f%d-", &hashCode); err != nil {
+			continue
+		}
+		if p.migrated[hashCode] == nil {
+			p.migrated[hashCode] = make(map[string]*pb.DataLocation)
+		}
+		p.migrated[hashCode][location.Name] = location
+	}
+}
+
+// migratedShards returns the new locations of the flow's migrated shards.
+// They are sent with every report, so a lost response does not lose them.
+func (p *FlowProgresses) migratedShards(hashCode uint32) (locations []*pb.DataLocation) {
+	for _, location := range p.migrated[hashCode] {
+		locations = append(locations, location)
+	}
+	return locations
+}
+
+func (s *MasterServer) ReportFlowProgress(ctx context.Context, in *pb.FlowProgress) (*pb.FlowProgressResponse, error) {
+	if err := s.Tokens.CheckContext(ctx, security.PermissionSubmit); err != nil {
+		return nil, fmt.Errorf("ReportFlowProgress denied: %v", err)
+	}
+	if in.GetFlowHashCode() == 0 {
+		return nil, fmt.Errorf("missing flow hash code")
+	}
+
+	s.Lock()
+	now := time.Now()
+	s.Flows.report(in, now)
+	migrated := s.Flows.migratedShards(in.GetFlowHashCode())
+	s.Unlock()
+
+	s.recordHistory(now)
+	return &pb.FlowProgressResponse{
+		MigratedShards: migrated,
+	}, nil
+}
+
+// flowStatuses lists the flows holding leases, and the flows reported
+// recently, with their progress.
+func (s *MasterServer) flowStatuses() []*pb.FlowStatus {
+	now := time.Now()
+	flows := s.Topology.flowStatuses()
+	listed := make(map[uint32]bool)
+	for _, flow := range flows {
+		listed[flow.FlowHashCode] = true
+		flow.State = pb.StateRunning
+		if progress, found := s.Flows.get(flow.FlowHashCode, now); found {
+			addProgress(flow, progress)
+		}
+	}
+	for hashCode := range s.Flows.reported {
+		if listed[hashCode] {
+			continue
+		}
+		progress, _ := s.Flows.get(hashCode, now)
+		flows = append(flows, flowStatusOf(progress))
+	}
+	sort.Sort(byStartTime(flows))
+	return flows
+}
+
+// runningFlowHashCodes lists the flows holding leases or reported running.
+func (s *MasterServer) runningFlowHashCodes() (hashCodes []uint32) {
+	for _, flow := range s.flowStatuses() {
+		if flow.State == pb.StateRunning {
+			hashCodes = append(hashCodes, flow.FlowHashCode)
+		}
+	}
+	return hashCodes
+}
+
+// flowStatusOf summarizes the flow from its progress alone.
+func flowStatusOf(progress *pb.FlowProgress) *pb.FlowStatus {
+	flow := &pb.FlowStatus{
+		FlowHashCode: progress.FlowHashCode,
+		Name:         progress.Name,
+		User:         progress.User,
+		Queue:        progress.Queue,
+		Driver:       progress.Driver,
+		StartTime:    progress.StartTime,
+	}
+	addProgress(flow, progress)
+	return flow
+}
+
+// addProgress summarizes the task groups of the flow.
+func addProgress(flow *pb.FlowStatus, progress *pb.FlowProgress) {
+	flow.State = progress.State
+	flow.StopTime = progress.StopTime
+	flow.Error = progress.Error
+	if progress.StartTime != 0 && (flow.StartTime == 0 || progress.StartTime < flow.StartTime) {
+		flow.StartTime = progress.StartTime
+	}
+	flow.TaskGroups = int32(len(progress.TaskGroups))
+	for _, tg := range progress.TaskGroups {
+		switch tg.State {
+		case pb.StateRunning:
+			flow.RunningTaskGroups++
+		case pb.StateCompleted:
+			flow.CompletedTaskGroups++
+		case pb.StateFailed:
+			flow.FailedTaskGroups++
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package master
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+func TestFlowProgress(t *testing.T) {
+
+	ms := newMasterServer()
+	location := addTestAgent(ms.Topology)
+	ms.Topology.newLease(&pb.Allocation{
+		Location:  location,
+		Allocated: &pb.ComputeResource{CpuCount: 1, MemoryMb: 256},
+	}, DefaultQueueName, FlowInfo{HashCode: 7, Name: "wordcount"})
+
+	now := time.Now()
+	ms.Flows.report(&pb.FlowProgress{
+		FlowHashCode: 7,
+		State:        pb.StateRunning,
+		TaskGroups: []*pb.TaskGroupProgress{
+			{Id: 0, State: pb.StateCompleted},
+			{Id: 1, State: pb.StateRunning},
+		},
+	}, now)
+	ms.Flows.report(&pb.FlowProgress{
+		FlowHashCode: 9,
+		Name:         "sort",
+		State:        pb.StateCompleted,
+		StopTime:     now.Unix(),
+	}, now)
+	ms.Flows.report(&pb.FlowProgress{
+		FlowHashCode: 11,
+		State:        pb.StateRunning,
+	}, now.Add(-2*lostFlowTimeout))
+
+	states := make(map[uint32]*pb.FlowStatus)
+	for _, flow := range ms.flowStatuses() {
+		states[flow.FlowHashCode] = flow
+	}
+	if len(states) != 3 {
+		t.Fatalf("expecting 3 flows, got %v", states)
+	}
+	if f := states[7]; f.State != pb.StateRunning || len(f.Allocations) != 1 ||
+		f.TaskGroups != 2 || f.CompletedTaskGroups != 1 || f.RunningTaskGroups != 1 {
+		t.Errorf("unexpected running flow: %v", f)
+	}
+	if f := states[9]; f.State != pb.StateCompleted || f.Name != "sort" {
+		t.Errorf("unexpected completed flow: %v", f)
+	}
+	if f := states[11]; f.State != pb.StateFailed {
+		t.Errorf("expecting flow 11 without reports failed, got %v", f)
+	}
+
+	ms.Flows.expire(now.Add(flowProgressMemory + time.Minute))
+	if flows := ms.flowStatuses(); len(flows) != 1 || flows[0].FlowHashCode != 7 {
+		t.Errorf("expecting only flow 7 with leases left, got %v", flows)
+	}
+}
+
+func TestMigratedShards(t *testing.T) {
+
+	ms := newMasterServer()
+	now := time.Now()
+	ms.Flows.report(&pb.FlowProgress{FlowHashCode: 7, State: pb.StateRunning}, now)
+	ms.Flows.report(&pb.FlowProgress{FlowHashCode: 9, State: pb.StateCompleted}, now)
+
+	if running := ms.runningFlowHashCodes(); len(running) != 1 || running[0] != 7 {
+		t.Errorf("expecting only flow 7 running, got %v", running)
+	}
+
+	target := &pb.Location{Server: "127.0.0.2", Port: 45327}
+	ms.Flows.migrate([]*pb.DataLocation{
+		{Name: "f7-d1-s0", Location: target, OnDisk: true},
+		{Name: "f7-d1-s1", Location: target, OnDisk: true},
+		{Name: "unknown", Location: target, OnDisk: true},
+	})
+	if migrated := ms.Flows.migratedShards(7); len(migrated) != 2 {
+		t.Errorf("expecting 2 migrated shards of flow 7, got %v", migrated)
+	}
+	if migrated := ms.Flows.migratedShards(9); len(migrated) != 0 {
+		t.Errorf("expecting no migrated shards of flow 9, got %v", migrated)
+	}
+
+	ms.Flows.expire(now.Add(flowProgressMemory + time.Minute))
+	if migrated := ms.Flows.migratedShards(7); len(migrated) != 0 {
+		t.Errorf("expecting migrated shards forgotten with the flow, got %v", migrated)
+	}
+}
@@ -0,0 +1,184 @@
+package master
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/gleam/distributed/security"
+	"github.com/chrislusf/gleam/pb"
+	"golang.org/x/net/context"
+)
+
+// History keeps the last progress report of each finished flow as its run
+// record, with the plan, the task stats, errors, agent placements and times,
+// so runs can be compared after their drivers exit. Each run is a JSON file
+// in the history directory. Without a directory, nothing is kept.
+// The files are read and written without holding the master lock.
+type History struct {
+	sync.Mutex // guards runs
+	dir        string
+	maxRuns    int
+	runs       map[uint32]*pb.FlowStatus
+}
+
+// LoadHistory reads the summaries of the runs kept in the directory. Only
+// the latest maxRuns runs are kept, or all runs if maxRuns is 0.
+func LoadHistory(dir string, maxRuns int) (*History, error) {
+	h := &History{
+		dir:     dir,
+		maxRuns: maxRuns,
+		runs:    make(map[uint32]*pb.FlowStatus),
+	}
+	if dir == "" {
+		return h, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		hashCode, ok := runHashCode(f.Name())
+		if !ok {
+			continue
+		}
+		progress, err := h.get(hashCode)
+		if err != nil {
+			log.Printf("Skip flow history %s: %v", f.Name(), err)
+			continue
+		}
+		h.runs[hashCode] = flowStatusOf(progress)
+	}
+	return h, nil
+}
+
+func (h *History) fileName(hashCode uint32) string {
+	return filepath.Join(h.dir, fmt.Sprintf("flow-%d.json", hashCode))
+}
+
+func runHashCode(fileName string) (uint32, bool) {
+	if !strings.HasPrefix(fileName, "flow-") || !strings.HasSuffix(fileName, ".json") {
+		return 0, false
+	}
+	hashCode, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(fileName, "flow-"), ".json"), 10, 32)
+	return uint32(hashCode), err == nil
+}
+
+// save writes the run record, and removes the oldest runs over the limit.
+func (h *History) save(progress *pb.FlowProgress) error {
+	if h.dir == "" {
+		return nil
+	}
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	fileName := h.fileName(progress.FlowHashCode)
+	if err := ioutil.WriteFile(fileName+".tmp", data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(fileName+".tmp", fileName); err != nil {
+		return err
+	}
+
+	h.Lock()
+	h.runs[progress.FlowHashCode] = flowStatusOf(progress)
+	var removed []*pb.FlowStatus
+	if h.maxRuns > 0 && len(h.runs) > h.maxRuns {
+		removed = h.sortedRuns("", 0)[h.maxRuns:]
+		for _, run := range removed {
+			delete(h.runs, run.FlowHashCode)
+		}
+	}
+	h.Unlock()
+
+	for _, run := range removed {
+		if err := os.Remove(h.fileName(run.FlowHashCode)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// list returns the summaries of the runs, the latest first, optionally
+// only the runs with the name, and at most limit runs if limit is positive.
+func (h *History) list(name string, limit int) (runs []*pb.FlowStatus) {
+	h.Lock()
+	defer h.Unlock()
+	return h.sortedRuns(name, limit)
+}
+
+func (h *History) sortedRuns(name string, limit int) (runs []*pb.FlowStatus) {
+	for _, run := range h.runs {
+		if name == "" || run.Name == name {
+			runs = append(runs, run)
+		}
+	}
+	sort.Sort(sort.Reverse(byStartTime(runs)))
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs
+}
+
+// get reads the run record.
+func (h *History) get(hashCode uint32) (*pb.FlowProgress, error) {
+	if h.dir == "" {
+		return nil, fmt.Errorf("flow history is not kept")
+	}
+	data, err := ioutil.ReadFile(h.fileName(hashCode))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("flow %d is not in history", hashCode)
+	}
+	if err != nil {
+		return nil, err
+	}
+	progress := &pb.FlowProgress{}
+	if err := json.Unmarshal(data, progress); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+// recordHistory saves the flows finished, or lost, since the last call.
+// The flows are taken under the master lock, and saved after releasing it.
+func (s *MasterServer) recordHistory(now time.Time) {
+	s.Lock()
+	finished := s.Flows.finished(now)
+	s.Unlock()
+
+	for _, progress := range finished {
+		if err := s.History.save(progress); err != nil {
+			log.Printf("Failed to save flow %d to history: %v", progress.FlowHashCode, err)
+		}
+	}
+}
+
+func (s *MasterServer) ListHistory(ctx context.Context, in *pb.ListHistoryRequest) (*pb.ListHistoryResponse, error) {
+	if err := s.Tokens.CheckContext(ctx, security.PermissionRead); err != nil {
+		return nil, fmt.Errorf("ListHistory denied: %v", err)
+	}
+
+	return &pb.ListHistoryResponse{
+		Flows: s.History.list(in.GetName(), int(in.GetLimit())),
+	}, nil
+}
+
+func (s *MasterServer) GetHistory(ctx context.Context, in *pb.GetHistoryRequest) (*pb.FlowProgress, error) {
+	if err := s.Tokens.CheckContext(ctx, security.PermissionRead); err != nil {
+		return nil, fmt.Errorf("GetHistory denied: %v", err)
+	}
+
+	return s.History.get(in.GetFlowHashCode())
+}
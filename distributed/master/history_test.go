@@ -0,0 +1,91 @@
+package master
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+	"golang.org/x/net/context"
+)
+
+func TestHistory(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "gleam-history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	h, err := LoadHistory(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms := newMasterServer()
+	ms.History = h
+
+	now := time.Now()
+	ms.Flows.report(&pb.FlowProgress{
+		FlowHashCode: 7,
+		Name:         "wordcount",
+		State:        pb.StateCompleted,
+		StartTime:    now.Unix() - 30,
+		StopTime:     now.Unix(),
+		Plan:         `{"steps":[]}`,
+		TaskGroups: []*pb.TaskGroupProgress{
+			{Id: 0, State: pb.StateCompleted, TaskStats: []*pb.InstructionStat{{StepId: 0, OutputRows: 3}}},
+		},
+	}, now)
+	ms.Flows.report(&pb.FlowProgress{
+		FlowHashCode: 9,
+		Name:         "wordcount",
+		State:        pb.StateRunning,
+		StartTime:    now.Unix() - 20,
+	}, now)
+	ms.recordHistory(now)
+
+	if runs := h.list("", 0); len(runs) != 1 || runs[0].FlowHashCode != 7 {
+		t.Fatalf("expecting only the completed flow in history, got %v", runs)
+	}
+
+	// the lost flow is saved as failed, only once
+	ms.recordHistory(now.Add(2 * lostFlowTimeout))
+	ms.recordHistory(now.Add(3 * lostFlowTimeout))
+	runs := h.list("wordcount", 0)
+	if len(runs) != 2 || runs[0].FlowHashCode != 9 || runs[0].State != pb.StateFailed {
+		t.Fatalf("expecting the lost flow failed and listed first, got %v", runs)
+	}
+
+	// the files are read without the master lock, which the drivers and
+	// agents wait for
+	ms.Lock()
+	run, err := ms.GetHistory(context.Background(), &pb.GetHistoryRequest{FlowHashCode: 7})
+	ms.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Plan == "" || run.TaskGroups[0].TaskStats[0].OutputRows != 3 {
+		t.Errorf("expecting the plan and task stats kept, got %v", run)
+	}
+
+	// reloading finds the same runs
+	h, err = LoadHistory(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runs := h.list("", 1); len(runs) != 1 || runs[0].FlowHashCode != 9 {
+		t.Errorf("expecting the latest run after reloading, got %v", runs)
+	}
+
+	// the oldest runs are removed over the limit
+	if err := h.save(&pb.FlowProgress{FlowHashCode: 11, State: pb.StateCompleted, StartTime: now.Unix()}); err != nil {
+		t.Fatal(err)
+	}
+	if runs := h.list("", 0); len(runs) != 2 || runs[0].FlowHashCode != 11 || runs[1].FlowHashCode != 9 {
+		t.Errorf("expecting the 2 latest runs kept, got %v", runs)
+	}
+	if _, err := h.get(7); err == nil {
+		t.Errorf("expecting the oldest run removed")
+	}
+}
@@ -0,0 +1,190 @@
+package master
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+const (
+	DefaultLeaseDuration = time.Minute
+
+	// remember expired leases, to tell agents to kill their executors
+	expiredLeaseMemory = 10 * time.Minute
+)
+
+// Lease is an allocation given to a driver. It expires unless the driver
+// renews it in time, e.g., when the driver is killed.
+type Lease struct {
+	Id         uint64
+	Location   pb.Location
+	Allocated  pb.ComputeResource
+	Queue      string
+	Flow       FlowInfo
+	Created    time.Time
+	Expiration time.Time
+}
+
+// FlowInfo identifies the driver holding a lease.
+type FlowInfo struct {
+	HashCode uint32
+	Name     string
+	User     string
+	Driver   string
+}
+
+func flowInfoOf(in *pb.ComputeRequest) FlowInfo {
+	return FlowInfo{
+		HashCode: in.GetFlowHashCode(),
+		Name:     in.GetFlowName(),
+		User:     in.GetUser(),
+		Driver:   in.GetDriver(),
+	}
+}
+
+type leases struct {
+	Duration    time.Duration
+	active      map[uint64]*Lease
+	expired     map[uint64]time.Time
+	killedFlows map[uint32]time.Time
+	lastId      uint64
+}
+
+func newLeases() *leases {
+	return &leases{
+		Duration:    DefaultLeaseDuration,
+		active:      make(map[uint64]*Lease),
+		expired:     make(map[uint64]time.Time),
+		killedFlows: make(map[uint32]time.Time),
+		// lease ids from a restarted master should not collide with old ones
+		lastId: uint64(time.Now().UnixNano()),
+	}
+}
+
+func (t *Topology) newLease(allocation *pb.Allocation, queue string, flow FlowInfo) {
+	t.leases.lastId++
+	now := time.Now()
+	lease := &Lease{
+		Id:         t.leases.lastId,
+		Location:   *allocation.Location,
+		Allocated:  *allocation.Allocated,
+		Queue:      queue,
+		Flow:       flow,
+		Created:    now,
+		Expiration: now.Add(t.leases.Duration),
+	}
+	t.leases.active[lease.Id] = lease
+	allocation.LeaseId = lease.Id
+}
+
+// renewLeases extends the active leases, and returns the expired ones.
+func (t *Topology) renewLeases(leaseIds []uint64) (expiredLeaseIds []uint64) {
+	expiration := time.Now().Add(t.leases.Duration)
+	for _, id := range leaseIds {
+		if lease, ok := t.leases.active[id]; ok {
+			lease.Expiration = expiration
+		} else {
+			expiredLeaseIds = append(expiredLeaseIds, id)
+		}
+	}
+	return
+}
+
+// releaseLeases ends the leases, as if they have expired.
+func (t *Topology) releaseLeases(leaseIds []uint64) {
+	now := time.Now()
+	for _, id := range leaseIds {
+		if lease, ok := t.leases.active[id]; ok {
+			lease.Expiration = now
+		}
+	}
+	t.reclaimExpiredLeases(now)
+}
+
+// reclaimExpiredLeases returns the resources of expired leases without
+// running executors. The agents kill the executors of expired leases,
+// and stop reporting their resources in the next heartbeats.
+func (t *Topology) reclaimExpiredLeases(now time.Time) {
+	for id, lease := range t.leases.active {
+		if now.Before(lease.Expiration) {
+			continue
+		}
+		delete(t.leases.active, id)
+		t.leases.expired[id] = now
+		fmt.Printf("lease %d expired: %v on %s\n", id, lease.Allocated, lease.Location.URL())
+
+		agent, found := t.findAgentInformation(&lease.Location)
+		if !found || agent.RunningLeaseIds[id] {
+			continue
+		}
+		t.minusAllocated(&lease.Location, lease.Queue, lease.Allocated)
+	}
+	for id, expiredTime := range t.leases.expired {
+		if now.Sub(expiredTime) > expiredLeaseMemory {
+			delete(t.leases.expired, id)
+		}
+	}
+	for hashCode, killedTime := range t.leases.killedFlows {
+		if now.Sub(killedTime) > expiredLeaseMemory {
+			delete(t.leases.killedFlows, hashCode)
+		}
+	}
+}
+
+func (t *Topology) minusAllocated(location *pb.Location, queue string, allocated pb.ComputeResource) {
+	dc, hasDc := t.GetDataCenter(location.DataCenter)
+	if !hasDc {
+		return
+	}
+	rack, hasRack := dc.GetRack(location.Rack)
+	if !hasRack {
+		return
+	}
+	agent, hasAgent := rack.GetAgent(location.URL())
+	if !hasAgent {
+		return
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	agent.Allocated = agent.Allocated.Minus(allocated)
+	agent.QueueAllocated[queue] = agent.QueueAllocated[queue].Minus(allocated)
+	rack.Allocated = rack.Allocated.Minus(allocated)
+	dc.Allocated = dc.Allocated.Minus(allocated)
+	t.Allocated = t.Allocated.Minus(allocated)
+}
+
+// addIdleLeases adds the active leases without running executors to the
+// resources allocated on the agent, since agents only report the
+// resources of running executors.
+func (t *Topology) addIdleLeases(heartbeat *pb.Heartbeat) {
+	running := make(map[uint64]bool)
+	for _, id := range heartbeat.RunningLeaseIds {
+		running[id] = true
+	}
+	allocated := *heartbeat.Allocated
+	for _, lease := range t.leases.active {
+		if running[lease.Id] || lease.Location.URL() != heartbeat.Location.URL() {
+			continue
+		}
+		allocated = allocated.Plus(lease.Allocated)
+		leaseAllocated := lease.Allocated
+		heartbeat.QueueAllocations = append(heartbeat.QueueAllocations, &pb.QueueAllocation{
+			Queue:     lease.Queue,
+			Allocated: &leaseAllocated,
+		})
+	}
+	heartbeat.Allocated = &allocated
+}
+
+// expiredLeasesOf returns the expired leases that still have running executors.
+func (t *Topology) expiredLeasesOf(heartbeat *pb.Heartbeat) (expiredLeaseIds []uint64) {
+	for _, id := range heartbeat.RunningLeaseIds {
+		if _, expired := t.leases.expired[id]; expired {
+			expiredLeaseIds = append(expiredLeaseIds, id)
+		}
+	}
+	return
+}
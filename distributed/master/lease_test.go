@@ -0,0 +1,66 @@
+package master
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+func TestLeaseExpiration(t *testing.T) {
+
+	tp := NewTopology()
+	location := addTestAgent(tp)
+
+	allocation := &pb.Allocation{
+		Location:  location,
+		Allocated: &pb.ComputeResource{CpuCount: 1, MemoryMb: 512},
+	}
+	tp.newLease(allocation, DefaultQueueName, FlowInfo{})
+
+	// an idle lease is counted as allocated, though the agent does not report it
+	heartbeat := &pb.Heartbeat{
+		Location:  location,
+		Resource:  &pb.ComputeResource{CpuCount: 4, MemoryMb: 4096},
+		Allocated: &pb.ComputeResource{},
+	}
+	tp.addIdleLeases(heartbeat)
+	tp.UpdateAgentInformation(heartbeat)
+	if tp.Allocated.MemoryMb != 512 {
+		t.Errorf("expecting 512 MB allocated, got %v", tp.Allocated)
+	}
+
+	if expired := tp.renewLeases([]uint64{allocation.LeaseId}); len(expired) != 0 {
+		t.Errorf("expecting lease renewed, got expired %v", expired)
+	}
+
+	tp.reclaimExpiredLeases(time.Now().Add(2 * tp.leases.Duration))
+	if !tp.Allocated.IsZero() {
+		t.Errorf("expecting expired lease reclaimed, got %v", tp.Allocated)
+	}
+	if expired := tp.renewLeases([]uint64{allocation.LeaseId}); len(expired) != 1 {
+		t.Errorf("expecting lease expired, got %v", expired)
+	}
+
+	// executors of expired leases are killed by the agent
+	running := &pb.Heartbeat{
+		Location:        location,
+		Resource:        &pb.ComputeResource{CpuCount: 4, MemoryMb: 4096},
+		Allocated:       &pb.ComputeResource{CpuCount: 1, MemoryMb: 512},
+		RunningLeaseIds: []uint64{allocation.LeaseId},
+	}
+	if expired := tp.expiredLeasesOf(running); len(expired) != 1 {
+		t.Errorf("expecting the running executor to be killed, got %v", expired)
+	}
+}
+
+// addTestAgent adds an agent with 4 cpus and 4 GB memory to the topology.
+func addTestAgent(tp *Topology) *pb.Location {
+	location := &pb.Location{DataCenter: "dc", Rack: "rack", Server: "localhost", Port: 45327}
+	tp.UpdateAgentInformation(&pb.Heartbeat{
+		Location:  location,
+		Resource:  &pb.ComputeResource{CpuCount: 4, MemoryMb: 4096},
+		Allocated: &pb.ComputeResource{},
+	})
+	return location
+}
@@ -0,0 +1,68 @@
+package master
+
+import (
+	"github.com/chrislusf/gleam/pb"
+)
+
+// hostPreferencesOf maps the requested resources to their preferred hosts.
+func hostPreferencesOf(in *pb.ComputeRequest) map[*pb.ComputeResource][]string {
+	preferences := make(map[*pb.ComputeResource][]string)
+	for i, preference := range in.GetHostPreferences() {
+		if i < len(in.GetComputeResources()) && len(preference.GetHosts()) > 0 {
+			preferences[in.ComputeResources[i]] = preference.GetHosts()
+		}
+	}
+	return preferences
+}
+
+// findServersNearHosts allocates the requests on their preferred hosts,
+// or else on the racks of the preferred hosts. The requests without
+// preferred hosts, or not allocated, are returned as remaining requests.
+func (t *Topology) findServersNearHosts(dc *DataCenter, queue string, requests []*pb.ComputeResource,
+	preferences map[*pb.ComputeResource][]string) (allocated []*pb.Allocation, remainingRequests []*pb.ComputeResource) {
+	for _, request := range requests {
+		hosts := preferences[request]
+		if len(hosts) == 0 {
+			remainingRequests = append(remainingRequests, request)
+			continue
+		}
+		if allocation := t.allocateNearHosts(dc, queue, request, hosts); allocation != nil {
+			allocated = append(allocated, allocation)
+		} else {
+			remainingRequests = append(remainingRequests, request)
+		}
+	}
+	return
+}
+
+func (t *Topology) allocateNearHosts(dc *DataCenter, queue string, request *pb.ComputeResource, hosts []string) *pb.Allocation {
+	preferredRacks := make(map[string]bool)
+	for _, host := range hosts {
+		for _, rack := range dc.GetRacks() {
+			for _, agent := range rack.GetAgents() {
+				if !agent.Location.IsOnHost(host) {
+					continue
+				}
+				preferredRacks[rack.Name] = true
+				if t.canAllocate(agent, request) {
+					return t.allocateOnAgent(dc, rack, agent, queue, request)
+				}
+			}
+		}
+	}
+	for _, rack := range dc.GetRacks() {
+		if !preferredRacks[rack.Name] {
+			continue
+		}
+		for _, agent := range rack.GetAgents() {
+			if t.canAllocate(agent, request) {
+				return t.allocateOnAgent(dc, rack, agent, queue, request)
+			}
+		}
+	}
+	return nil
+}
+
+func (t *Topology) canAllocate(agent *AgentInformation, request *pb.ComputeResource) bool {
+	return t.isAllocatable(agent) && availableOn(agent).Accommodates(*request)
+}
@@ -0,0 +1,50 @@
+package master
+
+import (
+	"testing"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+func TestFindServersNearHosts(t *testing.T) {
+
+	tp := NewTopology()
+	for _, location := range []*pb.Location{
+		{DataCenter: "dc", Rack: "r1", Server: "host1.example.com", Port: 45327},
+		{DataCenter: "dc", Rack: "r1", Server: "host2.example.com", Port: 45327},
+		{DataCenter: "dc", Rack: "r2", Server: "host3.example.com", Port: 45327},
+	} {
+		tp.UpdateAgentInformation(&pb.Heartbeat{
+			Location:  location,
+			Resource:  &pb.ComputeResource{CpuCount: 1, MemoryMb: 1024},
+			Allocated: &pb.ComputeResource{},
+		})
+	}
+	dc, _ := tp.GetDataCenter("dc")
+
+	executor := func() *pb.ComputeResource {
+		return &pb.ComputeResource{CpuCount: 1, MemoryMb: 512}
+	}
+	in := &pb.ComputeRequest{
+		ComputeResources: []*pb.ComputeResource{executor(), executor(), executor(), executor()},
+		HostPreferences: []*pb.HostPreference{
+			{Hosts: []string{"HOST1"}},
+			{Hosts: []string{"host1.example.com"}},
+			{},
+			{Hosts: []string{"unknown"}},
+		},
+	}
+
+	allocated, remaining := tp.findServersNearHosts(dc, DefaultQueueName, in.ComputeResources, hostPreferencesOf(in))
+	if len(allocated) != 2 || len(remaining) != 2 {
+		t.Fatalf("expecting 2 allocated and 2 remaining, got %v and %v", allocated, remaining)
+	}
+	// node local
+	if allocated[0].Location.Server != "host1.example.com" {
+		t.Errorf("expecting node local allocation, got %v", allocated[0].Location)
+	}
+	// host1 is full, so rack local
+	if allocated[1].Location.Server != "host2.example.com" {
+		t.Errorf("expecting rack local allocation, got %v", allocated[1].Location)
+	}
+}
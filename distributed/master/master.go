@@ -5,9 +5,11 @@ package master
 import (
 	"io"
 	"log"
-	"net"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/chrislusf/gleam/distributed/security"
 	"github.com/chrislusf/gleam/pb"
 	"github.com/soheilhy/cmux"
 	"google.golang.org/grpc"
@@ -20,9 +22,68 @@ func init() {
 	masterServer = newMasterServer()
 }
 
-func RunMaster(listenOn string) {
+type MasterOption struct {
+	Address    *string
+	TokenFile  *string
+	QueueFile  *string
+	Preemption *bool
+	// drivers need to renew their leases within this duration
+	LeaseDuration *time.Duration
+	// agents are suspect, and then dead, after missing heartbeats
+	HeartbeatInterval       *time.Duration
+	SuspectMissedHeartbeats *int
+	DeadMissedHeartbeats    *int
+	// skip agents busy with other processes or low on disk
+	MaxCPUUsage   *float64
+	MinFreeDiskMB *int64
+	// keep the run records of finished flows, if the directory is set
+	HistoryDir     *string
+	HistoryMaxRuns *int
+}
+
+// RunMaster starts the master. If a token file is set, clients need to
+// present a token with proper permissions.
+func RunMaster(option *MasterOption) {
+
+	listenOn := *option.Address
 
-	listener, err := net.Listen("tcp", listenOn)
+	tokens, err := security.LoadTokenFile(*option.TokenFile)
+	if err != nil {
+		log.Fatalf("Failed to load tokens: %v", err)
+	}
+	if err := tokens.RequireTLS(); err != nil {
+		log.Fatalf("Failed to use token file %s: %v", *option.TokenFile, err)
+	}
+	masterServer.Tokens = tokens
+
+	queues, err := LoadQueueFile(*option.QueueFile)
+	if err != nil {
+		log.Fatalf("Failed to load queues: %v", err)
+	}
+	queues.Preemption = *option.Preemption
+	masterServer.Queues = queues
+
+	history, err := LoadHistory(*option.HistoryDir, *option.HistoryMaxRuns)
+	if err != nil {
+		log.Fatalf("Failed to load flow history: %v", err)
+	}
+	masterServer.History = history
+
+	if *option.LeaseDuration > 0 {
+		masterServer.Topology.leases.Duration = *option.LeaseDuration
+	}
+	masterServer.Topology.LoadLimits = LoadLimits{
+		MaxCPUUsage:   *option.MaxCPUUsage,
+		MinFreeDiskMB: *option.MinFreeDiskMB,
+	}
+
+	go masterServer.reclaimExpiredLeases()
+	if *option.HeartbeatInterval > 0 {
+		go masterServer.checkHeartbeats(*option.HeartbeatInterval,
+			*option.SuspectMissedHeartbeats, *option.DeadMissedHeartbeats)
+	}
+
+	listener, err := security.Listen(listenOn)
 	if err != nil {
 		log.Fatalf("master server fails to listen on %s: %v", listenOn, err)
 	}
@@ -38,7 +99,7 @@ func RunMaster(listenOn string) {
 	pb.RegisterGleamMasterServer(grpcS, masterServer)
 	reflection.Register(grpcS)
 
-	httpS := &http.Server{Handler: &masterHttpHandler{mux: mux}}
+	httpS := &http.Server{Handler: &masterHttpHandler{mux: mux, tokens: tokens}}
 
 	go grpcS.Serve(grpcL)
 	go httpS.Serve(httpL)
@@ -49,14 +110,29 @@ func RunMaster(listenOn string) {
 
 }
 
+// masterHttpHandler serves the exact paths in mux. The paths ending with
+// a slash, except the root, also serve all paths under them.
+// With a token file, all paths need a token with read permission.
 type masterHttpHandler struct {
-	mux map[string]func(http.ResponseWriter, *http.Request)
+	mux    map[string]func(http.ResponseWriter, *http.Request)
+	tokens *security.TokenStore
 }
 
 func (m *masterHttpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if h, ok := m.mux[r.URL.String()]; ok {
+	if err := m.tokens.CheckRequest(r, security.PermissionRead); err != nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="gleam"`)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if h, ok := m.mux[r.URL.Path]; ok {
 		h(w, r)
 		return
 	}
+	for path, h := range m.mux {
+		if path != "/" && strings.HasSuffix(path, "/") && strings.HasPrefix(r.URL.Path, path) {
+			h(w, r)
+			return
+		}
+	}
 	io.WriteString(w, "My server: "+r.URL.String())
 }
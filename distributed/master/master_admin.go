@@ -0,0 +1,202 @@
+package master
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chrislusf/gleam/distributed/security"
+	"github.com/chrislusf/gleam/pb"
+	"golang.org/x/net/context"
+)
+
+func (s *MasterServer) GetTopology(ctx context.Context, in *pb.TopologyRequest) (*pb.TopologyResponse, error) {
+	if err := s.Tokens.CheckContext(ctx, security.PermissionRead); err != nil {
+		return nil, fmt.Errorf("GetTopology denied: %v", err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	return s.Topology.status(), nil
+}
+
+func (s *MasterServer) ListFlows(ctx context.Context, in *pb.ListFlowsRequest) (*pb.ListFlowsResponse, error) {
+	if err := s.Tokens.CheckContext(ctx, security.PermissionRead); err != nil {
+		return nil, fmt.Errorf("ListFlows denied: %v", err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	return &pb.ListFlowsResponse{
+		Flows: s.flowStatuses(),
+	}, nil
+}
+
+func (s *MasterServer) KillFlow(ctx context.Context, in *pb.KillFlowRequest) (*pb.KillFlowResponse, error) {
+	if err := s.Tokens.CheckContext(ctx, security.PermissionAdmin); err != nil {
+		return nil, fmt.Errorf("KillFlow denied: %v", err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	killed, err := s.Topology.killFlow(in.GetFlowHashCode())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.KillFlowResponse{
+		KilledLeases: int32(killed),
+	}, nil
+}
+
+func (t *Topology) status() *pb.TopologyResponse {
+	resource, allocated := t.Resource, t.Allocated
+	ret := &pb.TopologyResponse{
+		Resource:  &resource,
+		Allocated: &allocated,
+	}
+	dcs := t.GetDataCenters()
+	var dcNames []string
+	for name := range dcs {
+		dcNames = append(dcNames, name)
+	}
+	sort.Strings(dcNames)
+	for _, dcName := range dcNames {
+		ret.DataCenters = append(ret.DataCenters, dataCenterStatusOf(dcs[dcName]))
+	}
+	return ret
+}
+
+func dataCenterStatusOf(dc *DataCenter) *pb.DataCenterStatus {
+	resource, allocated := dc.Resource, dc.Allocated
+	ret := &pb.DataCenterStatus{
+		Name:      dc.Name,
+		Resource:  &resource,
+		Allocated: &allocated,
+	}
+	racks := dc.GetRacks()
+	var rackNames []string
+	for name := range racks {
+		rackNames = append(rackNames, name)
+	}
+	sort.Strings(rackNames)
+	for _, rackName := range rackNames {
+		ret.Racks = append(ret.Racks, rackStatusOf(racks[rackName]))
+	}
+	return ret
+}
+
+func rackStatusOf(rack *Rack) *pb.RackStatus {
+	resource, allocated := rack.Resource, rack.Allocated
+	ret := &pb.RackStatus{
+		Name:      rack.Name,
+		Resource:  &resource,
+		Allocated: &allocated,
+	}
+	agents := rack.GetAgents()
+	var urls []string
+	for url := range agents {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+	for _, url := range urls {
+		ret.Agents = append(ret.Agents, agentStatusOf(agents[url]))
+	}
+	return ret
+}
+
+func agentStatusOf(agent *AgentInformation) *pb.AgentStatus {
+	location, resource, allocated := agent.Location, agent.Resource, agent.Allocated
+	return &pb.AgentStatus{
+		Location:      &location,
+		Resource:      &resource,
+		Allocated:     &allocated,
+		State:         agent.State,
+		Draining:      agent.Draining,
+		LastHeartbeat: agent.LastHeartBeat.Unix(),
+		Load:          agent.Load,
+	}
+}
+
+// flowStatuses groups the active leases by their drivers.
+func (t *Topology) flowStatuses() (flows []*pb.FlowStatus) {
+	byHashCode := make(map[uint32]*pb.FlowStatus)
+	for _, lease := range t.leases.active {
+		flow, found := byHashCode[lease.Flow.HashCode]
+		if !found {
+			flow = &pb.FlowStatus{
+				FlowHashCode: lease.Flow.HashCode,
+				Name:         lease.Flow.Name,
+				User:         lease.Flow.User,
+				Queue:        lease.Queue,
+				Driver:       lease.Flow.Driver,
+				StartTime:    lease.Created.Unix(),
+			}
+			byHashCode[lease.Flow.HashCode] = flow
+			flows = append(flows, flow)
+		}
+		if created := lease.Created.Unix(); created < flow.StartTime {
+			flow.StartTime = created
+		}
+		location, allocated := lease.Location, lease.Allocated
+		flow.Allocations = append(flow.Allocations, &pb.Allocation{
+			Location:  &location,
+			Allocated: &allocated,
+			LeaseId:   lease.Id,
+		})
+	}
+	sort.Sort(byStartTime(flows))
+	for _, flow := range flows {
+		sort.Sort(byLeaseId(flow.Allocations))
+	}
+	return flows
+}
+
+// killFlow ends all leases of the flow. The agents kill its executors,
+// and the driver is refused new allocations and told to exit.
+func (t *Topology) killFlow(hashCode uint32) (killed int, err error) {
+	if hashCode == 0 {
+		return 0, fmt.Errorf("missing flow hash code")
+	}
+	var leaseIds []uint64
+	for id, lease := range t.leases.active {
+		if lease.Flow.HashCode == hashCode {
+			leaseIds = append(leaseIds, id)
+		}
+	}
+	if len(leaseIds) == 0 {
+		return 0, fmt.Errorf("flow %d is not found", hashCode)
+	}
+	t.leases.killedFlows[hashCode] = time.Now()
+	t.releaseLeases(leaseIds)
+	return len(leaseIds), nil
+}
+
+// isKilledFlow checks whether the flow is killed recently.
+// Drivers not sending their flow hash codes can not be killed.
+func (t *Topology) isKilledFlow(hashCode uint32) bool {
+	if hashCode == 0 {
+		return false
+	}
+	_, killed := t.leases.killedFlows[hashCode]
+	return killed
+}
+
+type byStartTime []*pb.FlowStatus
+
+func (s byStartTime) Len() int      { return len(s) }
+func (s byStartTime) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byStartTime) Less(i, j int) bool {
+	if s[i].StartTime != s[j].StartTime {
+		return s[i].StartTime < s[j].StartTime
+	}
+	return s[i].FlowHashCode < s[j].FlowHashCode
+}
+
+type byLeaseId []*pb.Allocation
+
+func (s byLeaseId) Len() int           { return len(s) }
+func (s byLeaseId) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byLeaseId) Less(i, j int) bool { return s[i].LeaseId < s[j].LeaseId }
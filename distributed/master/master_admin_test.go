@@ -0,0 +1,43 @@
+package master
+
+import (
+	"testing"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+func TestKillFlow(t *testing.T) {
+
+	tp := NewTopology()
+	location := addTestAgent(tp)
+
+	for i, hashCode := range []uint32{7, 7, 9} {
+		tp.newLease(&pb.Allocation{
+			Location:  location,
+			Allocated: &pb.ComputeResource{CpuCount: 1, MemoryMb: int64(256 * (i + 1))},
+		}, DefaultQueueName, FlowInfo{HashCode: hashCode, Name: "wordcount"})
+	}
+
+	flows := tp.flowStatuses()
+	if len(flows) != 2 {
+		t.Fatalf("expecting 2 flows, got %v", flows)
+	}
+	for _, flow := range flows {
+		if flow.FlowHashCode == 7 && len(flow.Allocations) != 2 {
+			t.Errorf("expecting 2 allocations of flow 7, got %v", flow.Allocations)
+		}
+	}
+
+	if killed, err := tp.killFlow(7); err != nil || killed != 2 {
+		t.Errorf("expecting 2 leases killed, got %d: %v", killed, err)
+	}
+	if !tp.isKilledFlow(7) || tp.isKilledFlow(9) {
+		t.Errorf("expecting only flow 7 killed")
+	}
+	if flows := tp.flowStatuses(); len(flows) != 1 || flows[0].FlowHashCode != 9 {
+		t.Errorf("expecting flow 9 still running, got %v", flows)
+	}
+	if _, err := tp.killFlow(7); err == nil {
+		t.Errorf("expecting no leases left for flow 7")
+	}
+}
@@ -0,0 +1,182 @@
+package master
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+// apiPrefix is the versioned JSON API, for dashboards and alerts:
+//
+//	GET /api/v1/topology                    data centers, racks and agents
+//	GET /api/v1/agents                      all agents
+//	GET /api/v1/agents/{host:port}          one agent
+//	GET /api/v1/flows                       running and recently finished flows
+//	GET /api/v1/flows/{hashCode}            one flow
+//	GET /api/v1/flows/{hashCode}/taskgroups progress of the task groups of a flow
+//	GET /api/v1/flows/{hashCode}/progress   steps, datasets and task groups of a flow
+//	GET /api/v1/history?name=&limit=        finished flows kept in the history, the latest first
+//	GET /api/v1/history/{hashCode}          the run record of a finished flow
+//
+// With a token file, requests need a token with read permission, sent as
+// "Authorization: Bearer <token>", which masterHttpHandler checks.
+const apiPrefix = "/api/v1/"
+
+func (ms *MasterServer) apiHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, apiPrefix), "/")
+	parts := strings.Split(path, "/")
+
+	// the history is kept in files, read without holding the master lock
+	if parts[0] == "history" {
+		ms.historyApiHandler(w, r, parts)
+		return
+	}
+
+	ms.Lock()
+	defer ms.Unlock()
+
+	switch {
+	case path == "topology":
+		writeJSON(w, ms.Topology.status())
+	case path == "agents":
+		writeJSON(w, ms.agentStatuses())
+	case len(parts) == 2 && parts[0] == "agents":
+		agent, found := ms.Topology.agentByURL(parts[1])
+		if !found {
+			writeAPIError(w, http.StatusNotFound, fmt.Errorf("agent %s is not found", parts[1]))
+			return
+		}
+		writeJSON(w, agentStatusOf(agent))
+	case path == "flows":
+		flows := ms.flowStatuses()
+		if flows == nil {
+			flows = []*pb.FlowStatus{}
+		}
+		writeJSON(w, flows)
+	case len(parts) == 2 && parts[0] == "flows":
+		flow, err := ms.findFlow(parts[1])
+		if err != nil {
+			writeAPIError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, flow)
+	case len(parts) == 3 && parts[0] == "flows" && parts[2] == "taskgroups":
+		if _, err := ms.findFlow(parts[1]); err != nil {
+			writeAPIError(w, http.StatusNotFound, err)
+			return
+		}
+		// flows from older drivers hold leases without reporting progress
+		taskGroups := []*pb.TaskGroupProgress{}
+		hashCode, _ := strconv.ParseUint(parts[1], 10, 32)
+		if progress, found := ms.Flows.get(uint32(hashCode), time.Now()); found {
+			taskGroups = append(taskGroups, progress.TaskGroups...)
+		}
+		writeJSON(w, taskGroups)
+	case len(parts) == 3 && parts[0] == "flows" && parts[2] == "progress":
+		hashCode, _ := strconv.ParseUint(parts[1], 10, 32)
+		progress, found := ms.Flows.get(uint32(hashCode), time.Now())
+		if !found {
+			writeAPIError(w, http.StatusNotFound, fmt.Errorf("flow %s has not reported progress", parts[1]))
+			return
+		}
+		writeJSON(w, progress)
+	default:
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("%s is not found", r.URL.Path))
+	}
+}
+
+func (ms *MasterServer) agentStatuses() []*pb.AgentStatus {
+	var agents []*AgentInformation
+	for _, dc := range ms.Topology.GetDataCenters() {
+		for _, rack := range dc.GetRacks() {
+			for _, agent := range rack.GetAgents() {
+				agents = append(agents, agent)
+			}
+		}
+	}
+	sort.Sort(byAgentURL(agents))
+	statuses := []*pb.AgentStatus{}
+	for _, agent := range agents {
+		statuses = append(statuses, agentStatusOf(agent))
+	}
+	return statuses
+}
+
+func (t *Topology) agentByURL(url string) (*AgentInformation, bool) {
+	for _, dc := range t.GetDataCenters() {
+		for _, rack := range dc.GetRacks() {
+			if agent, found := rack.GetAgents()[url]; found {
+				return agent, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (ms *MasterServer) historyApiHandler(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 1:
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		runs := ms.History.list(r.URL.Query().Get("name"), limit)
+		if runs == nil {
+			runs = []*pb.FlowStatus{}
+		}
+		writeJSON(w, runs)
+	case len(parts) == 2:
+		hashCode, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil {
+			writeAPIError(w, http.StatusNotFound, fmt.Errorf("invalid flow hash code %s", parts[1]))
+			return
+		}
+		run, err := ms.History.get(uint32(hashCode))
+		if err != nil {
+			writeAPIError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, run)
+	default:
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("%s is not found", r.URL.Path))
+	}
+}
+
+func (ms *MasterServer) findFlow(id string) (*pb.FlowStatus, error) {
+	hashCode, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid flow hash code %s", id)
+	}
+	for _, flow := range ms.flowStatuses() {
+		if flow.FlowHashCode == uint32(hashCode) {
+			return flow, nil
+		}
+	}
+	return nil, fmt.Errorf("flow %s is not found", id)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(append(data, '\n'))
+}
+
+func writeAPIError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
@@ -3,26 +3,60 @@ package master
 import (
 	"fmt"
 	"io"
+	"sync"
+	"time"
 
+	"github.com/chrislusf/gleam/distributed/security"
 	"github.com/chrislusf/gleam/pb"
 	"golang.org/x/net/context"
 )
 
 type MasterServer struct {
+	// serialize allocations to keep the queue shares accurate
+	sync.Mutex
 	Topology *Topology
+	Tokens   *security.TokenStore
+	Queues   *QueueManager
+	Flows    *FlowProgresses
+	History  *History
 }
 
 func newMasterServer() *MasterServer {
 	return &MasterServer{
 		Topology: NewTopology(),
+		Queues:   NewQueueManager(),
+		Flows:    NewFlowProgresses(),
+		History:  &History{runs: make(map[uint32]*pb.FlowStatus)},
 	}
 }
 
 func (s *MasterServer) GetResources(ctx context.Context, in *pb.ComputeRequest) (*pb.AllocationResult, error) {
-	var err error
+	if err := s.Tokens.CheckContext(ctx, security.PermissionSubmit); err != nil {
+		return nil, fmt.Errorf("GetResources denied: %v", err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	s.Queues.Lock()
+	defer s.Queues.Unlock()
+
+	if s.Topology.isKilledFlow(in.GetFlowHashCode()) {
+		return nil, fmt.Errorf("flow %d is killed", in.GetFlowHashCode())
+	}
+	queue, err := s.Queues.getQueue(in.GetQueue(), in.GetUser())
+	if err != nil {
+		return nil, err
+	}
+	s.Queues.refreshAllocated(s.Topology)
+	requests := s.Queues.admit(queue, in.GetComputeResources(), s.Topology.Resource)
+	if len(requests) == 0 {
+		s.Queues.recordPending(queue, in.GetFlowHashCode(), len(in.GetComputeResources()))
+		return &pb.AllocationResult{}, nil
+	}
+
 	dcName := in.GetDataCenter()
 	if dcName == "" {
-		dcName, err = s.Topology.allocateDataCenter(in.GetComputeResources())
+		dcName, err = s.Topology.allocateDataCenter(requests)
 		if err != nil {
 			return nil, err
 		}
@@ -32,15 +66,112 @@ func (s *MasterServer) GetResources(ctx context.Context, in *pb.ComputeRequest)
 		return nil, fmt.Errorf("Failed to find existing data center: %s", dcName)
 	}
 
-	Allocations := s.Topology.findServers(dc, in.GetComputeResources())
+	// place the requests near their inputs first
+	Allocations, remainingRequests := s.Topology.findServersNearHosts(dc, queue.Name, requests, hostPreferencesOf(in))
+	Allocations = append(Allocations, s.Topology.findServers(dc, queue.Name, remainingRequests)...)
+	flow := flowInfoOf(in)
+	for _, allocation := range Allocations {
+		s.Topology.newLease(allocation, queue.Name, flow)
+	}
+
+	s.Queues.recordAllocations(queue, len(in.GetComputeResources()), Allocations)
+	s.Queues.recordPending(queue, in.GetFlowHashCode(), len(in.GetComputeResources())-len(Allocations))
+	if len(Allocations) < len(requests) {
+		var shortfall pb.ComputeResource
+		for _, r := range requests {
+			shortfall = shortfall.Plus(*r)
+		}
+		for _, a := range Allocations {
+			shortfall = shortfall.Minus(*a.Allocated)
+		}
+		s.Queues.preempt(s.Topology, queue, shortfall)
+	}
 
 	return &pb.AllocationResult{
-		Allocations: Allocations,
+		Allocations:  Allocations,
+		LeaseSeconds: int64(s.Topology.leases.Duration / time.Second),
 	}, nil
 
 }
 
+func (s *MasterServer) RenewLeases(ctx context.Context, in *pb.LeaseRequest) (*pb.LeaseResponse, error) {
+	if err := s.Tokens.CheckContext(ctx, security.PermissionSubmit); err != nil {
+		return nil, fmt.Errorf("RenewLeases denied: %v", err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	return &pb.LeaseResponse{
+		ExpiredLeaseIds: s.Topology.renewLeases(in.GetLeaseIds()),
+		DeadAgents:      s.Topology.getDeadAgents(),
+		FlowKilled:      s.Topology.isKilledFlow(in.GetFlowHashCode()),
+	}, nil
+}
+
+func (s *MasterServer) ReleaseLeases(ctx context.Context, in *pb.LeaseRequest) (*pb.LeaseResponse, error) {
+	if err := s.Tokens.CheckContext(ctx, security.PermissionSubmit); err != nil {
+		return nil, fmt.Errorf("ReleaseLeases denied: %v", err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.Topology.releaseLeases(in.GetLeaseIds())
+
+	return &pb.LeaseResponse{}, nil
+}
+
+func (s *MasterServer) DrainAgent(ctx context.Context, in *pb.DrainAgentRequest) (*pb.DrainAgentResponse, error) {
+	if err := s.Tokens.CheckContext(ctx, security.PermissionAdmin); err != nil {
+		return nil, fmt.Errorf("DrainAgent denied: %v", err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	targets, err := s.Topology.drainAgent(in.GetLocation())
+	if err != nil {
+		return nil, err
+	}
+	s.Flows.migrate(in.GetMigratedShards())
+	return &pb.DrainAgentResponse{
+		Targets:              targets,
+		RunningFlowHashCodes: s.runningFlowHashCodes(),
+	}, nil
+}
+
+// checkHeartbeats periodically finds agents missing heartbeats.
+func (s *MasterServer) checkHeartbeats(interval time.Duration, suspectMissed, deadMissed int) {
+	for {
+		time.Sleep(interval)
+		s.Lock()
+		s.Topology.checkHeartbeats(time.Now(),
+			interval*time.Duration(suspectMissed), interval*time.Duration(deadMissed))
+		s.Unlock()
+	}
+}
+
+// reclaimExpiredLeases periodically returns the resources of dead drivers,
+// saves the finished and lost flows to the history, and forgets the flows
+// not reported for long.
+func (s *MasterServer) reclaimExpiredLeases() {
+	for {
+		time.Sleep(time.Second)
+		now := time.Now()
+		s.recordHistory(now)
+		s.Lock()
+		s.Topology.reclaimExpiredLeases(now)
+		s.Flows.expire(now)
+		s.Unlock()
+	}
+}
+
 func (s *MasterServer) SendHeartbeat(stream pb.GleamMaster_SendHeartbeatServer) error {
+	if err := s.Tokens.CheckContext(stream.Context(), security.PermissionAdmin); err != nil {
+		return fmt.Errorf("SendHeartbeat denied: %v", err)
+	}
+
 	var location *pb.Location
 	for {
 		heartbeat, err := stream.Recv()
@@ -51,7 +182,9 @@ func (s *MasterServer) SendHeartbeat(stream pb.GleamMaster_SendHeartbeatServer)
 			}
 		} else {
 			if location != nil {
+				s.Lock()
 				s.Topology.deleteAgentInformation(location)
+				s.Unlock()
 			}
 			fmt.Printf("lost agent: %v\n", location)
 
@@ -62,6 +195,34 @@ func (s *MasterServer) SendHeartbeat(stream pb.GleamMaster_SendHeartbeatServer)
 				return err
 			}
 		}
+		s.Lock()
+		s.Topology.reviveAgent(heartbeat.Location)
+		s.Topology.addIdleLeases(heartbeat)
 		s.Topology.UpdateAgentInformation(heartbeat)
+		expiredLeaseIds := s.Topology.expiredLeasesOf(heartbeat)
+		s.Unlock()
+
+		preemptRequests := s.takePendingPreemptions(location)
+		if len(preemptRequests) > 0 || len(expiredLeaseIds) > 0 {
+			if err := stream.Send(&pb.HeartbeatResponse{
+				PreemptRequests: preemptRequests,
+				ExpiredLeaseIds: expiredLeaseIds,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *MasterServer) takePendingPreemptions(location *pb.Location) []*pb.PreemptRequest {
+	s.Lock()
+	defer s.Unlock()
+
+	ai, ok := s.Topology.findAgentInformation(location)
+	if !ok {
+		return nil
 	}
+	preemptRequests := ai.PendingPreemptions
+	ai.PendingPreemptions = nil
+	return preemptRequests
 }
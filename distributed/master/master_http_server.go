@@ -4,8 +4,12 @@ package master
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/chrislusf/gleam/distributed/master/ui"
+	"github.com/chrislusf/gleam/distributed/metrics"
+	"github.com/chrislusf/gleam/pb"
 )
 
 var mux map[string]func(http.ResponseWriter, *http.Request)
@@ -13,17 +17,45 @@ var mux map[string]func(http.ResponseWriter, *http.Request)
 func init() {
 	mux = make(map[string]func(http.ResponseWriter, *http.Request))
 	mux["/"] = masterServer.uiStatusHandler
+	mux["/metrics"] = metrics.Handler(masterServer.metricsHandler)
+	mux[apiPrefix] = masterServer.apiHandler
+	mux["/flows/"] = masterServer.uiFlowHandler
 }
 
 func (ms *MasterServer) uiStatusHandler(w http.ResponseWriter, r *http.Request) {
 	infos := make(map[string]interface{})
 	infos["Version"] = 0.01
+	ms.Lock()
+	queues := ms.Queues.Statuses(ms.Topology)
+	flows := ms.flowStatuses()
+	ms.Unlock()
 	args := struct {
 		Version  string
 		Topology interface{}
+		Queues   []QueueStatus
+		Flows    []*pb.FlowStatus
 	}{
 		"0.01",
 		ms.Topology,
+		queues,
+		flows,
 	}
 	ui.StatusTpl.Execute(w, args)
 }
+
+// uiFlowHandler serves /flows/{hashCode}, showing the progress of the flow.
+func (ms *MasterServer) uiFlowHandler(w http.ResponseWriter, r *http.Request) {
+	hashCode, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/flows/"), 10, 32)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	args := struct {
+		Version      string
+		FlowHashCode uint32
+	}{
+		"0.01",
+		uint32(hashCode),
+	}
+	ui.FlowTpl.Execute(w, args)
+}
@@ -0,0 +1,119 @@
+package master
+
+import (
+	"sort"
+
+	"github.com/chrislusf/gleam/distributed/metrics"
+	"github.com/chrislusf/gleam/pb"
+)
+
+func (ms *MasterServer) metricsHandler(m *metrics.Writer) {
+	ms.Lock()
+	queues := ms.Queues.Statuses(ms.Topology)
+	flows := ms.Topology.flowStatuses()
+	leaseCount := len(ms.Topology.leases.active)
+	deadAgentCount := len(ms.Topology.deadAgents)
+	var agents []*AgentInformation
+	for _, dc := range ms.Topology.GetDataCenters() {
+		for _, rack := range dc.GetRacks() {
+			for _, agent := range rack.GetAgents() {
+				agents = append(agents, agent)
+			}
+		}
+	}
+	sort.Sort(byAgentURL(agents))
+	// copy the agent information, which heartbeats keep updating
+	snapshots := make([]AgentInformation, len(agents))
+	for i, agent := range agents {
+		snapshots[i] = *agent
+	}
+	total, allocated := ms.Topology.Resource, ms.Topology.Allocated
+	ms.Unlock()
+
+	stateCounts := map[string]int{AgentAlive: 0, AgentSuspect: 0}
+	drainingCount := 0
+	for _, agent := range snapshots {
+		stateCounts[agent.State]++
+		if agent.Draining {
+			drainingCount++
+		}
+	}
+	m.Header("gleam_master_agents", metrics.Gauge, "Number of agents by state.")
+	for _, state := range []string{AgentAlive, AgentSuspect} {
+		m.Sample("gleam_master_agents", float64(stateCounts[state]), "state", state)
+	}
+	m.Metric("gleam_master_draining_agents", metrics.Gauge, "Number of agents being drained.", float64(drainingCount))
+	m.Metric("gleam_master_dead_agents", metrics.Gauge, "Number of agents removed recently for missing heartbeats.", float64(deadAgentCount))
+
+	m.Metric("gleam_master_cluster_cpu_cores", metrics.Gauge, "Cpu cores of the cluster.", cpuCores(total))
+	m.Metric("gleam_master_cluster_memory_megabytes", metrics.Gauge, "Memory of the cluster.", memoryMb(total))
+	m.Metric("gleam_master_cluster_allocated_cpu_cores", metrics.Gauge, "Allocated cpu cores of the cluster.", cpuCores(allocated))
+	m.Metric("gleam_master_cluster_allocated_memory_megabytes", metrics.Gauge, "Allocated memory of the cluster.", memoryMb(allocated))
+
+	resourceMetrics := []struct {
+		name, allocatedName, help string
+		value                     func(r pb.ComputeResource) float64
+	}{
+		{"gleam_master_agent_cpu_cores", "gleam_master_agent_allocated_cpu_cores", "cpu cores of the agent.", cpuCores},
+		{"gleam_master_agent_memory_megabytes", "gleam_master_agent_allocated_memory_megabytes", "memory of the agent.", memoryMb},
+		{"gleam_master_agent_disk_megabytes", "gleam_master_agent_allocated_disk_megabytes", "disk space of the agent for datasets.", diskMb},
+	}
+	for _, rm := range resourceMetrics {
+		m.Header(rm.name, metrics.Gauge, "Total "+rm.help)
+		for _, agent := range snapshots {
+			m.Sample(rm.name, rm.value(agent.Resource), agentLabels(&agent)...)
+		}
+		m.Header(rm.allocatedName, metrics.Gauge, "Allocated "+rm.help)
+		for _, agent := range snapshots {
+			m.Sample(rm.allocatedName, rm.value(agent.Allocated), agentLabels(&agent)...)
+		}
+	}
+	m.Header("gleam_master_agent_cpu_usage", metrics.Gauge, "Measured busy fraction of all cpus of the agent machine.")
+	for _, agent := range snapshots {
+		if agent.Load != nil {
+			m.Sample("gleam_master_agent_cpu_usage", agent.Load.GetCpuUsage(), agentLabels(&agent)...)
+		}
+	}
+
+	m.Header("gleam_master_queue_allocated_cpu_cores", metrics.Gauge, "Cpu cores allocated to the queue.")
+	for _, q := range queues {
+		m.Sample("gleam_master_queue_allocated_cpu_cores", cpuCores(q.Allocated), "queue", q.Name)
+	}
+	m.Header("gleam_master_queue_allocated_memory_megabytes", metrics.Gauge, "Memory allocated to the queue.")
+	for _, q := range queues {
+		m.Sample("gleam_master_queue_allocated_memory_megabytes", memoryMb(q.Allocated), "queue", q.Name)
+	}
+	m.Header("gleam_master_queue_share", metrics.Gauge, "Dominant share of the cluster used by the queue.")
+	for _, q := range queues {
+		m.Sample("gleam_master_queue_share", q.Share, "queue", q.Name)
+	}
+	m.Header("gleam_master_queue_fair_share", metrics.Gauge, "Fair share of the cluster for the queue.")
+	for _, q := range queues {
+		m.Sample("gleam_master_queue_fair_share", q.FairShare, "queue", q.Name)
+	}
+	m.Header("gleam_master_queue_pending_requests", metrics.Gauge, "Resource requests of the active flows in the queue not satisfied yet.")
+	for _, q := range queues {
+		m.Sample("gleam_master_queue_pending_requests", float64(q.Pending), "queue", q.Name)
+	}
+
+	m.Metric("gleam_master_flows", metrics.Gauge, "Number of flows holding leases.", float64(len(flows)))
+	m.Metric("gleam_master_leases", metrics.Gauge, "Number of active leases.", float64(leaseCount))
+}
+
+func agentLabels(agent *AgentInformation) []string {
+	return []string{
+		"data_center", agent.Location.DataCenter,
+		"rack", agent.Location.Rack,
+		"agent", agent.Location.URL(),
+	}
+}
+
+func cpuCores(r pb.ComputeResource) float64 { return float64(r.CpuCount) }
+func memoryMb(r pb.ComputeResource) float64 { return float64(r.MemoryMb) }
+func diskMb(r pb.ComputeResource) float64   { return float64(r.DiskMb) }
+
+type byAgentURL []*AgentInformation
+
+func (s byAgentURL) Len() int           { return len(s) }
+func (s byAgentURL) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byAgentURL) Less(i, j int) bool { return s[i].Location.URL() < s[j].Location.URL() }
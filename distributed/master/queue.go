@@ -0,0 +1,450 @@
+package master
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+const (
+	DefaultQueueName = "default"
+
+	// a queue is active if it has allocations or asked for resources recently
+	activeQueueWindow = time.Minute
+	// a queue is starving if its recent request was not fully satisfied
+	starvationWindow = 30 * time.Second
+	// wait for the agents to stop the preempted executors before preempting again
+	preemptionInterval = 30 * time.Second
+)
+
+// Queue is a named share of the cluster. The flows of all queues compete
+// for the cluster by the queues' weights.
+type Queue struct {
+	Name     string
+	Weight   float64
+	MinShare float64 // guaranteed fraction of the cluster, enforced by preemption if enabled
+	MaxShare float64 // the queue never uses more than this fraction of the cluster
+	Users    map[string]time.Time
+
+	allocated     pb.ComputeResource
+	pending       map[uint32]pendingRequests // by flow hash code
+	lastRequest   time.Time
+	lastStarved   time.Time
+	lastPreempted time.Time
+}
+
+// QueueManager enforces fair sharing among the queues.
+type QueueManager struct {
+	sync.Mutex
+	Queues     map[string]*Queue
+	Configured bool // only the configured queues accept flows
+	Preemption bool // preempt the allocations of queues over their fair share
+}
+
+// QueueStatus is the usage of a queue shown on the status page.
+type QueueStatus struct {
+	Name      string
+	Weight    float64
+	MinShare  float64
+	MaxShare  float64
+	FairShare float64
+	Share     float64
+	Allocated pb.ComputeResource
+	Pending   int // unsatisfied resource requests of the active flows
+	Users     []string
+}
+
+func NewQueueManager() *QueueManager {
+	return &QueueManager{
+		Queues: make(map[string]*Queue),
+	}
+}
+
+func newQueue(name string) *Queue {
+	return &Queue{
+		Name:     name,
+		Weight:   1,
+		MaxShare: 1,
+		Users:    make(map[string]time.Time),
+		pending:  make(map[uint32]pendingRequests),
+	}
+}
+
+// pendingRequests are the resource requests of a flow not satisfied
+// in its latest request.
+type pendingRequests struct {
+	count int
+	time  time.Time
+}
+
+// LoadQueueFile reads queues from a file with lines of
+//
+//	<queue name> weight=<weight> min=<min share> max=<max share>
+//
+// where the shares are fractions of the cluster, e.g.
+//
+//	etl weight=3 min=0.2
+//	adhoc weight=1 max=0.5
+//
+// Empty lines and lines starting with "#" are ignored. If the file name is
+// empty, queues are created on demand with weight 1. The "default" queue,
+// for flows without a queue, has weight 1 unless configured.
+func LoadQueueFile(fileName string) (*QueueManager, error) {
+	qm := NewQueueManager()
+	if fileName == "" {
+		return qm, nil
+	}
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		q := newQueue(fields[0])
+		for _, field := range fields[1:] {
+			parts := strings.SplitN(field, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("%s:%d: expecting key=value, but got %q", fileName, lineNumber, field)
+			}
+			value, err := strconv.ParseFloat(parts[1], 64)
+			if err != nil || value < 0 {
+				return nil, fmt.Errorf("%s:%d: invalid value %q", fileName, lineNumber, field)
+			}
+			switch parts[0] {
+			case "weight":
+				q.Weight = value
+			case "min":
+				q.MinShare = value
+			case "max":
+				q.MaxShare = value
+			default:
+				return nil, fmt.Errorf("%s:%d: unknown key %q", fileName, lineNumber, parts[0])
+			}
+		}
+		if q.MinShare > q.MaxShare {
+			return nil, fmt.Errorf("%s:%d: min share %v is larger than max share %v", fileName, lineNumber, q.MinShare, q.MaxShare)
+		}
+		qm.Queues[q.Name] = q
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	// flows without a queue go to the built-in default queue
+	if _, found := qm.Queues[DefaultQueueName]; !found {
+		qm.Queues[DefaultQueueName] = newQueue(DefaultQueueName)
+	}
+	qm.Configured = true
+
+	return qm, nil
+}
+
+// getQueue finds the queue for a request, and records the request time.
+func (qm *QueueManager) getQueue(name, user string) (*Queue, error) {
+	if name == "" {
+		name = DefaultQueueName
+	}
+	q, ok := qm.Queues[name]
+	if !ok {
+		if qm.Configured {
+			return nil, fmt.Errorf("Unknown queue %s", name)
+		}
+		q = newQueue(name)
+		qm.Queues[name] = q
+	}
+	q.lastRequest = time.Now()
+	if user != "" {
+		q.Users[user] = q.lastRequest
+	}
+	return q, nil
+}
+
+// refreshAllocated sums up the queue allocations reported by agents.
+func (qm *QueueManager) refreshAllocated(t *Topology) {
+	for _, q := range qm.Queues {
+		q.allocated = pb.ComputeResource{}
+	}
+	for _, dc := range t.GetDataCenters() {
+		for _, rack := range dc.GetRacks() {
+			for _, agent := range rack.GetAgents() {
+				for name, allocated := range agent.QueueAllocated {
+					q, ok := qm.Queues[name]
+					if !ok {
+						// allocated before the master restarted
+						q = newQueue(name)
+						qm.Queues[name] = q
+					}
+					q.allocated = q.allocated.Plus(allocated)
+				}
+			}
+		}
+	}
+}
+
+// dominantShare is the larger fraction of the cpu or memory of the cluster.
+func dominantShare(allocated, total pb.ComputeResource) (share float64) {
+	if total.CpuCount > 0 {
+		share = float64(allocated.CpuCount) / float64(total.CpuCount)
+	}
+	if total.MemoryMb > 0 {
+		if memoryShare := float64(allocated.MemoryMb) / float64(total.MemoryMb); memoryShare > share {
+			share = memoryShare
+		}
+	}
+	return
+}
+
+func (q *Queue) isActive(now time.Time) bool {
+	return !q.allocated.IsZero() || now.Sub(q.lastRequest) < activeQueueWindow
+}
+
+// fairShare divides the cluster among active queues by their weights,
+// bounded by the queue's min and max shares.
+func (qm *QueueManager) fairShare(q *Queue, now time.Time) float64 {
+	var totalWeight float64
+	for _, other := range qm.Queues {
+		if other == q || other.isActive(now) {
+			totalWeight += other.Weight
+		}
+	}
+	share := 1.0
+	if totalWeight > 0 {
+		share = q.Weight / totalWeight
+	}
+	if share < q.MinShare {
+		share = q.MinShare
+	}
+	if share > q.MaxShare {
+		share = q.MaxShare
+	}
+	return share
+}
+
+// othersStarving checks whether other queues under their fair share
+// failed to get resources recently.
+func (qm *QueueManager) othersStarving(q *Queue, total pb.ComputeResource, now time.Time) bool {
+	for _, other := range qm.Queues {
+		if other == q || now.Sub(other.lastStarved) > starvationWindow {
+			continue
+		}
+		if dominantShare(other.allocated, total) < qm.fairShare(other, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// admit returns the requests the queue can be allocated without going over
+// its max share, or over its fair share while other queues are starving.
+// A queue without any allocation can always get one executor, so that small
+// shares on small clusters still make progress.
+func (qm *QueueManager) admit(q *Queue, requests []*pb.ComputeResource, total pb.ComputeResource) (admitted []*pb.ComputeResource) {
+	now := time.Now()
+	limit := q.MaxShare
+	if qm.othersStarving(q, total, now) {
+		if fair := qm.fairShare(q, now); fair < limit {
+			limit = fair
+		}
+	}
+	budget := pb.ComputeResource{
+		CpuCount: int32(limit*float64(total.CpuCount)) - q.allocated.CpuCount,
+		MemoryMb: int64(limit*float64(total.MemoryMb)) - q.allocated.MemoryMb,
+	}
+
+	for _, request := range requests {
+		if !budget.Covers(*request) && !(q.allocated.IsZero() && len(admitted) == 0) {
+			break
+		}
+		admitted = append(admitted, request)
+		budget = budget.Minus(*request)
+	}
+	return
+}
+
+// recordAllocations updates the queue after allocating the admitted requests.
+func (qm *QueueManager) recordAllocations(q *Queue, requestCount int, allocations []*pb.Allocation) {
+	for _, allocation := range allocations {
+		q.allocated = q.allocated.Plus(*allocation.Allocated)
+	}
+	if len(allocations) < requestCount {
+		q.lastStarved = time.Now()
+	}
+}
+
+// recordPending remembers how many resource requests of a flow are not
+// satisfied, until the flow asks again.
+func (qm *QueueManager) recordPending(q *Queue, flowHashCode uint32, count int) {
+	if count <= 0 {
+		delete(q.pending, flowHashCode)
+		return
+	}
+	q.pending[flowHashCode] = pendingRequests{count: count, time: time.Now()}
+}
+
+// pendingCount sums up the pending requests of the flows asking recently.
+func (q *Queue) pendingCount(now time.Time) (count int) {
+	for flowHashCode, p := range q.pending {
+		if now.Sub(p.time) > activeQueueWindow {
+			delete(q.pending, flowHashCode)
+			continue
+		}
+		count += p.count
+	}
+	return
+}
+
+// preempt asks agents to stop executors of queues over their fair share,
+// to free up the resources the queue needs to reach its fair share.
+// The requests are delivered to the agents with the heartbeat responses.
+func (qm *QueueManager) preempt(t *Topology, q *Queue, shortfall pb.ComputeResource) {
+	now := time.Now()
+	total := t.Resource
+	if !qm.Preemption || now.Sub(q.lastPreempted) < preemptionInterval {
+		return
+	}
+	fair := qm.fairShare(q, now)
+	if dominantShare(q.allocated, total) >= fair {
+		return
+	}
+	room := pb.ComputeResource{
+		CpuCount: int32(fair*float64(total.CpuCount)) - q.allocated.CpuCount,
+		MemoryMb: int64(fair*float64(total.MemoryMb)) - q.allocated.MemoryMb,
+	}
+	need := minResource(shortfall, room)
+	if !need.GreaterThanZero() {
+		return
+	}
+
+	var victims byOverShare
+	for _, other := range qm.Queues {
+		if other == q {
+			continue
+		}
+		if overShare := dominantShare(other.allocated, total) - qm.fairShare(other, now); overShare > 0 {
+			victims.queues = append(victims.queues, other)
+			victims.overShares = append(victims.overShares, overShare)
+		}
+	}
+	sort.Sort(victims)
+
+	for _, v := range victims.queues {
+		if !need.GreaterThanZero() {
+			break
+		}
+		victimFair := qm.fairShare(v, now)
+		excess := v.allocated.Minus(pb.ComputeResource{
+			CpuCount: int32(victimFair * float64(total.CpuCount)),
+			MemoryMb: int64(victimFair * float64(total.MemoryMb)),
+		})
+		take := minResource(excess, need)
+		if !take.GreaterThanZero() {
+			continue
+		}
+		taken := t.preemptQueue(v.Name, take)
+		if taken.IsZero() {
+			continue
+		}
+		log.Printf("preempting %v from queue %s for queue %s", taken, v.Name, q.Name)
+		need = need.Minus(taken)
+		q.lastPreempted = now
+	}
+}
+
+func minResource(a, b pb.ComputeResource) pb.ComputeResource {
+	if b.CpuCount < a.CpuCount {
+		a.CpuCount = b.CpuCount
+	}
+	if b.MemoryMb < a.MemoryMb {
+		a.MemoryMb = b.MemoryMb
+	}
+	return a
+}
+
+// preemptQueue spreads the resource to preempt over the agents running
+// the queue's executors.
+func (t *Topology) preemptQueue(queue string, resource pb.ComputeResource) (taken pb.ComputeResource) {
+	for _, dc := range t.GetDataCenters() {
+		for _, rack := range dc.GetRacks() {
+			for _, agent := range rack.GetAgents() {
+				remaining := resource.Minus(taken)
+				if !remaining.GreaterThanZero() {
+					return
+				}
+				allocated, ok := agent.QueueAllocated[queue]
+				if !ok || !allocated.GreaterThanZero() {
+					continue
+				}
+				r := minResource(allocated, remaining)
+				agent.PendingPreemptions = append(agent.PendingPreemptions, &pb.PreemptRequest{
+					Queue:    queue,
+					Resource: &r,
+				})
+				taken = taken.Plus(r)
+			}
+		}
+	}
+	return
+}
+
+// Statuses lists the usage of all queues, sorted by name.
+func (qm *QueueManager) Statuses(t *Topology) (statuses []QueueStatus) {
+	qm.Lock()
+	defer qm.Unlock()
+
+	qm.refreshAllocated(t)
+	now := time.Now()
+	for _, q := range qm.Queues {
+		status := QueueStatus{
+			Name:      q.Name,
+			Weight:    q.Weight,
+			MinShare:  q.MinShare,
+			MaxShare:  q.MaxShare,
+			FairShare: qm.fairShare(q, now),
+			Share:     dominantShare(q.allocated, t.Resource),
+			Allocated: q.allocated,
+			Pending:   q.pendingCount(now),
+		}
+		for user, lastRequest := range q.Users {
+			if now.Sub(lastRequest) < activeQueueWindow || !q.allocated.IsZero() {
+				status.Users = append(status.Users, user)
+			}
+		}
+		sort.Strings(status.Users)
+		statuses = append(statuses, status)
+	}
+	sort.Sort(byQueueName(statuses))
+	return
+}
+
+type byOverShare struct {
+	queues     []*Queue
+	overShares []float64
+}
+
+func (s byOverShare) Len() int { return len(s.queues) }
+func (s byOverShare) Swap(i, j int) {
+	s.queues[i], s.queues[j] = s.queues[j], s.queues[i]
+	s.overShares[i], s.overShares[j] = s.overShares[j], s.overShares[i]
+}
+func (s byOverShare) Less(i, j int) bool { return s.overShares[i] > s.overShares[j] }
+
+type byQueueName []QueueStatus
+
+func (s byQueueName) Len() int           { return len(s) }
+func (s byQueueName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byQueueName) Less(i, j int) bool { return s[i].Name < s[j].Name }
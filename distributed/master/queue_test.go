@@ -0,0 +1,68 @@
+package master
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+func TestQueueFairShare(t *testing.T) {
+
+	total := pb.ComputeResource{CpuCount: 8, MemoryMb: 8 * 1024}
+	executor := &pb.ComputeResource{CpuCount: 1, MemoryMb: 512}
+	requests := []*pb.ComputeResource{executor, executor, executor, executor, executor, executor, executor, executor}
+
+	qm := NewQueueManager()
+	big, _ := qm.getQueue("big", "alice")
+	big.Weight = 3
+	small, _ := qm.getQueue("small", "bob")
+
+	// with nobody else starving, a queue can use the whole cluster
+	if admitted := qm.admit(big, requests, total); len(admitted) != 8 {
+		t.Errorf("expecting 8 admitted, got %d", len(admitted))
+	}
+
+	// once another queue is starving, a queue is limited to its fair share
+	small.lastStarved = time.Now()
+	if fair := qm.fairShare(big, time.Now()); fair != 0.75 {
+		t.Errorf("expecting fair share 0.75, got %v", fair)
+	}
+	if admitted := qm.admit(big, requests, total); len(admitted) != 6 {
+		t.Errorf("expecting 6 admitted, got %d", len(admitted))
+	}
+
+	// max share is always enforced, but a queue can start one executor
+	small.MaxShare = 0.1
+	if admitted := qm.admit(small, requests, total); len(admitted) != 1 {
+		t.Errorf("expecting 1 admitted, got %d", len(admitted))
+	}
+	small.allocated = *executor
+	if admitted := qm.admit(small, requests, total); len(admitted) != 0 {
+		t.Errorf("expecting 0 admitted, got %d", len(admitted))
+	}
+}
+
+func TestQueueFileWithoutDefaultQueue(t *testing.T) {
+
+	fileName := filepath.Join(t.TempDir(), "queues")
+	if err := ioutil.WriteFile(fileName, []byte("etl weight=3 min=0.2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	qm, err := LoadQueueFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if q, err := qm.getQueue("", "alice"); err != nil || q.Name != DefaultQueueName {
+		t.Errorf("expecting flows without a queue in the default queue, got %v, %v", q, err)
+	}
+	if q, err := qm.getQueue("etl", "bob"); err != nil || q.Weight != 3 {
+		t.Errorf("expecting the configured etl queue, got %v, %v", q, err)
+	}
+	if _, err := qm.getQueue("adhoc", "carol"); err == nil {
+		t.Errorf("expecting unknown queues rejected")
+	}
+}
@@ -27,14 +27,17 @@ func (t *Topology) allocateDataCenter(requests []*pb.ComputeResource) (string, e
 	return "", fmt.Errorf("All data centers are busy.")
 }
 
-func (t *Topology) allocateServersOnRack(dc *DataCenter, rack *Rack, requests []*pb.ComputeResource) (
+func (t *Topology) allocateServersOnRack(dc *DataCenter, rack *Rack, queue string, requests []*pb.ComputeResource) (
 	allocated []*pb.Allocation, remainingRequests []*pb.ComputeResource) {
 	var j = -1
 	for _, agent := range rack.GetAgents() {
 		if j >= len(requests) {
 			break
 		}
-		available := agent.Resource.Minus(agent.Allocated)
+		if !t.isAllocatable(agent) {
+			continue
+		}
+		available := availableOn(agent)
 		hasAllocation := true
 		for available.GreaterThanZero() && hasAllocation && j < len(requests) {
 			hasAllocation = false
@@ -46,15 +49,8 @@ func (t *Topology) allocateServersOnRack(dc *DataCenter, rack *Rack, requests []
 			request := requests[j]
 
 			// fmt.Printf("available %v, requested %v\n", available, request.ComputeResource)
-			if available.Covers(*request) {
-				allocated = append(allocated, &pb.Allocation{
-					Location:  &agent.Location,
-					Allocated: request,
-				})
-				agent.Allocated = agent.Allocated.Plus(*request)
-				rack.Allocated = rack.Allocated.Plus(*request)
-				dc.Allocated = dc.Allocated.Plus(*request)
-				t.Allocated = t.Allocated.Plus(*request)
+			if available.Accommodates(*request) {
+				allocated = append(allocated, t.allocateOnAgent(dc, rack, agent, queue, request))
 				available = available.Minus(*request)
 				hasAllocation = true
 			} else {
@@ -65,7 +61,23 @@ func (t *Topology) allocateServersOnRack(dc *DataCenter, rack *Rack, requests []
 	return
 }
 
-func (t *Topology) findServers(dc *DataCenter, requests []*pb.ComputeResource) (ret []*pb.Allocation) {
+func (t *Topology) allocateOnAgent(dc *DataCenter, rack *Rack, agent *AgentInformation, queue string, request *pb.ComputeResource) *pb.Allocation {
+	agent.Allocated = agent.Allocated.Plus(*request)
+	agent.QueueAllocated[queue] = agent.QueueAllocated[queue].Plus(*request)
+	rack.Allocated = rack.Allocated.Plus(*request)
+	dc.Allocated = dc.Allocated.Plus(*request)
+	t.Allocated = t.Allocated.Plus(*request)
+
+	// tell the driver the cpu level of the agent
+	allocated := *request
+	allocated.CpuLevel = agent.Resource.CpuLevel
+	return &pb.Allocation{
+		Location:  &agent.Location,
+		Allocated: &allocated,
+	}
+}
+
+func (t *Topology) findServers(dc *DataCenter, queue string, requests []*pb.ComputeResource) (ret []*pb.Allocation) {
 
 	// sort racks by unallocated resources
 	var racks []*Rack
@@ -77,7 +89,7 @@ func (t *Topology) findServers(dc *DataCenter, requests []*pb.ComputeResource) (
 	sort.Sort(byRequestedResources(requests))
 
 	for _, rack := range racks {
-		allocated, requests := t.allocateServersOnRack(dc, rack, requests)
+		allocated, requests := t.allocateServersOnRack(dc, rack, queue, requests)
 		ret = append(ret, allocated...)
 		if len(requests) == 0 {
 			break
@@ -28,12 +28,22 @@ func (l *Topology) UpdateAgentInformation(ai *pb.Heartbeat) {
 			oldInfo.Resource = *ai.Resource
 		}
 		oldInfo.LastHeartBeat = time.Now()
+		oldInfo.State = AgentAlive
+		oldInfo.Draining = ai.Draining
+		oldInfo.QueueAllocated = toQueueAllocated(ai.QueueAllocations)
+		oldInfo.RunningLeaseIds = toLeaseIdSet(ai.RunningLeaseIds)
+		oldInfo.Load = ai.Load
 	} else {
 		rack.AddAgent(&AgentInformation{
-			Location:      *ai.Location,
-			LastHeartBeat: time.Now(),
-			Resource:      *ai.Resource,
-			Allocated:     *ai.Allocated,
+			Location:        *ai.Location,
+			LastHeartBeat:   time.Now(),
+			State:           AgentAlive,
+			Draining:        ai.Draining,
+			Resource:        *ai.Resource,
+			Allocated:       *ai.Allocated,
+			QueueAllocated:  toQueueAllocated(ai.QueueAllocations),
+			RunningLeaseIds: toLeaseIdSet(ai.RunningLeaseIds),
+			Load:            ai.Load,
 		})
 	}
 
@@ -59,6 +69,26 @@ func (l *Topology) UpdateAgentInformation(ai *pb.Heartbeat) {
 
 }
 
+func toQueueAllocated(queueAllocations []*pb.QueueAllocation) map[string]pb.ComputeResource {
+	queueAllocated := make(map[string]pb.ComputeResource)
+	for _, qa := range queueAllocations {
+		queue := qa.Queue
+		if queue == "" {
+			queue = DefaultQueueName
+		}
+		queueAllocated[queue] = queueAllocated[queue].Plus(*qa.Allocated)
+	}
+	return queueAllocated
+}
+
+func toLeaseIdSet(leaseIds []uint64) map[uint64]bool {
+	set := make(map[uint64]bool, len(leaseIds))
+	for _, id := range leaseIds {
+		set[id] = true
+	}
+	return set
+}
+
 func (l *Topology) deleteAgentInformation(location *pb.Location) {
 
 	dc, hasDc := l.GetDataCenter(location.DataCenter)
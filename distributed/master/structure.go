@@ -10,8 +10,18 @@ import (
 type AgentInformation struct {
 	Location      pb.Location
 	LastHeartBeat time.Time
+	State         string // AgentAlive, or AgentSuspect if missing heartbeats
+	Draining      bool   // no new allocations, while running executors finish
 	Resource      pb.ComputeResource
 	Allocated     pb.ComputeResource
+	// allocated resource by queue
+	QueueAllocated map[string]pb.ComputeResource
+	// sent to the agent with the next heartbeat response
+	PendingPreemptions []*pb.PreemptRequest
+	// leases with running executors
+	RunningLeaseIds map[uint64]bool
+	// measured usage of the machine
+	Load *pb.AgentLoad
 }
 
 type Rack struct {
@@ -35,11 +45,16 @@ type Topology struct {
 	Allocated pb.ComputeResource
 	sync.RWMutex
 	DataCenters map[string]*DataCenter
+	leases      *leases
+	deadAgents  map[string]deadAgent
+	LoadLimits  LoadLimits
 }
 
 func NewTopology() *Topology {
 	return &Topology{
 		DataCenters: make(map[string]*DataCenter),
+		leases:      newLeases(),
+		deadAgents:  make(map[string]deadAgent),
 	}
 }
 
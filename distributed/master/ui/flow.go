@@ -0,0 +1,283 @@
+package ui
+
+import (
+	"html/template"
+)
+
+// FlowTpl renders the steps and datasets of a flow as a graph, with the
+// task groups and dataset shards colored by their states. The page polls
+// the JSON API of the master until the flow stops.
+var FlowTpl = template.Must(template.New("flow").Parse(`<!DOCTYPE html>
+<html>
+  <head>
+    <title>Gleam flow {{ .FlowHashCode }}</title>
+    <link rel="stylesheet" href="https://maxcdn.bootstrapcdn.com/bootstrap/3.3.1/css/bootstrap.min.css">
+    <style>
+      svg text { font-family: monospace; font-size: 12px; }
+      .state-pending, .state-untouched { fill: #eeeeee; background-color: #eeeeee; }
+      .state-waiting, .state-location-assigned { fill: #d9edf7; background-color: #d9edf7; }
+      .state-running, .state-in-progress { fill: #fcf8e3; background-color: #fcf8e3; }
+      .state-in-retry { fill: #faebcc; background-color: #faebcc; }
+      .state-completed, .state-successful { fill: #dff0d8; background-color: #dff0d8; }
+      .state-failed { fill: #f2dede; background-color: #f2dede; }
+      .edge { stroke: #999999; fill: none; }
+    </style>
+  </head>
+  <body>
+    <div class="container">
+      <div class="page-header">
+        <h1>
+          <a href="/">Gleam</a> <small>flow {{ .FlowHashCode }} <span id="flow"></span></small>
+        </h1>
+      </div>
+
+      <div class="row">
+        <p id="error" class="text-danger"></p>
+        <div style="overflow-x: auto"><svg id="dag" width="0" height="0"></svg></div>
+      </div>
+
+      <div class="row">
+        <h2>Steps</h2>
+        <table class="table table-striped table-condensed">
+          <thead>
+            <tr>
+              <th>Step</th><th>Network</th><th>Tasks</th>
+              <th>Rows In</th><th>Rows Out</th><th>Bytes In</th><th>Bytes Out</th>
+              <th>Time</th><th>CPU</th><th>Spill</th>
+            </tr>
+          </thead>
+          <tbody id="steps"></tbody>
+        </table>
+      </div>
+
+      <div class="row">
+        <h2>Task Groups</h2>
+        <table class="table table-striped table-condensed">
+          <thead>
+            <tr>
+              <th>Id</th><th>Steps</th><th>State</th><th>Agent</th>
+              <th>Rows In</th><th>Rows Out</th><th>Bytes In</th><th>Bytes Out</th>
+              <th>Waited</th><th>Took</th><th>Error</th>
+            </tr>
+          </thead>
+          <tbody id="taskgroups"></tbody>
+        </table>
+      </div>
+    </div>
+
+    <script>
+    (function() {
+      var flowId = "{{ .FlowHashCode }}";
+      var token = new URLSearchParams(window.location.search).get("token");
+      var svgNS = "http://www.w3.org/2000/svg";
+
+      function get(path) {
+        var headers = token ? {"Authorization": "Bearer " + token} : {};
+        return fetch("/api/v1/flows/" + flowId + path, {headers: headers}).then(function(response) {
+          return response.json().then(function(body) {
+            if (!response.ok) {
+              throw new Error(body.error);
+            }
+            return body;
+          });
+        });
+      }
+
+      function stateClass(state) {
+        return "state-" + (state || "pending").replace(/ /g, "-");
+      }
+
+      function svg(parent, name, attrs, text) {
+        var e = document.createElementNS(svgNS, name);
+        for (var key in attrs) {
+          e.setAttribute(key, attrs[key]);
+        }
+        if (text !== undefined) {
+          e.textContent = text;
+        }
+        parent.appendChild(e);
+        return e;
+      }
+
+      function cell(row, text) {
+        var td = document.createElement("td");
+        td.textContent = text;
+        row.appendChild(td);
+        return td;
+      }
+
+      function duration(nanoseconds) {
+        return ((nanoseconds || 0) / 1e9).toFixed(1) + "s";
+      }
+
+      function seconds(from, to) {
+        if (!from) {
+          return "";
+        }
+        return ((to || Math.floor(Date.now() / 1000)) - from) + "s";
+      }
+
+      function agentOf(location) {
+        return location ? location.server + ":" + location.port : "driver";
+      }
+
+      // stepStates combines the states of the task groups running the step
+      function stepStates(progress) {
+        var states = {};
+        (progress.taskGroups || []).forEach(function(tg) {
+          (tg.stepIds || [0]).forEach(function(stepId) {
+            var old = states[stepId], state = tg.state || "pending";
+            if (!old || state == "failed" || (state == "running" && old != "failed") ||
+                (old == "completed" && state != "completed")) {
+              states[stepId] = state;
+            }
+          });
+        });
+        return states;
+      }
+
+      // levels places the steps and datasets into columns, from the sources to the outputs
+      function levels(progress) {
+        var inputs = {}, datasets = {}, memo = {};
+        (progress.datasets || []).forEach(function(d) {
+          datasets[d.id || 0] = d;
+          (d.readingStepIds || []).forEach(function(stepId) {
+            (inputs[stepId] = inputs[stepId] || []).push(d.id || 0);
+          });
+        });
+        function stepLevel(stepId) {
+          if (memo["s" + stepId] === undefined) {
+            memo["s" + stepId] = 0;
+            (inputs[stepId] || []).forEach(function(datasetId) {
+              memo["s" + stepId] = Math.max(memo["s" + stepId], datasetLevel(datasetId) + 1);
+            });
+          }
+          return memo["s" + stepId];
+        }
+        function datasetLevel(datasetId) {
+          if (memo["d" + datasetId] === undefined) {
+            memo["d" + datasetId] = stepLevel(datasets[datasetId].stepId || 0) + 1;
+          }
+          return memo["d" + datasetId];
+        }
+        (progress.steps || []).forEach(function(step) { stepLevel(step.id || 0); });
+        (progress.datasets || []).forEach(function(d) { datasetLevel(d.id || 0); });
+        return memo;
+      }
+
+      function drawDag(progress) {
+        var dag = document.getElementById("dag");
+        while (dag.firstChild) {
+          dag.removeChild(dag.firstChild);
+        }
+        var level = levels(progress), states = stepStates(progress);
+        var columnWidth = 190, rowHeight = 70, nodeWidth = 150, nodeHeight = 44;
+        var rows = {}, positions = {}, width = 0, height = 0;
+        function place(key) {
+          var column = level[key];
+          var row = rows[column] = (rows[column] || 0) + 1;
+          positions[key] = {x: 10 + column * columnWidth, y: 10 + (row - 1) * rowHeight};
+          width = Math.max(width, positions[key].x + nodeWidth + 10);
+          height = Math.max(height, positions[key].y + nodeHeight + 10);
+        }
+        (progress.steps || []).forEach(function(step) { place("s" + (step.id || 0)); });
+        (progress.datasets || []).forEach(function(d) { place("d" + (d.id || 0)); });
+        dag.setAttribute("width", width);
+        dag.setAttribute("height", height);
+
+        function edge(from, to) {
+          var a = positions[from], b = positions[to];
+          if (!a || !b) {
+            return;
+          }
+          var x1 = a.x + nodeWidth, y1 = a.y + nodeHeight / 2, x2 = b.x, y2 = b.y + nodeHeight / 2;
+          svg(dag, "path", {"class": "edge",
+            d: "M" + x1 + "," + y1 + " C" + (x1 + 20) + "," + y1 + " " + (x2 - 20) + "," + y2 + " " + x2 + "," + y2});
+        }
+        (progress.datasets || []).forEach(function(d) {
+          edge("s" + (d.stepId || 0), "d" + (d.id || 0));
+          (d.readingStepIds || []).forEach(function(stepId) { edge("d" + (d.id || 0), "s" + stepId); });
+        });
+
+        (progress.steps || []).forEach(function(step) {
+          var p = positions["s" + (step.id || 0)], stats = step.stats || {};
+          var g = svg(dag, "g", {});
+          svg(g, "title", {}, step.name + (step.id || 0) + " " + (step.networkType || "") +
+            "\nrows in " + (stats.inputRows || 0) + " out " + (stats.outputRows || 0) +
+            "\ntime " + duration(stats.wallTime) + " cpu " + duration(stats.cpuTime));
+          svg(g, "rect", {x: p.x, y: p.y, width: nodeWidth, height: nodeHeight, rx: 4,
+            stroke: "#666666", "class": stateClass(states[step.id || 0])});
+          svg(g, "text", {x: p.x + 6, y: p.y + 17}, step.name + (step.id || 0) + (step.isOnDriverSide ? " (driver)" : ""));
+          svg(g, "text", {x: p.x + 6, y: p.y + 35}, (stats.outputRows || 0) + " rows " + duration(stats.wallTime));
+        });
+
+        (progress.datasets || []).forEach(function(d) {
+          var p = positions["d" + (d.id || 0)], shards = d.shards || [];
+          var g = svg(dag, "g", {});
+          svg(g, "rect", {x: p.x, y: p.y, width: nodeWidth, height: nodeHeight, rx: 14,
+            stroke: "#666666", fill: "#ffffff", "stroke-dasharray": d.onDisk ? "4,2" : ""});
+          svg(g, "text", {x: p.x + 6, y: p.y + 17}, "d" + (d.id || 0) + " " + shards.length + " shards" + (d.onDisk ? " on disk" : ""));
+          var size = Math.min(12, (nodeWidth - 12) / Math.max(1, shards.length));
+          shards.forEach(function(shard, i) {
+            var box = svg(g, "rect", {x: p.x + 6 + i * size, y: p.y + 25, width: Math.max(1, size - 1), height: 12,
+              "class": stateClass(shard.status)});
+            svg(box, "title", {}, "shard " + (shard.id || 0) + ": " + (shard.status || "untouched") +
+              " on " + agentOf(shard.location) + ", " + (shard.rows || 0) + " rows " + (shard.bytes || 0) + " bytes");
+          });
+        });
+      }
+
+      function fillSteps(progress) {
+        var tbody = document.getElementById("steps");
+        tbody.innerHTML = "";
+        (progress.steps || []).forEach(function(step) {
+          var row = document.createElement("tr"), stats = step.stats || {};
+          [step.name + (step.id || 0), step.networkType || "", step.taskCount || 0,
+           stats.inputRows || 0, stats.outputRows || 0, stats.inputBytes || 0, stats.outputBytes || 0,
+           duration(stats.wallTime), duration(stats.cpuTime), stats.spillBytes || 0].forEach(function(text) {
+            cell(row, text);
+          });
+          tbody.appendChild(row);
+        });
+      }
+
+      function fillTaskGroups(progress) {
+        var tbody = document.getElementById("taskgroups");
+        tbody.innerHTML = "";
+        (progress.taskGroups || []).forEach(function(tg) {
+          var row = document.createElement("tr");
+          cell(row, tg.id || 0);
+          cell(row, (tg.steps || []).join(" "));
+          cell(row, tg.state || "pending").className = stateClass(tg.state);
+          cell(row, tg.startTime ? agentOf(tg.location) : "");
+          [tg.inputRows || 0, tg.outputRows || 0, tg.inputBytes || 0, tg.outputBytes || 0,
+           seconds(tg.waitTime, tg.startTime), seconds(tg.startTime, tg.stopTime >= tg.startTime ? tg.stopTime : 0),
+           tg.error || ""].forEach(function(text) {
+            cell(row, text);
+          });
+          tbody.appendChild(row);
+        });
+      }
+
+      function refresh() {
+        Promise.all([get(""), get("/progress")]).then(function(results) {
+          var flow = results[0], progress = results[1];
+          document.getElementById("error").textContent = flow.error || "";
+          document.getElementById("flow").textContent = (flow.name || "") + " " + (flow.state || "");
+          drawDag(progress);
+          fillSteps(progress);
+          fillTaskGroups(progress);
+          if (flow.state == "running") {
+            setTimeout(refresh, 2000);
+          }
+        }).catch(function(err) {
+          document.getElementById("error").textContent = err.message;
+          setTimeout(refresh, 5000);
+        });
+      }
+      refresh();
+    })();
+    </script>
+  </body>
+</html>
+`))
@@ -46,6 +46,72 @@ var StatusTpl = template.Must(template.New("status").Parse(`<!DOCTYPE html>
         </div>
       </div>
 
+      <div class="row">
+        <h2>Queues</h2>
+        <table class="table table-striped">
+          <thead>
+            <tr>
+              <th>Queue</th>
+              <th>Weight</th>
+              <th>Min Share</th>
+              <th>Max Share</th>
+              <th>Fair Share</th>
+              <th>Share</th>
+              <th>Allocated</th>
+              <th>Users</th>
+            </tr>
+          </thead>
+          <tbody>
+          {{ range $queue := .Queues }}
+            <tr>
+              <td><code>{{ $queue.Name }}</code></td>
+              <td>{{ $queue.Weight }}</td>
+              <td>{{ printf "%.2f" $queue.MinShare }}</td>
+              <td>{{ printf "%.2f" $queue.MaxShare }}</td>
+              <td>{{ printf "%.2f" $queue.FairShare }}</td>
+              <td>{{ printf "%.2f" $queue.Share }}</td>
+              <td>{{ $queue.Allocated }}</td>
+              <td>{{ range $queue.Users }}{{ . }} {{ end }}</td>
+            </tr>
+          {{ end }}
+          </tbody>
+        </table>
+      </div>
+
+      <div class="row">
+        <h2>Flows</h2>
+        <table class="table table-striped">
+          <thead>
+            <tr>
+              <th>Flow</th>
+              <th>Name</th>
+              <th>User</th>
+              <th>Queue</th>
+              <th>Driver</th>
+              <th>State</th>
+              <th>Task Groups</th>
+              <th>Leases</th>
+              <th>Error</th>
+            </tr>
+          </thead>
+          <tbody>
+          {{ range $flow := .Flows }}
+            <tr>
+              <td><a href="/flows/{{ $flow.FlowHashCode }}"><code>{{ $flow.FlowHashCode }}</code></a></td>
+              <td>{{ $flow.Name }}</td>
+              <td>{{ $flow.User }}</td>
+              <td>{{ $flow.Queue }}</td>
+              <td>{{ $flow.Driver }}</td>
+              <td>{{ $flow.State }}</td>
+              <td>{{ if $flow.TaskGroups }}{{ $flow.CompletedTaskGroups }}/{{ $flow.TaskGroups }}{{ end }}</td>
+              <td>{{ len $flow.Allocations }}</td>
+              <td>{{ $flow.Error }}</td>
+            </tr>
+          {{ end }}
+          </tbody>
+        </table>
+      </div>
+
       <div class="row">
         <h2>Topology</h2>
         <table class="table table-striped">
@@ -56,6 +122,11 @@ var StatusTpl = template.Must(template.New("status").Parse(`<!DOCTYPE html>
               <th>Server</th>
               <th>Port</th>
               <th>Last Heartbeat</th>
+              <th>State</th>
+              <th>CPU Usage</th>
+              <th>Free Memory</th>
+              <th>Executors</th>
+              <th>Shard Bytes</th>
               <th>Resource</th>
               <th>Allocated</th>
             </tr>
@@ -70,6 +141,15 @@ var StatusTpl = template.Must(template.New("status").Parse(`<!DOCTYPE html>
               <td>{{ $agent.Location.Server }}</td>
               <td>{{ $agent.Location.Port }}</td>
               <td>{{ $agent.LastHeartBeat }}</td>
+              <td>{{ $agent.State }}{{ if $agent.Draining }}, draining{{ end }}</td>
+              {{ with $agent.Load }}
+              <td>{{ printf "%.2f" .CpuUsage }}</td>
+              <td>{{ .FreeMemoryMb }} MB</td>
+              <td>{{ .RunningExecutors }}</td>
+              <td>{{ .ShardBytes }}</td>
+              {{ else }}
+              <td></td><td></td><td></td><td></td>
+              {{ end }}
               <td>{{ $agent.Resource }}</td>
               <td>{{ $agent.Allocated }}</td>
             </tr>
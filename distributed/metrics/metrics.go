@@ -0,0 +1,77 @@
+// Package metrics writes metrics in the Prometheus text format,
+// to be scraped from the /metrics http endpoints of master and agents.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	ContentType = "text/plain; version=0.0.4"
+
+	Gauge   = "gauge"
+	Counter = "counter"
+)
+
+// Writer writes metric families, each with a header and its samples.
+type Writer struct {
+	w *bufio.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// Header starts a metric family.
+func (m *Writer) Header(name, metricType, help string) {
+	fmt.Fprintf(m.w, "# HELP %s %s\n", name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help))
+	fmt.Fprintf(m.w, "# TYPE %s %s\n", name, metricType)
+}
+
+// Sample writes one value of the metric family. The labels are given as
+// name and value pairs.
+func (m *Writer) Sample(name string, value float64, labels ...string) {
+	m.w.WriteString(name)
+	if len(labels) > 1 {
+		m.w.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				m.w.WriteByte(',')
+			}
+			fmt.Fprintf(m.w, "%s=\"%s\"", labels[i], escapeLabelValue(labels[i+1]))
+		}
+		m.w.WriteByte('}')
+	}
+	m.w.WriteByte(' ')
+	m.w.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	m.w.WriteByte('\n')
+}
+
+// Metric writes a metric family with a single sample.
+func (m *Writer) Metric(name, metricType, help string, value float64) {
+	m.Header(name, metricType, help)
+	m.Sample(name, value)
+}
+
+func (m *Writer) Flush() error {
+	return m.w.Flush()
+}
+
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// Handler serves the metrics written by the function.
+func Handler(write func(m *Writer)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		m := NewWriter(w)
+		write(m)
+		m.Flush()
+	}
+}
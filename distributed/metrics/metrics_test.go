@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewWriter(&buf)
+	m.Header("gleam_agents", Gauge, "Number of agents.")
+	m.Sample("gleam_agents", 3, "state", "alive", "rack", `r"1`)
+	m.Metric("gleam_leases", Counter, "Leases.", 0.5)
+	m.Flush()
+
+	expected := `# HELP gleam_agents Number of agents.
+# TYPE gleam_agents gauge
+gleam_agents{state="alive",rack="r\"1"} 3
+# HELP gleam_leases Leases.
+# TYPE gleam_leases counter
+gleam_leases 0.5
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
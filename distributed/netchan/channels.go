@@ -10,14 +10,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/chrislusf/gleam/distributed/security"
 	"github.com/chrislusf/gleam/pb"
 	"github.com/chrislusf/gleam/util"
 	"github.com/golang/protobuf/proto"
 )
 
-func DialReadChannel(wg *sync.WaitGroup, readerName string, address string, channelName string, onDisk bool, outChan io.WriteCloser) error {
+func DialReadChannel(wg *sync.WaitGroup, readerName string, address string, channelName string, onDisk bool, compression string, outChan io.WriteCloser) error {
 
-	conn, err := net.Dial("tcp", address)
+	conn, err := security.Dial(address)
 	if err != nil {
 		wg.Done()
 		return fmt.Errorf("Fail to dial read %s: %v", address, err)
@@ -30,9 +31,11 @@ func DialReadChannel(wg *sync.WaitGroup, readerName string, address string, chan
 
 	data, err := proto.Marshal(&pb.ControlMessage{
 		IsOnDiskIO: onDisk,
+		Token:      security.ClientToken(),
 		ReadRequest: &pb.ReadRequest{
 			ChannelName: channelName,
 			ReaderName:  readerName,
+			Compression: compression,
 		},
 	})
 
@@ -46,12 +49,17 @@ func DialReadChannel(wg *sync.WaitGroup, readerName string, address string, chan
 		return fmt.Errorf("Fail to write ReadRequest: %v", err)
 	}
 
-	return util.ReaderToChannel(wg, channelName, conn, outChan, true, os.Stderr)
+	var reader io.ReadCloser = conn
+	if compression != util.CompressionNone {
+		reader = util.NewDecompressingReader(compression, conn)
+	}
+
+	return util.ReaderToChannel(wg, channelName, reader, outChan, true, os.Stderr)
 }
 
-func DialWriteChannel(wg *sync.WaitGroup, writerName string, address string, channelName string, onDisk bool, inChan io.Reader, readerCount int) error {
+func DialWriteChannel(wg *sync.WaitGroup, writerName string, address string, channelName string, onDisk bool, compression string, inChan io.Reader, readerCount int) error {
 
-	conn, err := net.Dial("tcp", address)
+	conn, err := security.Dial(address)
 	if err != nil {
 		wg.Done()
 		return fmt.Errorf("Fail to dial write %s: %v", address, err)
@@ -64,10 +72,12 @@ func DialWriteChannel(wg *sync.WaitGroup, writerName string, address string, cha
 
 	data, err := proto.Marshal(&pb.ControlMessage{
 		IsOnDiskIO: onDisk,
+		Token:      security.ClientToken(),
 		WriteRequest: &pb.WriteRequest{
 			ChannelName: channelName,
 			ReaderCount: int32(readerCount),
 			WriterName:  writerName,
+			Compression: compression,
 		},
 	})
 
@@ -81,6 +91,10 @@ func DialWriteChannel(wg *sync.WaitGroup, writerName string, address string, cha
 		return fmt.Errorf("Fail to write WriteRequest: %v", err)
 	}
 
+	if compression != util.CompressionNone {
+		inChan = util.NewCompressingReader(compression, inChan)
+	}
+
 	return util.ChannelToWriter(wg, channelName, inChan, conn, os.Stderr)
 
 }
@@ -2,6 +2,7 @@ package distributed
 
 import (
 	"github.com/chrislusf/gleam/distributed/driver"
+	"github.com/chrislusf/gleam/distributed/security"
 	"github.com/chrislusf/gleam/flow"
 )
 
@@ -14,6 +15,16 @@ type DistributedOption struct {
 	Module       string
 	Host         string
 	Port         int
+	Compression  string
+	TLS          *security.TLSOption
+	Token        string
+	Env          map[string]string
+	EnvAllowList []string
+	Config       map[string]string
+	Queue        string
+	User         string
+	ProfileDir   string
+	ProfileSteps []string
 }
 
 func Option() *DistributedOption {
@@ -37,6 +48,16 @@ func (o *DistributedOption) GetFlowRunner() flow.FlowRunner {
 		Module:       o.Module,
 		Host:         o.Host,
 		Port:         o.Port,
+		Compression:  o.Compression,
+		TLS:          o.TLS,
+		Token:        o.Token,
+		Env:          o.Env,
+		EnvAllowList: o.EnvAllowList,
+		Config:       o.Config,
+		Queue:        o.Queue,
+		User:         o.User,
+		ProfileDir:   o.ProfileDir,
+		ProfileSteps: o.ProfileSteps,
 	})
 }
 
@@ -49,3 +70,82 @@ func (o *DistributedOption) SetMaster(master string) *DistributedOption {
 	o.Master = master
 	return o
 }
+
+// SetQueue submits the flow to a named queue on the master. Queues share
+// the cluster by their weights. The user is shown on the master's status
+// page, and defaults to the USER environment variable.
+func (o *DistributedOption) SetQueue(queue, user string) *DistributedOption {
+	o.Queue = queue
+	o.User = user
+	return o
+}
+
+// SetCompression sets the codec, "snappy", "lz4" or "zstd", to compress
+// the data shuffled between tasks. Empty string means no compression.
+func (o *DistributedOption) SetCompression(compression string) *DistributedOption {
+	o.Compression = compression
+	return o
+}
+
+// SetTLS secures the connections to master and agents, and the file server
+// that agents download the executables from.
+// The caFile is used to verify the peers. If requireClientCert is true,
+// agents need to present certificates signed by the CA to download files.
+func (o *DistributedOption) SetTLS(caFile, certFile, keyFile string, requireClientCert bool) *DistributedOption {
+	o.TLS = &security.TLSOption{
+		CAFile:            caFile,
+		CertFile:          certFile,
+		KeyFile:           keyFile,
+		RequireClientCert: requireClientCert,
+	}
+	return o
+}
+
+// SetToken sets the token to access master and agents.
+// The token needs "submit" and "read" permissions, and "delete" to clean up
+// the datasets after the flow. TLS needs to be set too, see SetTLS.
+func (o *DistributedOption) SetToken(token string) *DistributedOption {
+	o.Token = token
+	return o
+}
+
+// SetEnv sets an environment variable for the executors and their scripts.
+func (o *DistributedOption) SetEnv(name, value string) *DistributedOption {
+	if o.Env == nil {
+		o.Env = make(map[string]string)
+	}
+	o.Env[name] = value
+	return o
+}
+
+// AllowEnv passes the named environment variables of the driver to the
+// executors and their scripts. A name ending with "*" matches by prefix,
+// e.g., "AWS_*". Other environment variables of the driver are not sent.
+func (o *DistributedOption) AllowEnv(names ...string) *DistributedOption {
+	o.EnvAllowList = append(o.EnvAllowList, names...)
+	return o
+}
+
+// SetProfiling profiles the executors running the named steps, or all
+// steps if none is named. A step is named by its name, e.g., "Map", or by
+// its name and id, e.g., "Map3". The CPU and heap profiles of each task
+// group are saved in the directory on the driver, named by the steps and
+// shards of the task group, e.g., "Map3.0-LocalSort4.0.cpu.pprof". The
+// profiles of a step can be merged with
+//
+//	go tool pprof -proto dir/*Map3.*.cpu.pprof > Map3.cpu.pprof
+func (o *DistributedOption) SetProfiling(dir string, steps ...string) *DistributedOption {
+	o.ProfileDir = dir
+	o.ProfileSteps = steps
+	return o
+}
+
+// SetConfig sets a key/value config for this flow. Scripts can read it
+// from the environment variable "GLEAM_CONFIG_" + key.
+func (o *DistributedOption) SetConfig(key, value string) *DistributedOption {
+	if o.Config == nil {
+		o.Config = make(map[string]string)
+	}
+	o.Config[key] = value
+	return o
+}
@@ -4,8 +4,10 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
+	"github.com/chrislusf/gleam/distributed/driver"
 	"github.com/chrislusf/gleam/flow"
 )
 
@@ -38,3 +40,15 @@ func TestInstructionSet(t *testing.T) {
 	// f.Run()
 
 }
+
+func TestSetEnvOverridesAllowedEnv(t *testing.T) {
+	t.Setenv("GLEAM_TEST_REGION", "us-east")
+	t.Setenv("GLEAM_TEST_ZONE", "a")
+
+	o := Option().AllowEnv("GLEAM_TEST_*").SetEnv("GLEAM_TEST_REGION", "eu-west")
+	envs := (&driver.Option{Env: o.Env, EnvAllowList: o.EnvAllowList}).ExecutorEnvs()
+	expected := []string{"GLEAM_TEST_REGION=eu-west", "GLEAM_TEST_ZONE=a"}
+	if !reflect.DeepEqual(envs, expected) {
+		t.Errorf("expecting %v, got %v", expected, envs)
+	}
+}
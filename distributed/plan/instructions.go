@@ -14,6 +14,9 @@ func TranslateToInstructionSet(taskGroups *TaskGroup) (ret *pb.InstructionSet) {
 	for _, task := range taskGroups.Tasks {
 		instruction := translateToInstruction(task)
 		if instruction != nil {
+			// to match the instruction stats back to the task
+			instruction.StepId = int32(task.Step.Id)
+			instruction.TaskId = int32(task.Id)
 			ret.Instructions = append(ret.Instructions, instruction)
 		}
 	}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/chrislusf/gleam/flow"
@@ -17,18 +18,29 @@ type TaskGroup struct {
 	Parents         []*TaskGroup
 	ParentStepGroup *StepGroup
 	RequestId       uint32 // id for actual request when running
+	sync.Mutex             // guards the times and the error below, read while running
 	WaitAt          time.Time
 	StartAt         time.Time
 	StopAt          time.Time
 	Error           error
 }
 
+// TaskGroupStatus is a snapshot of how the task group runs.
+type TaskGroupStatus struct {
+	State   string
+	WaitAt  time.Time
+	StartAt time.Time
+	StopAt  time.Time
+	Error   error
+}
+
 type StepGroup struct {
 	Steps      []*flow.Step
 	Parents    []*StepGroup
 	TaskGroups []*TaskGroup
 	sync.Mutex
 	waitForAllTasks *sync.Cond
+	peakMemoryMb    int64 // measured on the agents, accessed atomically
 }
 
 func GroupTasks(fc *flow.FlowContext) ([]*StepGroup, []*TaskGroup) {
@@ -52,6 +64,21 @@ func (t *StepGroup) AddParent(parent *StepGroup) *StepGroup {
 	return t
 }
 
+// RecordPeakMemory keeps the most memory used by any task group of the
+// step group, so the task groups allocated later ask for as much memory.
+func (t *StepGroup) RecordPeakMemory(memoryMb int64) {
+	for {
+		peak := atomic.LoadInt64(&t.peakMemoryMb)
+		if memoryMb <= peak || atomic.CompareAndSwapInt64(&t.peakMemoryMb, peak, memoryMb) {
+			return
+		}
+	}
+}
+
+func (t *StepGroup) PeakMemoryMb() int64 {
+	return atomic.LoadInt64(&t.peakMemoryMb)
+}
+
 func NewTaskGroup() *TaskGroup {
 	return &TaskGroup{}
 }
@@ -76,10 +103,7 @@ func (t *TaskGroup) String() string {
 
 func (t *TaskGroup) RequiredResources() *pb.ComputeResource {
 
-	resource := &pb.ComputeResource{
-		CpuCount: 1,
-		CpuLevel: 1,
-	}
+	resource := t.RequiredCompute()
 
 	for _, task := range t.Tasks {
 		inst := task.Step.Instruction
@@ -90,21 +114,109 @@ func (t *TaskGroup) RequiredResources() *pb.ComputeResource {
 		}
 	}
 
+	// the estimation is replaced by the memory measured on earlier runs
+	if t.ParentStepGroup != nil {
+		if peak := t.ParentStepGroup.PeakMemoryMb(); peak > resource.MemoryMb {
+			log.Printf("  %s : measured %d MB, estimated %d MB\n", t.String(), peak, resource.MemoryMb)
+			resource.MemoryMb = peak
+		}
+	}
+
+	return &resource
+}
+
+// RequiredCompute returns the cpu cores, cpu level and disk space hinted
+// by the steps of the task group. An on disk output dataset also needs
+// the disk space for its partition.
+func (t *TaskGroup) RequiredCompute() pb.ComputeResource {
+	resource := pb.ComputeResource{
+		CpuCount: 1,
+		CpuLevel: 1,
+	}
+	for _, task := range t.Tasks {
+		meta := task.Step.Meta
+		if int32(meta.CPUCount) > resource.CpuCount {
+			resource.CpuCount = int32(meta.CPUCount)
+		}
+		if int32(meta.CPULevel) > resource.CpuLevel {
+			resource.CpuLevel = int32(meta.CPULevel)
+		}
+		resource.DiskMb += meta.DiskMB
+	}
+	lastTask := t.Tasks[len(t.Tasks)-1]
+	if output := lastTask.Step.OutputDataset; output != nil && output.GetIsOnDiskIO() {
+		resource.DiskMb += output.GetPartitionSize()
+	}
 	return resource
 }
 
+// MarkWaiting records the task group waits for an executor.
+func (t *TaskGroup) MarkWaiting() {
+	t.Lock()
+	defer t.Unlock()
+	t.WaitAt = time.Now()
+}
+
+// MarkStart records the task group starts running, or running again.
+func (t *TaskGroup) MarkStart() {
+	t.Lock()
+	defer t.Unlock()
+	t.StartAt = time.Now()
+}
+
 func (t *TaskGroup) MarkStop(err error) {
+	t.Lock()
 	t.StopAt = time.Now()
 	t.Error = err
+	t.Unlock()
+
+	t.ParentStepGroup.Lock()
+	defer t.ParentStepGroup.Unlock()
 	t.ParentStepGroup.waitForAllTasks.Broadcast()
 }
 
+// Status returns a snapshot of the task group's state, times and error.
+func (t *TaskGroup) Status() TaskGroupStatus {
+	t.Lock()
+	defer t.Unlock()
+	return TaskGroupStatus{
+		State:   t.state(),
+		WaitAt:  t.WaitAt,
+		StartAt: t.StartAt,
+		StopAt:  t.StopAt,
+		Error:   t.Error,
+	}
+}
+
+// State tells the progress of the task group, by whichever of waiting,
+// starting and stopping happened last, since a task group can be rerun.
+func (t *TaskGroup) State() string {
+	t.Lock()
+	defer t.Unlock()
+	return t.state()
+}
+
+func (t *TaskGroup) state() string {
+	switch {
+	case !t.StopAt.IsZero() && !t.StopAt.Before(t.StartAt) && !t.StopAt.Before(t.WaitAt):
+		if t.Error != nil {
+			return pb.StateFailed
+		}
+		return pb.StateCompleted
+	case !t.StartAt.IsZero() && !t.StartAt.Before(t.WaitAt):
+		return pb.StateRunning
+	case !t.WaitAt.IsZero():
+		return pb.StateWaiting
+	}
+	return pb.StatePending
+}
+
 func (s *StepGroup) WaitForAllTasksToComplete() {
 	s.Lock()
 	defer s.Unlock()
 
 	for _, taskGroup := range s.TaskGroups {
-		for taskGroup.StopAt.IsZero() || taskGroup.Error != nil {
+		for taskGroup.State() != pb.StateCompleted {
 			s.waitForAllTasks.Wait()
 		}
 	}
@@ -6,53 +6,9 @@ import (
 	"github.com/chrislusf/gleam/flow"
 )
 
-func isMergeableDataset(ds *flow.Dataset, taskCount int) bool {
-	if taskCount != len(ds.Shards) {
-		return false
-	}
-	if taskCount != len(ds.Step.Tasks) {
-		return false
-	}
-	if len(ds.ReadingSteps) > 1 {
-		return false
-	}
-	for _, shard := range ds.Shards {
-		if len(shard.ReadingTasks) > 1 {
-			return false
-		}
-	}
-	return true
-}
-
 // find mergeable parent step or itself if parent is not mergeable
 func findAncestorStepId(step *flow.Step) (int, bool) {
-	current := step
-	taskCount := len(current.Tasks)
-
-	// println("find step", step.Name)
-
-	for taskCount == len(current.Tasks) {
-		if len(current.InputDatasets) > 1 {
-			// more than 2 dataset inputs
-			break
-		}
-		if len(current.InputDatasets) == 0 {
-			// no dataset inputs
-			break
-		}
-		if !isMergeableDataset(current.InputDatasets[0], taskCount) {
-			break
-		}
-		if (!current.IsOnDriverSide && current.InputDatasets[0].Step.IsOnDriverSide) ||
-			(current.IsOnDriverSide && !current.InputDatasets[0].Step.IsOnDriverSide) {
-			break
-		}
-
-		current = current.InputDatasets[0].Step
-		taskCount = len(current.Tasks)
-
-	}
-	return current.Id, true
+	return step.FusedAncestor().Id, true
 }
 
 // group local steps into one step group
@@ -1,8 +1,10 @@
 package plan
 
 import (
+	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/chrislusf/gleam/flow"
 	"github.com/chrislusf/gleam/pb"
@@ -49,3 +51,81 @@ func PrintInstructionSet(instructions *pb.InstructionSet) {
 		println(ins.String())
 	}
 }
+
+func TestTaskGroupState(t *testing.T) {
+	start := time.Now()
+	tg := NewTaskGroup()
+	tg.ParentStepGroup = NewStepGroup()
+
+	if state := tg.State(); state != pb.StatePending {
+		t.Errorf("expecting pending, got %s", state)
+	}
+	tg.WaitAt = start
+	if state := tg.State(); state != pb.StateWaiting {
+		t.Errorf("expecting waiting, got %s", state)
+	}
+	tg.StartAt = start.Add(time.Second)
+	if state := tg.State(); state != pb.StateRunning {
+		t.Errorf("expecting running, got %s", state)
+	}
+	tg.StopAt, tg.Error = start.Add(2*time.Second), fmt.Errorf("agent is lost")
+	if state := tg.State(); state != pb.StateFailed {
+		t.Errorf("expecting failed, got %s", state)
+	}
+	// rerun
+	tg.StartAt = start.Add(3 * time.Second)
+	if state := tg.State(); state != pb.StateRunning {
+		t.Errorf("expecting running again, got %s", state)
+	}
+	tg.StopAt, tg.Error = start.Add(4*time.Second), nil
+	if state := tg.State(); state != pb.StateCompleted {
+		t.Errorf("expecting completed, got %s", state)
+	}
+}
+
+func TestWaitForTaskGroupsWhileReporting(t *testing.T) {
+	sg := NewStepGroup()
+	tg := NewTaskGroup()
+	tg.ParentStepGroup = sg
+	sg.TaskGroups = append(sg.TaskGroups, tg)
+
+	done := make(chan struct{})
+	go func() {
+		tg.MarkWaiting()
+		tg.MarkStart()
+		tg.MarkStop(fmt.Errorf("agent is lost"))
+		tg.MarkStart()
+		tg.MarkStop(nil)
+	}()
+	go func() {
+		defer close(done)
+		sg.WaitForAllTasksToComplete()
+	}()
+
+	// reporting reads the task group while it runs
+	for {
+		select {
+		case <-done:
+			if status := tg.Status(); status.State != pb.StateCompleted || status.Error != nil {
+				t.Errorf("expecting completed, got %+v", status)
+			}
+			return
+		default:
+			tg.Status()
+		}
+	}
+}
+
+func TestRequiredMemoryFromMeasuredPeak(t *testing.T) {
+	tg := NewTaskGroup().AddTask(&flow.Task{Step: &flow.Step{Meta: &flow.StepMetadata{}}})
+	tg.ParentStepGroup = NewStepGroup()
+
+	if memoryMb := tg.RequiredResources().MemoryMb; memoryMb != 0 {
+		t.Errorf("expecting no memory estimated, got %d MB", memoryMb)
+	}
+	tg.ParentStepGroup.RecordPeakMemory(300)
+	tg.ParentStepGroup.RecordPeakMemory(200)
+	if memoryMb := tg.RequiredResources().MemoryMb; memoryMb != 300 {
+		t.Errorf("expecting the measured 300 MB, got %d MB", memoryMb)
+	}
+}
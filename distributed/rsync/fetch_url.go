@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/url"
+	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/chrislusf/gleam/util"
@@ -16,8 +19,9 @@ type ListFileResult struct {
 	Files []FileHash `json:"files,omitempty"`
 }
 
-func ListFiles(server string) ([]FileHash, error) {
-	jsonBlob, err := util.Get(util.SchemePrefix + server + "/list")
+// ListFiles lists the files served, sending the token of the driver if not empty.
+func ListFiles(server, token string) ([]FileHash, error) {
+	jsonBlob, err := util.GetWithToken(util.SchemePrefix+server+"/list", token)
 	if err != nil {
 		return nil, err
 	}
@@ -29,17 +33,20 @@ func ListFiles(server string) ([]FileHash, error) {
 	return ret.Files, nil
 }
 
-func FetchFilesTo(driverAddress string, dir string) error {
+func FetchFilesTo(driverAddress string, dir string, token string) error {
 	fetchLock.Lock()
 	defer fetchLock.Unlock()
 
-	fileList, err := ListFiles(driverAddress)
+	fileList, err := ListFiles(driverAddress, token)
 	if err != nil {
 		return fmt.Errorf("Failed to list files: %v", err)
 	}
 
 	for _, fh := range fileList {
-		toFile := filepath.Join(dir, filepath.Base(fh.File))
+		toFile := filepath.Join(dir, filepath.FromSlash(fh.File))
+		if !strings.HasPrefix(toFile, filepath.Clean(dir)+string(filepath.Separator)) {
+			return fmt.Errorf("File %s is outside of %s", fh.File, dir)
+		}
 		hasSameHash := false
 		if toFileHash, err := GenerateFileHash(toFile); err == nil {
 			hasSameHash = toFileHash.Hash == fh.Hash
@@ -48,7 +55,11 @@ func FetchFilesTo(driverAddress string, dir string) error {
 			// println("skip downloading same", fh.File)
 			continue
 		}
-		if err = FetchUrl(util.SchemePrefix+driverAddress+"/file/"+fh.File, toFile); err != nil {
+		if err = os.MkdirAll(filepath.Dir(toFile), 0755); err != nil {
+			return fmt.Errorf("Failed to create directory for %s: %v", fh.File, err)
+		}
+		fileUrl := util.SchemePrefix + driverAddress + "/file/" + (&url.URL{Path: fh.File}).EscapedPath()
+		if err = FetchUrl(fileUrl, toFile, token); err != nil {
 			return fmt.Errorf("Failed to download file %s: %v", fh.File, err)
 		}
 	}
@@ -56,8 +67,8 @@ func FetchFilesTo(driverAddress string, dir string) error {
 	return err
 }
 
-func FetchUrl(fileUrl string, destFile string) error {
-	_, buf, err := util.DownloadUrl(fileUrl)
+func FetchUrl(fileUrl string, destFile string, token string) error {
+	buf, err := util.GetWithToken(fileUrl, token)
 	if err != nil {
 		return fmt.Errorf("Failed to read from %s: %v", fileUrl, err)
 	}
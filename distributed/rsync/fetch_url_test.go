@@ -0,0 +1,95 @@
+package rsync
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFetchFilesToSubDirectories(t *testing.T) {
+
+	rs, err := NewRsyncServer(os.Args[0], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.AddFile("fetch_url.go", "lib/text/fetch_url.go"); err != nil {
+		t.Fatal(err)
+	}
+	rs.StartRsyncServer("localhost:0")
+
+	dir := t.TempDir()
+	if err := FetchFilesTo(fmt.Sprintf("localhost:%d", rs.Port), dir, ""); err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+
+	expected, _ := ioutil.ReadFile("fetch_url.go")
+	actual, err := ioutil.ReadFile(filepath.Join(dir, "lib", "text", "fetch_url.go"))
+	if err != nil {
+		t.Fatalf("expecting the sub directories recreated: %v", err)
+	}
+	if string(actual) != string(expected) {
+		t.Errorf("fetched %d bytes, expected %d bytes", len(actual), len(expected))
+	}
+	if _, err := os.Stat(filepath.Join(dir, filepath.Base(os.Args[0]))); err != nil {
+		t.Errorf("expecting the executable fetched: %v", err)
+	}
+}
+
+func TestFetchFilesToRejectsPathTraversal(t *testing.T) {
+
+	for _, name := range []string{"../escaped.go", "lib/../../escaped.go", "lib/../../../tmp/escaped.go"} {
+		rs, err := NewRsyncServer(os.Args[0], nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rs.AddFile("fetch_url.go", name); err != nil {
+			t.Fatal(err)
+		}
+		rs.StartRsyncServer("localhost:0")
+
+		parent := t.TempDir()
+		dir := filepath.Join(parent, "executor")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := FetchFilesTo(fmt.Sprintf("localhost:%d", rs.Port), dir, ""); err == nil {
+			t.Errorf("expecting %s to be rejected", name)
+		}
+		if _, err := os.Stat(filepath.Join(parent, "escaped.go")); err == nil {
+			t.Errorf("%s is written outside of the directory", name)
+		}
+	}
+}
+
+func TestFetchFilesRequiresToken(t *testing.T) {
+
+	rs, err := NewRsyncServer(os.Args[0], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.AddFile("fetch_url.go", "fetch_url.go"); err != nil {
+		t.Fatal(err)
+	}
+	rs.token = "s3cr3t"
+	rs.StartRsyncServer("localhost:0")
+	server := fmt.Sprintf("localhost:%d", rs.Port)
+
+	for _, token := range []string{"", "wrong"} {
+		if _, err := ListFiles(server, token); err == nil {
+			t.Errorf("expecting listing with token %q rejected", token)
+		}
+		if err := FetchUrl("http://"+server+"/file/fetch_url.go", filepath.Join(t.TempDir(), "fetch_url.go"), token); err == nil {
+			t.Errorf("expecting downloading with token %q rejected", token)
+		}
+	}
+
+	dir := t.TempDir()
+	if err := FetchFilesTo(server, dir, "s3cr3t"); err != nil {
+		t.Fatalf("fetch with token: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "fetch_url.go")); err != nil {
+		t.Errorf("expecting the file fetched with the token: %v", err)
+	}
+}
@@ -3,6 +3,7 @@
 package rsync
 
 import (
+	"crypto/subtle"
 	"hash/crc32"
 	"io"
 	"log"
@@ -13,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/chrislusf/gleam/distributed/security"
 	"github.com/chrislusf/gleam/util"
 )
 
@@ -30,12 +32,14 @@ type RsyncServer struct {
 	RelatedFiles   []string
 
 	fileHashes []FileHash
+	token      string // required from the clients if not empty
 }
 
 func NewRsyncServer(file string, relatedFiles []string) (*RsyncServer, error) {
 	rs := &RsyncServer{
 		ExecutableFile: file,
 		RelatedFiles:   relatedFiles,
+		token:          security.ClientToken(),
 	}
 	if fh, err := GenerateFileHash(file); err != nil {
 		log.Printf("Failed1 to read %s: %v", file, err)
@@ -52,11 +56,39 @@ func NewRsyncServer(file string, relatedFiles []string) (*RsyncServer, error) {
 	return rs, nil
 }
 
+// AddFile serves a file under a slash separated name,
+// which can include directories relative to the executor's working directory.
+func (rs *RsyncServer) AddFile(fullPath, name string) error {
+	fh, err := GenerateFileHash(fullPath)
+	if err != nil {
+		return err
+	}
+	fh.File = name
+	rs.fileHashes = append(rs.fileHashes, *fh)
+	return nil
+}
+
+// checkToken serves only the clients sending the same token as this driver,
+// i.e., the agents starting its executors.
+func (rs *RsyncServer) checkToken(w http.ResponseWriter, r *http.Request) bool {
+	if rs.token == "" || subtle.ConstantTimeCompare([]byte(security.TokenFromRequest(r)), []byte(rs.token)) == 1 {
+		return true
+	}
+	http.Error(w, "invalid token", http.StatusUnauthorized)
+	return false
+}
+
 func (rs *RsyncServer) listHandler(w http.ResponseWriter, r *http.Request) {
+	if !rs.checkToken(w, r) {
+		return
+	}
 	util.Json(w, r, http.StatusAccepted, ListFileResult{rs.fileHashes})
 }
 
 func (rs *RsyncServer) fileHandler(w http.ResponseWriter, r *http.Request) {
+	if !rs.checkToken(w, r) {
+		return
+	}
 	fileName := r.URL.Path[len("/file/"):]
 	for _, fh := range rs.fileHashes {
 		if fh.File == fileName {
@@ -80,7 +112,7 @@ func (rs *RsyncServer) StartRsyncServer(listenOn string) {
 
 	var listener net.Listener
 	var err error
-	listener, err = net.Listen("tcp", listenOn)
+	listener, err = security.Listen(listenOn)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -19,7 +19,7 @@ func TestNormalHttpCopy(t *testing.T) {
 	}
 	rsyncServer.StartRsyncServer(":0")
 
-	err = FetchFilesTo(fmt.Sprintf("localhost:%d", rsyncServer.Port), "/tmp")
+	err = FetchFilesTo(fmt.Sprintf("localhost:%d", rsyncServer.Port), "/tmp", "")
 	if err != nil {
 		fmt.Printf("pausing localhost:%d\n", rsyncServer.Port)
 		time.Sleep(time.Minute)
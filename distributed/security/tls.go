@@ -0,0 +1,142 @@
+// Package security secures the connections between driver, master, agents
+// and executors.
+package security
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+
+	"github.com/chrislusf/gleam/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// TLSOption configures TLS for all gRPC, ControlMessage and file server
+// connections of a process. TLS is enabled when CertFile is set.
+type TLSOption struct {
+	CAFile            string
+	CertFile          string
+	KeyFile           string
+	RequireClientCert bool
+}
+
+var (
+	serverTLSConfig *tls.Config
+	clientTLSConfig *tls.Config
+)
+
+func (o *TLSOption) IsEnabled() bool {
+	return o != nil && o.CertFile != ""
+}
+
+// Args returns the command line flags to pass the same TLS option
+// to a gleamd sub process.
+func (o *TLSOption) Args() (args []string) {
+	if !o.IsEnabled() {
+		return nil
+	}
+	args = append(args, "--tls.cert", o.CertFile, "--tls.key", o.KeyFile)
+	if o.CAFile != "" {
+		args = append(args, "--tls.ca", o.CAFile)
+	}
+	if o.RequireClientCert {
+		args = append(args, "--tls.requireClientCert")
+	}
+	return
+}
+
+// SetupTLS loads the certificates. It should be called before any
+// connections are made or accepted.
+func SetupTLS(option *TLSOption) error {
+	if !option.IsEnabled() {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(option.CertFile, option.KeyFile)
+	if err != nil {
+		return fmt.Errorf("Failed to load certificate %s: %v", option.CertFile, err)
+	}
+
+	var certPool *x509.CertPool
+	if option.CAFile != "" {
+		caData, err := ioutil.ReadFile(option.CAFile)
+		if err != nil {
+			return fmt.Errorf("Failed to read CA %s: %v", option.CAFile, err)
+		}
+		certPool = x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caData) {
+			return fmt.Errorf("Failed to parse CA %s", option.CAFile)
+		}
+	}
+
+	server := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    certPool,
+	}
+	if option.RequireClientCert {
+		server.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	// gRPC clients only offer "h2", while browsers and http clients also
+	// offer "http/1.1", which is what the http servers behind cmux speak.
+	server.GetConfigForClient = func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+		c := server.Clone()
+		c.NextProtos = []string{"h2"}
+		for _, proto := range hello.SupportedProtos {
+			if proto == "http/1.1" {
+				c.NextProtos = []string{"http/1.1"}
+			}
+		}
+		return c, nil
+	}
+
+	serverTLSConfig = server
+	clientTLSConfig = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      certPool,
+	}
+
+	util.Transport.TLSClientConfig = clientTLSConfig
+	util.SchemePrefix = "https://"
+
+	return nil
+}
+
+func IsTLSEnabled() bool {
+	return clientTLSConfig != nil
+}
+
+// Listen listens on the tcp address, with TLS if enabled.
+func Listen(address string) (net.Listener, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	if serverTLSConfig == nil {
+		return listener, nil
+	}
+	return tls.NewListener(listener, serverTLSConfig), nil
+}
+
+// Dial connects to the tcp address, with TLS if enabled.
+func Dial(address string) (net.Conn, error) {
+	if clientTLSConfig == nil {
+		return net.Dial("tcp", address)
+	}
+	return tls.Dial("tcp", address, clientTLSConfig)
+}
+
+// GrpcDialOptions returns the transport security and token options for gRPC clients.
+func GrpcDialOptions() (options []grpc.DialOption) {
+	if clientTLSConfig == nil {
+		options = append(options, grpc.WithInsecure())
+	} else {
+		options = append(options, grpc.WithTransportCredentials(credentials.NewTLS(clientTLSConfig)))
+	}
+	if clientToken != "" {
+		options = append(options, grpc.WithPerRPCCredentials(tokenCredentials{}))
+	}
+	return
+}
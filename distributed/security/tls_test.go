@@ -0,0 +1,214 @@
+package security
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/gleam/util"
+)
+
+// writeTestCerts writes a self-signed CA, and a certificate for 127.0.0.1
+// signed by it, used by both the server and the client.
+func writeTestCerts(t *testing.T) (caFile, certFile, keyFile string) {
+	dir := t.TempDir()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "gleam test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDer, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "gleam test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caFile = filepath.Join(dir, "ca.crt")
+	certFile = filepath.Join(dir, "gleam.crt")
+	keyFile = filepath.Join(dir, "gleam.key")
+	for file, block := range map[string]*pem.Block{
+		caFile:   {Type: "CERTIFICATE", Bytes: caDer},
+		certFile: {Type: "CERTIFICATE", Bytes: der},
+		keyFile:  {Type: "EC PRIVATE KEY", Bytes: keyDer},
+	} {
+		if err := ioutil.WriteFile(file, pem.EncodeToMemory(block), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return
+}
+
+// setupTestTLS sets up the TLS option, and restores plaintext after the test.
+func setupTestTLS(t *testing.T, option *TLSOption) {
+	transportTLSConfig, schemePrefix := util.Transport.TLSClientConfig, util.SchemePrefix
+	t.Cleanup(func() {
+		serverTLSConfig, clientTLSConfig = nil, nil
+		util.Transport.TLSClientConfig, util.SchemePrefix = transportTLSConfig, schemePrefix
+	})
+	if err := SetupTLS(option); err != nil {
+		t.Fatalf("setup tls: %v", err)
+	}
+}
+
+// echoOnce accepts one connection, and echoes one line back,
+// or reports the error of reading it.
+func echoOnce(listener net.Listener) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			done <- err
+			return
+		}
+		defer conn.Close()
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		if err != nil {
+			done <- err
+			return
+		}
+		_, err = conn.Write([]byte(line))
+		done <- err
+	}()
+	return done
+}
+
+func echo(t *testing.T, conn net.Conn) {
+	if _, err := conn.Write([]byte("hello\n")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil || line != "hello\n" {
+		t.Fatalf("expecting hello echoed, got %q: %v", line, err)
+	}
+}
+
+func TestPlaintextWithoutTLS(t *testing.T) {
+	setupTestTLS(t, &TLSOption{})
+	if IsTLSEnabled() {
+		t.Fatalf("expecting TLS disabled without a certificate")
+	}
+
+	listener, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	if _, isPlain := listener.(*net.TCPListener); !isPlain {
+		t.Errorf("expecting a plain tcp listener, got %T", listener)
+	}
+	done := echoOnce(listener)
+
+	conn, err := Dial(listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, isTLS := conn.(*tls.Conn); isTLS {
+		t.Errorf("expecting a plaintext connection")
+	}
+	echo(t, conn)
+	if err := <-done; err != nil {
+		t.Errorf("server: %v", err)
+	}
+
+	if options := GrpcDialOptions(); len(options) != 1 {
+		t.Errorf("expecting only the insecure gRPC option, got %d options", len(options))
+	}
+}
+
+func TestTLSHandshake(t *testing.T) {
+	caFile, certFile, keyFile := writeTestCerts(t)
+	setupTestTLS(t, &TLSOption{CAFile: caFile, CertFile: certFile, KeyFile: keyFile, RequireClientCert: true})
+	if !IsTLSEnabled() || util.SchemePrefix != "https://" {
+		t.Fatalf("expecting TLS enabled")
+	}
+
+	listener, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	done := echoOnce(listener)
+
+	conn, err := Dial(listener.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	echo(t, conn)
+	if err := <-done; err != nil {
+		t.Errorf("server: %v", err)
+	}
+
+	state := conn.(*tls.Conn).ConnectionState()
+	if !state.HandshakeComplete || len(state.PeerCertificates) == 0 {
+		t.Errorf("expecting a verified server certificate, got %+v", state)
+	}
+}
+
+func TestTLSRejectsClientWithoutCert(t *testing.T) {
+	caFile, certFile, keyFile := writeTestCerts(t)
+	setupTestTLS(t, &TLSOption{CAFile: caFile, CertFile: certFile, KeyFile: keyFile, RequireClientCert: true})
+
+	listener, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	done := echoOnce(listener)
+
+	// the client trusts the server, but has no certificate of its own
+	conn, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{RootCAs: clientTLSConfig.RootCAs})
+	if err == nil {
+		defer conn.Close()
+		// with TLS 1.3, the server rejects the client after the client handshake completes
+		conn.Write([]byte("hello\n"))
+		_, err = bufio.NewReader(conn).ReadString('\n')
+	}
+	if err == nil {
+		t.Errorf("expecting the client without certificate rejected")
+	}
+	if err := <-done; err == nil {
+		t.Errorf("expecting the server to fail the handshake")
+	}
+}
@@ -0,0 +1,157 @@
+package security
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	PermissionSubmit = "submit" // run executors, write datasets
+	PermissionRead   = "read"   // read datasets and status
+	PermissionDelete = "delete" // delete datasets
+	PermissionAdmin  = "admin"  // all of the above, and join as an agent
+
+	// TokenEnvironmentVariable passes the client token to executors
+	TokenEnvironmentVariable = "GLEAM_AUTH_TOKEN"
+
+	tokenMetadataKey = "gleam-token"
+)
+
+var clientToken string
+
+// TokenStore keeps the permissions granted to each token.
+// A nil TokenStore allows everything.
+type TokenStore struct {
+	token2Permissions map[string]map[string]bool
+}
+
+// LoadTokenFile reads one token per line, followed by its comma separated permissions, e.g.,
+//
+//	s3cr3t submit,read,delete
+//
+// Empty lines and lines starting with "#" are skipped.
+// An empty file name disables the authentication.
+func LoadTokenFile(fileName string) (*TokenStore, error) {
+	if fileName == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open token file %s: %v", fileName, err)
+	}
+	defer f.Close()
+
+	ts := &TokenStore{token2Permissions: make(map[string]map[string]bool)}
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expecting a token and its permissions", fileName, lineNumber)
+		}
+		permissions := make(map[string]bool)
+		for _, permission := range strings.Split(fields[1], ",") {
+			switch permission {
+			case PermissionSubmit, PermissionRead, PermissionDelete, PermissionAdmin:
+				permissions[permission] = true
+			default:
+				return nil, fmt.Errorf("%s:%d: unknown permission %q", fileName, lineNumber, permission)
+			}
+		}
+		ts.token2Permissions[fields[0]] = permissions
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read token file %s: %v", fileName, err)
+	}
+
+	return ts, nil
+}
+
+// Check returns an error if the token does not have the permission.
+func (ts *TokenStore) Check(token, permission string) error {
+	if ts == nil {
+		return nil
+	}
+	permissions, found := ts.token2Permissions[token]
+	if !found {
+		return fmt.Errorf("invalid token")
+	}
+	if !permissions[permission] && !permissions[PermissionAdmin] {
+		return fmt.Errorf("token has no %s permission", permission)
+	}
+	return nil
+}
+
+// CheckContext checks the token sent by a gRPC client.
+func (ts *TokenStore) CheckContext(ctx context.Context, permission string) error {
+	return ts.Check(TokenFromContext(ctx), permission)
+}
+
+// RequireTLS returns an error if tokens are checked without TLS, which
+// would let clients send their tokens in plaintext.
+func (ts *TokenStore) RequireTLS() error {
+	if ts != nil && !IsTLSEnabled() {
+		return fmt.Errorf("token authentication requires TLS")
+	}
+	return nil
+}
+
+// CheckRequest checks the token sent by an http client, either as
+// "Authorization: Bearer <token>", or as the password of basic
+// authentication, which browsers prompt for.
+func (ts *TokenStore) CheckRequest(r *http.Request, permission string) error {
+	return ts.Check(TokenFromRequest(r), permission)
+}
+
+// SetClientToken sets the token sent along with all requests from this process.
+// TLS needs to be set up first, so that the token is never sent in plaintext.
+func SetClientToken(token string) error {
+	if token != "" && !IsTLSEnabled() {
+		return fmt.Errorf("sending a token requires TLS")
+	}
+	clientToken = token
+	return nil
+}
+
+func ClientToken() string {
+	return clientToken
+}
+
+func TokenFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md[tokenMetadataKey]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func TokenFromRequest(r *http.Request) string {
+	if _, password, ok := r.BasicAuth(); ok {
+		return password
+	}
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+// tokenCredentials sends the client token with every gRPC call.
+type tokenCredentials struct{}
+
+func (tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{tokenMetadataKey: clientToken}, nil
+}
+
+func (tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
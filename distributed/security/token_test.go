@@ -0,0 +1,93 @@
+package security
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestTokenPermissions(t *testing.T) {
+
+	f, err := ioutil.TempFile("", "tokens")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("# token permissions\nsubmitter submit,read\n\nroot admin\n")
+	f.Close()
+
+	ts, err := LoadTokenFile(f.Name())
+	if err != nil {
+		t.Fatalf("load token file: %v", err)
+	}
+
+	checks := []struct {
+		token      string
+		permission string
+		allowed    bool
+	}{
+		{"submitter", PermissionSubmit, true},
+		{"submitter", PermissionRead, true},
+		{"submitter", PermissionDelete, false},
+		{"root", PermissionDelete, true},
+		{"unknown", PermissionRead, false},
+		{"", PermissionRead, false},
+	}
+	for _, c := range checks {
+		if err := ts.Check(c.token, c.permission); (err == nil) != c.allowed {
+			t.Errorf("token %q permission %s: allowed=%v, got %v", c.token, c.permission, c.allowed, err)
+		}
+	}
+
+	var noAuth *TokenStore
+	if err := noAuth.Check("", PermissionAdmin); err != nil {
+		t.Errorf("nil token store should allow all: %v", err)
+	}
+}
+
+func TestTokensRequireTLS(t *testing.T) {
+
+	if err := SetClientToken("s3cr3t"); err == nil {
+		t.Errorf("expecting a token without TLS to be rejected")
+	}
+	if err := SetClientToken(""); err != nil {
+		t.Errorf("expecting no token to be allowed without TLS: %v", err)
+	}
+
+	ts := &TokenStore{token2Permissions: map[string]map[string]bool{"reader": {PermissionRead: true}}}
+	if err := ts.RequireTLS(); err == nil {
+		t.Errorf("expecting checking tokens without TLS to be rejected")
+	}
+	var noAuth *TokenStore
+	if err := noAuth.RequireTLS(); err != nil {
+		t.Errorf("expecting no token store to be allowed without TLS: %v", err)
+	}
+
+	if (tokenCredentials{}).RequireTransportSecurity() != true {
+		t.Errorf("expecting gRPC tokens to require transport security")
+	}
+}
+
+func TestTokenFromRequest(t *testing.T) {
+
+	ts := &TokenStore{token2Permissions: map[string]map[string]bool{"reader": {PermissionRead: true}}}
+
+	r := httptest.NewRequest("GET", "/api/v1/flows", nil)
+	if err := ts.CheckRequest(r, PermissionRead); err == nil {
+		t.Errorf("expecting a request without token to be rejected")
+	}
+	r.Header.Set("Authorization", "Bearer reader")
+	if err := ts.CheckRequest(r, PermissionRead); err != nil {
+		t.Errorf("bearer token: %v", err)
+	}
+
+	r = httptest.NewRequest("GET", "/", nil)
+	r.SetBasicAuth("", "reader")
+	if err := ts.CheckRequest(r, PermissionRead); err != nil {
+		t.Errorf("basic authentication: %v", err)
+	}
+	if err := ts.CheckRequest(r, PermissionAdmin); err == nil {
+		t.Errorf("expecting a token without admin permission to be rejected")
+	}
+}
@@ -36,6 +36,16 @@ func NewLocalFileDataStore(dir, name string) (ds *LocalFileDataStore) {
 	return
 }
 
+// OpenLocalFileDataStore opens a data store written before, e.g., before the
+// agent restarted, keeping its data.
+func OpenLocalFileDataStore(dir, name string) (*LocalFileDataStore, error) {
+	ds := NewLocalFileDataStore(dir, name)
+	if err := ds.store.openExisting(); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
 func (ds *LocalFileDataStore) Write(data []byte) (int, error) {
 	count, err := ds.store.Write(data)
 	ds.lastWriteAt = time.Now()
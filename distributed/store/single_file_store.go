@@ -119,6 +119,27 @@ func (l *SingleFileStore) openNew() error {
 	return nil
 }
 
+// openExisting opens the file written before, and appends to its data.
+func (l *SingleFileStore) openExisting() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.filename(), os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("can't open existing logfile: %s", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("can't stat existing logfile: %s", err)
+	}
+
+	l.file = f
+	l.size = info.Size()
+	l.Position = l.Offset + l.size
+	return nil
+}
+
 func (l *SingleFileStore) filename() string {
 	return l.Filename
 }
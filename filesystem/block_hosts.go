@@ -0,0 +1,41 @@
+package filesystem
+
+import (
+	"sort"
+)
+
+// blockLocationSource lists the hosts storing each block of a file.
+type blockLocationSource interface {
+	BlockLocations(path string) ([][]string, error)
+}
+
+// blockHosts returns the hosts storing the blocks of the file,
+// the ones storing the most blocks first.
+func blockHosts(source blockLocationSource, path string) ([]string, error) {
+	blocks, err := source.BlockLocations(path)
+	if err != nil {
+		return nil, err
+	}
+
+	blockCounts := make(map[string]int)
+	var hosts []string
+	for _, blockHosts := range blocks {
+		for _, host := range blockHosts {
+			if blockCounts[host] == 0 {
+				hosts = append(hosts, host)
+			}
+			blockCounts[host]++
+		}
+	}
+	sort.Stable(byBlockCount{hosts, blockCounts})
+	return hosts, nil
+}
+
+type byBlockCount struct {
+	hosts  []string
+	counts map[string]int
+}
+
+func (s byBlockCount) Len() int           { return len(s.hosts) }
+func (s byBlockCount) Swap(i, j int)      { s.hosts[i], s.hosts[j] = s.hosts[j], s.hosts[i] }
+func (s byBlockCount) Less(i, j int) bool { return s.counts[s.hosts[i]] > s.counts[s.hosts[j]] }
@@ -0,0 +1,47 @@
+package filesystem
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type fakeBlockLocations map[string][][]string
+
+func (f fakeBlockLocations) BlockLocations(path string) ([][]string, error) {
+	blocks, found := f[path]
+	if !found {
+		return nil, errors.New("file not found")
+	}
+	return blocks, nil
+}
+
+func TestBlockHosts(t *testing.T) {
+	source := fakeBlockLocations{
+		"/empty":  nil,
+		"/single": {{"dn1", "dn2", "dn3"}},
+		"/ranked": {{"dn1", "dn2"}, {"dn3", "dn2"}, {"dn2", "dn3"}, {"dn4"}},
+	}
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{"/empty", nil},
+		{"/single", []string{"dn1", "dn2", "dn3"}},
+		{"/ranked", []string{"dn2", "dn3", "dn1", "dn4"}},
+	}
+	for _, tt := range tests {
+		hosts, err := blockHosts(source, tt.path)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.path, err)
+			continue
+		}
+		if !reflect.DeepEqual(hosts, tt.expected) {
+			t.Errorf("%s: expecting %v, got %v", tt.path, tt.expected, hosts)
+		}
+	}
+
+	if hosts, err := blockHosts(source, "/missing"); err == nil {
+		t.Errorf("expecting an error for a missing file, got %v", hosts)
+	}
+}
@@ -29,6 +29,12 @@ type VirtualFileSystem interface {
 	IsDir(*FileLocation) bool
 }
 
+// FileHostLocator is implemented by file systems that know
+// which hosts store the file locally.
+type FileHostLocator interface {
+	Hosts(*FileLocation) []string
+}
+
 var (
 	fileSystems = []VirtualFileSystem{
 		&LocalFileSystem{},
@@ -70,3 +76,17 @@ func IsDir(filepath string) bool {
 	}
 	return false
 }
+
+// Hosts returns the hosts storing the file locally, if known.
+func Hosts(filepath string) []string {
+	fileLocation := &FileLocation{filepath}
+	for _, fs := range fileSystems {
+		if fs.Accept(fileLocation) {
+			if locator, ok := fs.(FileHostLocator); ok {
+				return locator.Hosts(fileLocation)
+			}
+			return nil
+		}
+	}
+	return nil
+}
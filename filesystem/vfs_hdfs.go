@@ -3,10 +3,15 @@ package filesystem
 import (
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/colinmarc/hdfs"
+	hdfs_proto "github.com/colinmarc/hdfs/protocol/hadoop_hdfs"
+	"github.com/colinmarc/hdfs/rpc"
+	"github.com/golang/protobuf/proto"
 )
 
 /*
@@ -17,6 +22,8 @@ Get the namenode from
 to locate hdfs-site.xml and core-site.xml
 */
 type HdfsFileSystem struct {
+	sync.Mutex
+	namenodeConnections map[string]*rpc.NamenodeConnection
 }
 
 func (fs *HdfsFileSystem) Accept(fl *FileLocation) bool {
@@ -91,6 +98,93 @@ func (fs *HdfsFileSystem) IsDir(fl *FileLocation) bool {
 	return fi.IsDir()
 }
 
+// Hosts returns the datanodes storing the blocks of the file,
+// the ones storing the most blocks first.
+func (fs *HdfsFileSystem) Hosts(fl *FileLocation) []string {
+	namenode, path, err := splitLocationToParts(fl.Location)
+	if err != nil {
+		return nil
+	}
+	if namenode == "" {
+		namenode = os.Getenv("HADOOP_NAMENODE")
+	}
+
+	namenodeConnection, err := fs.namenodeConnection(namenode)
+	if err != nil {
+		log.Printf("failed to connect to %s:%v\n", namenode, err)
+		return nil
+	}
+	hosts, err := blockHosts(namenodeBlockLocations{namenodeConnection}, path)
+	if err != nil {
+		log.Printf("failed to get block locations of %s:%v\n", fl.Location, err)
+		fs.closeNamenodeConnection(namenode, namenodeConnection)
+		return nil
+	}
+	return hosts
+}
+
+// namenodeConnection returns the connection to the namenode,
+// shared by the lookups of all files on the namenode.
+func (fs *HdfsFileSystem) namenodeConnection(namenode string) (*rpc.NamenodeConnection, error) {
+	fs.Lock()
+	defer fs.Unlock()
+	if namenodeConnection, found := fs.namenodeConnections[namenode]; found {
+		return namenodeConnection, nil
+	}
+
+	username, err := hdfs.Username()
+	if err != nil {
+		return nil, err
+	}
+	namenodeConnection, err := rpc.NewNamenodeConnection(namenode, username)
+	if err != nil {
+		return nil, err
+	}
+	if fs.namenodeConnections == nil {
+		fs.namenodeConnections = make(map[string]*rpc.NamenodeConnection)
+	}
+	fs.namenodeConnections[namenode] = namenodeConnection
+	return namenodeConnection, nil
+}
+
+// closeNamenodeConnection closes a failed connection, so that
+// the next lookup reconnects to the namenode.
+func (fs *HdfsFileSystem) closeNamenodeConnection(namenode string, namenodeConnection *rpc.NamenodeConnection) {
+	fs.Lock()
+	if fs.namenodeConnections[namenode] == namenodeConnection {
+		delete(fs.namenodeConnections, namenode)
+	}
+	fs.Unlock()
+	namenodeConnection.Close()
+}
+
+// namenodeBlockLocations gets the block locations of files from the namenode.
+type namenodeBlockLocations struct {
+	*rpc.NamenodeConnection
+}
+
+func (c namenodeBlockLocations) BlockLocations(path string) ([][]string, error) {
+	request := &hdfs_proto.GetBlockLocationsRequestProto{
+		Src:    proto.String(path),
+		Offset: proto.Uint64(0),
+		Length: proto.Uint64(math.MaxInt64),
+	}
+	response := &hdfs_proto.GetBlockLocationsResponseProto{}
+	if err := c.Execute("getBlockLocations", request, response); err != nil {
+		return nil, err
+	}
+
+	var blocks [][]string
+	for _, block := range response.GetLocations().GetBlocks() {
+		var hosts []string
+		for _, datanode := range block.GetLocs() {
+			hosts = append(hosts, datanode.GetId().GetHostName())
+		}
+		blocks = append(blocks, hosts)
+	}
+	return blocks, nil
+}
+
 func splitLocationToParts(location string) (namenode, path string, err error) {
 	hdfsPrefix := "hdfs://"
 	if !strings.HasPrefix(location, hdfsPrefix) {
@@ -51,3 +51,9 @@ func (fs *LocalFileSystem) IsDir(fl *FileLocation) bool {
 	}
 	return false
 }
+
+// Hosts returns nil, since the driver can not tell which agents
+// have a local file with the same path.
+func (fs *LocalFileSystem) Hosts(fl *FileLocation) []string {
+	return nil
+}
@@ -0,0 +1,214 @@
+package flow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/chrislusf/gleam/instruction"
+	"github.com/chrislusf/gleam/script"
+)
+
+// flowPlan describes the steps and datasets of a flow, and how the steps
+// are fused into step groups. It leaves out the flow hash code, which
+// changes every run, so that the plans of two versions can be diffed.
+type flowPlan struct {
+	Steps      []stepPlan      `json:"steps"`
+	Datasets   []datasetPlan   `json:"datasets"`
+	StepGroups []stepGroupPlan `json:"stepGroups"`
+}
+
+type stepPlan struct {
+	Id             int    `json:"id"`
+	Name           string `json:"name"`
+	NetworkType    string `json:"networkType"`
+	Tasks          int    `json:"tasks"`
+	InputDatasets  []int  `json:"inputDatasets"`
+	OutputDataset  *int   `json:"outputDataset"`
+	IsOnDriverSide bool   `json:"isOnDriverSide,omitempty"`
+	IsPipe         bool   `json:"isPipe,omitempty"`
+	Command        string `json:"command,omitempty"`
+	StepGroup      int    `json:"stepGroup"`
+}
+
+type datasetPlan struct {
+	Id              int      `json:"id"`
+	Step            int      `json:"step"`
+	ReadingSteps    []int    `json:"readingSteps"`
+	Shards          int      `json:"shards"`
+	OnDisk          bool     `json:"onDisk,omitempty"`
+	TotalSizeMB     int64    `json:"totalSizeMB"`
+	PartitionSizeMB int64    `json:"partitionSizeMB"`
+	PartitionedBy   []int    `json:"partitionedBy,omitempty"`
+	SortedBy        []string `json:"sortedBy,omitempty"`
+}
+
+type stepGroupPlan struct {
+	Id    int   `json:"id"`
+	Steps []int `json:"steps"`
+	Tasks int   `json:"tasks"`
+}
+
+// ToJSON writes the plan of the flow in JSON.
+func (fc *FlowContext) ToJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(fc.plan(), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// ToDot writes the plan of the flow as a Graphviz graph, e.g.,
+//
+//	dot -Tsvg flow.dot > flow.svg
+//
+// Steps are boxes, clustered by their step groups, and datasets are
+// ellipses, or cylinders if on disk. The edges from datasets to steps
+// are labeled by the network types of the steps.
+func (fc *FlowContext) ToDot(w io.Writer) error {
+	p := fc.plan()
+	var b bytes.Buffer
+	b.WriteString("digraph flow {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [fontname=monospace, fontsize=10];\n")
+	b.WriteString("  edge [fontname=monospace, fontsize=8];\n")
+
+	for _, sg := range p.StepGroups {
+		fmt.Fprintf(&b, "  subgraph cluster_%d {\n", sg.Id)
+		fmt.Fprintf(&b, "    label=%q;\n", fmt.Sprintf("step group %d, %d tasks", sg.Id, sg.Tasks))
+		b.WriteString("    style=dashed;\n")
+		for _, stepId := range sg.Steps {
+			step := p.Steps[stepId]
+			label := fmt.Sprintf("%s%d\n%d tasks", step.Name, step.Id, step.Tasks)
+			if step.IsOnDriverSide {
+				label += "\non driver"
+			}
+			if step.Command != "" {
+				label += "\n" + step.Command
+			}
+			fmt.Fprintf(&b, "    s%d [shape=box, label=%q];\n", step.Id, label)
+		}
+		b.WriteString("  }\n")
+	}
+
+	for _, d := range p.Datasets {
+		shape := "ellipse"
+		if d.OnDisk {
+			shape = "cylinder"
+		}
+		label := fmt.Sprintf("d%d\n%d shards", d.Id, d.Shards)
+		if d.TotalSizeMB > 0 {
+			label += fmt.Sprintf("\n%d MB, %d MB/shard", d.TotalSizeMB, d.PartitionSizeMB)
+		}
+		if len(d.PartitionedBy) > 0 {
+			label += fmt.Sprintf("\npartitioned by %v", d.PartitionedBy)
+		}
+		if len(d.SortedBy) > 0 {
+			label += "\nsorted by " + strings.Join(d.SortedBy, ",")
+		}
+		fmt.Fprintf(&b, "  d%d [shape=%s, label=%q];\n", d.Id, shape, label)
+	}
+
+	for _, d := range p.Datasets {
+		fmt.Fprintf(&b, "  s%d -> d%d;\n", d.Step, d.Id)
+		for _, stepId := range d.ReadingSteps {
+			fmt.Fprintf(&b, "  d%d -> s%d [label=%q];\n", d.Id, stepId, p.Steps[stepId].NetworkType)
+		}
+	}
+
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (fc *FlowContext) plan() *flowPlan {
+	p := &flowPlan{
+		Steps:      []stepPlan{},
+		Datasets:   []datasetPlan{},
+		StepGroups: []stepGroupPlan{},
+	}
+
+	// the steps are added in order, so the fused ancestors come first
+	groupOfAncestor := make(map[int]int)
+	for _, step := range fc.Steps {
+		ancestor := step.FusedAncestor()
+		groupId, found := groupOfAncestor[ancestor.Id]
+		if !found {
+			groupId = len(p.StepGroups)
+			groupOfAncestor[ancestor.Id] = groupId
+			p.StepGroups = append(p.StepGroups, stepGroupPlan{
+				Id:    groupId,
+				Tasks: len(ancestor.Tasks),
+			})
+		}
+		p.StepGroups[groupId].Steps = append(p.StepGroups[groupId].Steps, step.Id)
+
+		sp := stepPlan{
+			Id:             step.Id,
+			Name:           step.Name,
+			NetworkType:    step.NetworkType.String(),
+			Tasks:          len(step.Tasks),
+			InputDatasets:  []int{},
+			IsOnDriverSide: step.IsOnDriverSide,
+			IsPipe:         step.IsPipe,
+			StepGroup:      groupId,
+		}
+		for _, d := range step.InputDatasets {
+			sp.InputDatasets = append(sp.InputDatasets, d.Id)
+		}
+		if step.OutputDataset != nil {
+			id := step.OutputDataset.Id
+			sp.OutputDataset = &id
+		}
+		if step.Command != nil {
+			sp.Command = commandLine(step.Command)
+		}
+		p.Steps = append(p.Steps, sp)
+	}
+
+	for _, d := range fc.Datasets {
+		dp := datasetPlan{
+			Id:            d.Id,
+			ReadingSteps:  []int{},
+			Shards:        len(d.Shards),
+			OnDisk:        d.GetIsOnDiskIO(),
+			PartitionedBy: d.IsPartitionedBy,
+		}
+		if d.Step != nil {
+			dp.Step = d.Step.Id
+			dp.TotalSizeMB = d.GetTotalSize()
+			if len(d.Shards) > 0 {
+				dp.PartitionSizeMB = d.GetPartitionSize()
+			}
+		}
+		for _, step := range d.ReadingSteps {
+			dp.ReadingSteps = append(dp.ReadingSteps, step.Id)
+		}
+		for _, orderBy := range d.IsLocalSorted {
+			order := "asc"
+			if orderBy.Order == instruction.Descending {
+				order = "desc"
+			}
+			dp.SortedBy = append(dp.SortedBy, fmt.Sprintf("%d %s", orderBy.Index, order))
+		}
+		p.Datasets = append(p.Datasets, dp)
+	}
+
+	return p
+}
+
+// commandLine formats the command, quoting the arguments with spaces.
+func commandLine(c *script.Command) string {
+	words := []string{c.Path}
+	for _, arg := range c.Args {
+		if strings.ContainsAny(arg, " \t\"'") {
+			arg = strconv.Quote(arg)
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
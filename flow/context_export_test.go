@@ -0,0 +1,58 @@
+package flow
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+// exportTestFlow has a map on each side of a join of two partitions, and
+// a sort by another field after it.
+func exportTestFlow() *FlowContext {
+	fc := New()
+	left := fc.Strings([]string{"apple", "banana"}).Map(`
+		function(line)
+			return line, #line
+		end
+	`).Partition(2)
+	right := fc.Strings([]string{"apple", "cherry"}).Map(`
+		function(line)
+			return line, 1
+		end
+	`).Partition(2)
+	left.Join(right).Sort(OrderBy(2, false))
+	return fc
+}
+
+func TestExportPlan(t *testing.T) {
+	for _, tc := range []struct {
+		golden string
+		export func(*FlowContext, *bytes.Buffer) error
+	}{
+		{"plan.json", func(fc *FlowContext, b *bytes.Buffer) error { return fc.ToJSON(b) }},
+		{"plan.dot", func(fc *FlowContext, b *bytes.Buffer) error { return fc.ToDot(b) }},
+	} {
+		var got bytes.Buffer
+		if err := tc.export(exportTestFlow(), &got); err != nil {
+			t.Fatalf("export %s: %v", tc.golden, err)
+		}
+
+		golden := filepath.Join("testdata", tc.golden)
+		if *updateGolden {
+			if err := ioutil.WriteFile(golden, got.Bytes(), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		want, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatalf("read %s, run the test with -update to create it: %v", golden, err)
+		}
+		if !bytes.Equal(got.Bytes(), want) {
+			t.Errorf("%s differs from the golden file, got:\n%s", tc.golden, got.String())
+		}
+	}
+}
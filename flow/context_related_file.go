@@ -0,0 +1,69 @@
+package flow
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AddFile ships a local file to the working directory of the executors.
+// Lua scripts can require it, and Pipe() commands can read it.
+// A relative path inside the current directory keeps its directory
+// structure, so the same relative path works both locally and on agents.
+// Otherwise the file is placed by its base name.
+func (fc *FlowContext) AddFile(path string) *FlowContext {
+	fullPath, err := filepath.Abs(path)
+	if err != nil {
+		log.Fatalf("Failed to locate file %s: %v", path, err)
+	}
+	if fi, err := os.Stat(fullPath); err != nil || fi.IsDir() {
+		log.Fatalf("Failed to add file %s: not a regular file, %v", path, err)
+	}
+	name := filepath.Clean(path)
+	if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+		name = filepath.Base(name)
+	}
+	fc.addRelatedFile(fullPath, name)
+	return fc
+}
+
+// AddDir ships all files under a local directory to the executors.
+// The files are placed under the directory's base name in the
+// executors' working directory. E.g., with AddDir("lib"), Lua scripts
+// can require "lib.mymodule".
+func (fc *FlowContext) AddDir(dir string) *FlowContext {
+	fullDir, err := filepath.Abs(dir)
+	if err != nil {
+		log.Fatalf("Failed to locate directory %s: %v", dir, err)
+	}
+	err = filepath.Walk(fullDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(fullDir, path)
+		if err != nil {
+			return err
+		}
+		fc.addRelatedFile(path, filepath.Join(filepath.Base(fullDir), relativePath))
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to add directory %s: %v", dir, err)
+	}
+	return fc
+}
+
+func (fc *FlowContext) addRelatedFile(fullPath, name string) {
+	name = filepath.ToSlash(name)
+	for i, f := range fc.RelatedFiles {
+		if f.Name == name {
+			fc.RelatedFiles[i].FullPath = fullPath
+			return
+		}
+	}
+	fc.RelatedFiles = append(fc.RelatedFiles, RelatedFile{FullPath: fullPath, Name: name})
+}
@@ -0,0 +1,54 @@
+package flow
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRelatedFileNames(t *testing.T) {
+
+	dir := t.TempDir()
+	libDir := filepath.Join(dir, "lib")
+	if err := os.MkdirAll(filepath.Join(libDir, "text"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"lib/util.lua", "lib/text/split.lua", "words.txt"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fc := &FlowContext{}
+	// inside the current directory, keeping the relative path
+	fc.AddFile("context.go")
+	// outside the current directory, by the base name
+	fc.AddFile("../util/retry.go")
+	fc.AddFile(filepath.Join(dir, "words.txt"))
+	fc.AddDir(libDir)
+	// the same name is added once
+	fc.AddFile(filepath.Join(dir, "words.txt"))
+
+	expected := map[string]string{
+		"context.go":         "context.go",
+		"retry.go":           filepath.Join("..", "util", "retry.go"),
+		"words.txt":          filepath.Join(dir, "words.txt"),
+		"lib/util.lua":       filepath.Join(libDir, "util.lua"),
+		"lib/text/split.lua": filepath.Join(libDir, "text", "split.lua"),
+	}
+	if len(fc.RelatedFiles) != len(expected) {
+		t.Fatalf("expecting %d files, got %+v", len(expected), fc.RelatedFiles)
+	}
+	for _, f := range fc.RelatedFiles {
+		path, found := expected[f.Name]
+		if !found {
+			t.Errorf("unexpected file name %s", f.Name)
+			continue
+		}
+		fullPath, _ := filepath.Abs(path)
+		if f.FullPath != fullPath {
+			t.Errorf("file %s: expecting %s, got %s", f.Name, fullPath, f.FullPath)
+		}
+	}
+}
@@ -26,6 +26,30 @@ func PartitionSize(n int64) DasetsetHint {
 	}
 }
 
+// CPUs hints the number of cpu cores used by each partition of the
+// step producing the dataset, e.g., for multi-threaded scripts.
+func CPUs(n int) DasetsetHint {
+	return func(d *Dataset) {
+		d.Step.Meta.CPUCount = n
+	}
+}
+
+// CPULevel hints the step producing the dataset is cpu heavy, and should
+// run on agents with at least this "--executor.cpu.level".
+func CPULevel(level int) DasetsetHint {
+	return func(d *Dataset) {
+		d.Step.Meta.CPULevel = level
+	}
+}
+
+// DiskMB hints the disk space in MB needed by each partition of the
+// step producing the dataset, besides the dataset itself.
+func DiskMB(n int64) DasetsetHint {
+	return func(d *Dataset) {
+		d.Step.Meta.DiskMB = n
+	}
+}
+
 // OnDisk ensure the intermediate dataset are persisted to disk.
 // This allows executors to run not in parallel if executors are limited.
 func (d *Dataset) OnDisk(fn func(*Dataset) *Dataset) *Dataset {
@@ -130,7 +130,7 @@ func (fc *FlowContext) Channel(ch chan interface{}) (ret *Dataset) {
 			if err != nil {
 				return err
 			}
-			stats.Count++
+			stats.OutputRows++
 		}
 		return nil
 	}
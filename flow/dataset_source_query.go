@@ -3,10 +3,13 @@ package flow
 import (
 	"bytes"
 	"encoding/gob"
+	"io"
 	"log"
+	"sort"
 
 	"github.com/chrislusf/gleam/adapter"
 	"github.com/chrislusf/gleam/instruction"
+	"github.com/chrislusf/gleam/util"
 )
 
 // Query use the connection information specified via connectionId
@@ -26,19 +29,14 @@ func (fc *FlowContext) Query(connectionId string, query adapter.AdapterQuery) (r
 		log.Fatalf("Failed to split query for connection %v, %v: %v", connectionId, query, err)
 	}
 
-	var encoded [][]byte
-	for _, split := range splits {
-		d := encodeSplit(split)
-		// println("adding encoded data:", len(d), string(d))
-		encoded = append(encoded, d)
-	}
-
-	parallelCount := len(encoded)
+	parallelCount := len(splits)
 	if query.GetParallelLimit() > 0 && parallelCount > query.GetParallelLimit() {
 		parallelCount = query.GetParallelLimit()
 	}
 
-	data := fc.Bytes(encoded).RoundRobin(parallelCount)
+	partitions, partitionHosts := partitionSplitsByHost(splits, parallelCount)
+
+	data := fc.splitSource(partitions)
 
 	ret = fc.newNextDataset(parallelCount)
 	step := fc.AddOneToOneStep(data, ret)
@@ -46,10 +44,96 @@ func (fc *FlowContext) Query(connectionId string, query adapter.AdapterQuery) (r
 		ci.AdapterName,
 		connectionId,
 	))
+	for i, task := range step.Tasks {
+		task.PreferredHosts = partitionHosts[i]
+	}
 
 	return ret
 }
 
+// splitSource sends the encoded splits of each partition to its own shard.
+func (fc *FlowContext) splitSource(partitions [][][]byte) (ret *Dataset) {
+	ret = fc.newNextDataset(len(partitions))
+	step := fc.AddOneToAllStep(nil, ret)
+	step.IsOnDriverSide = true
+	step.Name = "Splits"
+	step.Function = func(readers []io.Reader, writers []io.Writer, stats *instruction.Stats) error {
+		for i, writer := range writers {
+			for _, data := range partitions[i] {
+				if err := util.WriteRow(writer, data); err != nil {
+					return err
+				}
+				stats.OutputRows++
+			}
+		}
+		return nil
+	}
+	return
+}
+
+// partitionSplitsByHost puts the splits preferring the same host into the
+// same partition when possible, and returns the encoded splits and the
+// preferred hosts of each partition, most preferred first.
+func partitionSplitsByHost(splits []adapter.Split, parallelCount int) (partitions [][][]byte, partitionHosts [][]string) {
+	sorted := make([]adapter.Split, len(splits))
+	copy(sorted, splits)
+	sort.Stable(byFirstPreferredHost(sorted))
+
+	for p := 0; p < parallelCount; p++ {
+		var encoded [][]byte
+		hostCounts := make(map[string]int)
+		for _, split := range sorted[p*len(sorted)/parallelCount : (p+1)*len(sorted)/parallelCount] {
+			encoded = append(encoded, encodeSplit(split))
+			for _, host := range adapter.PreferredHosts(split) {
+				hostCounts[host]++
+			}
+		}
+		partitions = append(partitions, encoded)
+		partitionHosts = append(partitionHosts, hostsByCount(hostCounts))
+	}
+	return
+}
+
+func hostsByCount(hostCounts map[string]int) []string {
+	var hosts []string
+	for host := range hostCounts {
+		hosts = append(hosts, host)
+	}
+	sort.Sort(byHostCount{hosts, hostCounts})
+	return hosts
+}
+
+type byFirstPreferredHost []adapter.Split
+
+func (s byFirstPreferredHost) Len() int      { return len(s) }
+func (s byFirstPreferredHost) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byFirstPreferredHost) Less(i, j int) bool {
+	return firstPreferredHost(s[i]) < firstPreferredHost(s[j])
+}
+
+func firstPreferredHost(split adapter.Split) string {
+	hosts := adapter.PreferredHosts(split)
+	if len(hosts) == 0 {
+		return ""
+	}
+	return hosts[0]
+}
+
+type byHostCount struct {
+	hosts  []string
+	counts map[string]int
+}
+
+func (s byHostCount) Len() int      { return len(s.hosts) }
+func (s byHostCount) Swap(i, j int) { s.hosts[i], s.hosts[j] = s.hosts[j], s.hosts[i] }
+func (s byHostCount) Less(i, j int) bool {
+	ci, cj := s.counts[s.hosts[i]], s.counts[s.hosts[j]]
+	if ci != cj {
+		return ci > cj
+	}
+	return s.hosts[i] < s.hosts[j]
+}
+
 func encodeSplit(split adapter.Split) []byte {
 	var network bytes.Buffer
 	enc := gob.NewEncoder(&network)
@@ -0,0 +1,220 @@
+package flow
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// ProgressText prints each report as a table, for people watching.
+	ProgressText = "text"
+	// ProgressLines prints each report as key=value lines, one line for
+	// the flow and one for each step, for CI logs and scripts, e.g.,
+	//
+	//	progress elapsed=80.0 splits=12/40 eta=186.7
+	//	progress elapsed=80.0 step=Map1 tasks=12/40 rows=1234567
+	ProgressLines = "lines"
+)
+
+// ProgressOption sets how the progress of a flow is printed while it runs.
+type ProgressOption struct {
+	Interval time.Duration // between reports, 10 seconds if not set
+	Quiet    bool          // print only the final report
+	Format   string        // ProgressText if empty, or ProgressLines
+	Writer   io.Writer     // os.Stderr if not set
+}
+
+// ShowProgress prints the progress of the flow periodically while it runs,
+// locally or distributed: the completed and total tasks and the rows
+// processed by each step, and the estimated time to finish, in proportion
+// to the source splits read so far.
+func (fc *FlowContext) ShowProgress(option ProgressOption) *FlowContext {
+	if option.Interval <= 0 {
+		option.Interval = 10 * time.Second
+	}
+	if option.Format == "" {
+		option.Format = ProgressText
+	}
+	if option.Writer == nil {
+		option.Writer = os.Stderr
+	}
+	fc.Progress = &option
+	return fc
+}
+
+// ProgressReporter prints the progress of a running flow.
+type ProgressReporter struct {
+	fc         *FlowContext
+	option     *ProgressOption
+	isTaskDone func(*Task) bool
+	startTime  time.Time
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+// StartProgress starts reporting the progress if the flow asks for it,
+// and returns nil otherwise. Flow runners tell whether a task is done,
+// and stop the reporter after the flow runs.
+func (fc *FlowContext) StartProgress(isTaskDone func(*Task) bool) *ProgressReporter {
+	if fc.Progress == nil {
+		return nil
+	}
+	r := &ProgressReporter{
+		fc:         fc,
+		option:     fc.Progress,
+		isTaskDone: isTaskDone,
+		startTime:  time.Now(),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go r.loop()
+	return r
+}
+
+// Stop prints the final report.
+func (r *ProgressReporter) Stop() {
+	if r == nil {
+		return
+	}
+	close(r.stop)
+	<-r.done
+}
+
+func (r *ProgressReporter) loop() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.option.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !r.option.Quiet {
+				r.report()
+			}
+		case <-r.stop:
+			r.report()
+			return
+		}
+	}
+}
+
+type stepProgress struct {
+	name      string
+	doneTasks int
+	tasks     int
+	rows      int64
+}
+
+func (r *ProgressReporter) report() {
+	elapsed := time.Since(r.startTime)
+	var steps []stepProgress
+	for _, step := range r.fc.Steps {
+		p := stepProgress{
+			name:  fmt.Sprintf("%s%d", step.Name, step.Id),
+			tasks: len(step.Tasks),
+			rows:  stepRows(step),
+		}
+		for _, task := range step.Tasks {
+			if r.isTaskDone(task) {
+				p.doneTasks++
+			}
+		}
+		steps = append(steps, p)
+	}
+	doneSplits, splits := r.splits()
+	eta := estimateRemaining(elapsed, doneSplits, splits)
+
+	w := r.option.Writer
+	if r.option.Format == ProgressLines {
+		etaSeconds := "-"
+		if eta >= 0 {
+			etaSeconds = fmt.Sprintf("%.1f", eta.Seconds())
+		}
+		fmt.Fprintf(w, "progress elapsed=%.1f splits=%d/%d eta=%s\n", elapsed.Seconds(), doneSplits, splits, etaSeconds)
+		for _, p := range steps {
+			fmt.Fprintf(w, "progress elapsed=%.1f step=%s tasks=%d/%d rows=%d\n", elapsed.Seconds(), p.name, p.doneTasks, p.tasks, p.rows)
+		}
+		return
+	}
+
+	etaText := "-"
+	if eta >= 0 {
+		etaText = (eta / time.Second * time.Second).String()
+	}
+	fmt.Fprintf(w, "progress after %v: %d/%d source splits, eta %s\n", elapsed/time.Second*time.Second, doneSplits, splits, etaText)
+	for _, p := range steps {
+		fmt.Fprintf(w, "  %-20s %5d/%-5d tasks %12d rows\n", p.name, p.doneTasks, p.tasks, p.rows)
+	}
+}
+
+// stepRows counts the rows written by the step, or read by the step if it
+// writes no dataset. The rows are counted on the dataset shards when the
+// flow runs locally, or else summed up from the stats of the tasks done.
+func stepRows(step *Step) (rows int64) {
+	var shards []*DatasetShard
+	if step.OutputDataset != nil {
+		shards = step.OutputDataset.Shards
+	} else {
+		for _, d := range step.InputDatasets {
+			shards = append(shards, d.Shards...)
+		}
+	}
+	for _, shard := range shards {
+		rows += atomic.LoadInt64(&shard.Rows)
+	}
+	if rows == 0 {
+		stats := step.Stats()
+		if step.OutputDataset != nil {
+			rows = stats.OutputRows
+		} else {
+			rows = stats.InputRows
+		}
+	}
+	return rows
+}
+
+// splits counts the tasks reading the sources, i.e., the tasks of the
+// steps reading only from steps without inputs, or of the steps without
+// inputs if no step reads them.
+func (r *ProgressReporter) splits() (done, total int) {
+	var readers, roots []*Step
+	for _, step := range r.fc.Steps {
+		if len(step.InputDatasets) == 0 {
+			roots = append(roots, step)
+			continue
+		}
+		readsSources := true
+		for _, d := range step.InputDatasets {
+			if d.Step == nil || len(d.Step.InputDatasets) > 0 {
+				readsSources = false
+			}
+		}
+		if readsSources {
+			readers = append(readers, step)
+		}
+	}
+	if len(readers) == 0 {
+		readers = roots
+	}
+	for _, step := range readers {
+		for _, task := range step.Tasks {
+			total++
+			if r.isTaskDone(task) {
+				done++
+			}
+		}
+	}
+	return done, total
+}
+
+// estimateRemaining assumes the rest of the flow takes time in proportion
+// to the source splits done, and returns -1 if not known yet.
+func estimateRemaining(elapsed time.Duration, done, total int) time.Duration {
+	if done == 0 || total == 0 {
+		return -1
+	}
+	return time.Duration(float64(elapsed) * float64(total-done) / float64(done))
+}
@@ -0,0 +1,108 @@
+package flow
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/gleam/instruction"
+)
+
+// progressTestFlow builds Source -> Map -> Sort, with 4, 2 and 1 tasks.
+func progressTestFlow() *FlowContext {
+	source := &Step{Id: 0, Name: "Source"}
+	source.OutputDataset = &Dataset{Step: source}
+	mapper := &Step{Id: 1, Name: "Map", InputDatasets: []*Dataset{source.OutputDataset}}
+	mapper.OutputDataset = &Dataset{Step: mapper}
+	sorter := &Step{Id: 2, Name: "Sort", InputDatasets: []*Dataset{mapper.OutputDataset}}
+
+	for step, count := range map[*Step]int{source: 4, mapper: 2, sorter: 1} {
+		for i := 0; i < count; i++ {
+			step.Tasks = append(step.Tasks, &Task{Id: i, Step: step})
+		}
+	}
+	return &FlowContext{Steps: []*Step{source, mapper, sorter}}
+}
+
+func doneTasks(tasks ...*Task) func(*Task) bool {
+	done := make(map[*Task]bool)
+	for _, task := range tasks {
+		done[task] = true
+	}
+	return func(task *Task) bool {
+		return done[task]
+	}
+}
+
+func TestEstimateRemaining(t *testing.T) {
+	if eta := estimateRemaining(time.Minute, 0, 4); eta != -1 {
+		t.Errorf("expecting unknown eta with no splits done, got %v", eta)
+	}
+	if eta := estimateRemaining(time.Minute, 0, 0); eta != -1 {
+		t.Errorf("expecting unknown eta with no splits, got %v", eta)
+	}
+	if eta := estimateRemaining(time.Minute, 1, 4); eta != 3*time.Minute {
+		t.Errorf("expecting 3m for 1/4 splits done in 1m, got %v", eta)
+	}
+	if eta := estimateRemaining(time.Minute, 4, 4); eta != 0 {
+		t.Errorf("expecting no time left with all splits done, got %v", eta)
+	}
+}
+
+func TestProgressSplits(t *testing.T) {
+	fc := progressTestFlow()
+	mapper := fc.Steps[1]
+	r := &ProgressReporter{fc: fc, isTaskDone: doneTasks(mapper.Tasks[0], fc.Steps[0].Tasks[0])}
+	if done, total := r.splits(); done != 1 || total != 2 {
+		t.Errorf("expecting the splits read by the Map tasks 1/2, got %d/%d", done, total)
+	}
+
+	// without steps reading the sources, the sources are the splits
+	source := &Step{Id: 0, Name: "Source", Tasks: []*Task{{Id: 0}, {Id: 1}, {Id: 2}}}
+	r = &ProgressReporter{fc: &FlowContext{Steps: []*Step{source}}, isTaskDone: doneTasks(source.Tasks[2])}
+	if done, total := r.splits(); done != 1 || total != 3 {
+		t.Errorf("expecting the source splits 1/3, got %d/%d", done, total)
+	}
+}
+
+func TestProgressLines(t *testing.T) {
+	fc := progressTestFlow()
+	mapper := fc.Steps[1]
+	mapper.Tasks[0].SetRun(time.Unix(100, 0), time.Unix(101, 0), &instruction.Stats{OutputRows: 1234})
+
+	var out bytes.Buffer
+	r := &ProgressReporter{
+		fc:         fc,
+		option:     &ProgressOption{Format: ProgressLines, Writer: &out},
+		isTaskDone: doneTasks(mapper.Tasks[0]),
+		startTime:  time.Now().Add(-80 * time.Second),
+	}
+	r.report()
+
+	// the elapsed time may pass 80s a little by the time of the report
+	report := regexp.MustCompile(`=80\.\d`).ReplaceAllString(out.String(), "=80.0")
+	lines := strings.Split(strings.TrimSpace(report), "\n")
+	expected := []string{
+		"progress elapsed=80.0 splits=1/2 eta=80.0",
+		"progress elapsed=80.0 step=Source0 tasks=0/4 rows=0",
+		"progress elapsed=80.0 step=Map1 tasks=1/2 rows=1234",
+		"progress elapsed=80.0 step=Sort2 tasks=0/1 rows=0",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("expecting %d lines, got %q", len(expected), out.String())
+	}
+	for i, line := range lines {
+		if line != expected[i] {
+			t.Errorf("line %d: expecting %q, got %q", i, expected[i], line)
+		}
+	}
+
+	out.Reset()
+	r.isTaskDone = doneTasks()
+	r.report()
+	if first := strings.SplitN(out.String(), "\n", 2)[0]; !strings.HasSuffix(first, "splits=0/2 eta=-") {
+		t.Errorf("expecting an unknown eta with no splits done, got %q", first)
+	}
+}
@@ -2,8 +2,10 @@ package flow
 
 import (
 	"io"
+	"log"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/chrislusf/gleam/util"
@@ -25,10 +27,23 @@ var (
 )
 
 func (r *localDriver) RunFlowContext(fc *FlowContext) {
+	progress := fc.StartProgress(func(task *Task) bool {
+		_, stop, _ := task.GetRun()
+		return !stop.IsZero()
+	})
+
 	var wg sync.WaitGroup
 	wg.Add(1)
 	r.RunFlowContextAsync(&wg, fc)
 	wg.Wait()
+
+	progress.Stop()
+
+	if fc.TraceFile != "" {
+		if err := fc.LocalTrace().WriteFile(fc.TraceFile); err != nil {
+			log.Printf("Failed to write trace to %s: %v", fc.TraceFile, err)
+		}
+	}
 }
 
 func (r *localDriver) RunFlowContextAsync(wg *sync.WaitGroup, fc *FlowContext) {
@@ -76,10 +91,9 @@ func (r *localDriver) runDatasetShard(wg *sync.WaitGroup, shard *DatasetShard) {
 	}
 
 	util.BufWrites(writers, func(writers []io.Writer) {
-		w := io.MultiWriter(writers...)
-		n, _ := io.Copy(w, shard.IncomingChan.Reader)
-		// println("shard", shard.Name(), "moved", n, "bytes.")
-		shard.Counter = n
+		w := &shardCounter{Writer: io.MultiWriter(writers...), shard: shard}
+		io.Copy(w, shard.IncomingChan.Reader)
+		// println("shard", shard.Name(), "moved", shard.Counter, "bytes.")
 		shard.CloseTime = time.Now()
 	})
 
@@ -88,6 +102,21 @@ func (r *localDriver) runDatasetShard(wg *sync.WaitGroup, shard *DatasetShard) {
 	}
 }
 
+// shardCounter counts the rows and bytes moved through the shard, so the
+// progress can be followed while the flow runs.
+type shardCounter struct {
+	io.Writer
+	shard    *DatasetShard
+	messages util.MessageCounter
+}
+
+func (w *shardCounter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	atomic.AddInt64(&w.shard.Counter, int64(n))
+	atomic.AddInt64(&w.shard.Rows, w.messages.Count(p[:n]))
+	return n, err
+}
+
 func (r *localDriver) runStep(wg *sync.WaitGroup, step *Step) {
 	defer wg.Done()
 
@@ -129,7 +158,10 @@ func (r *localDriver) runTask(wg *sync.WaitGroup, task *Task) {
 		writer := task.OutputShards[0].IncomingChan.Writer
 		wg.Add(1)
 		prevIsPipe := task.InputShards[0].Dataset.Step.IsPipe
+		start := time.Now()
+		task.SetRun(start, time.Time{}, nil)
 		util.Execute(wg, task.Step.Name, execCommand, reader, writer, prevIsPipe, task.Step.IsPipe, true, os.Stderr)
+		task.SetRun(start, time.Now(), nil)
 	} else {
 		println("network type:", task.Step.NetworkType)
 	}
@@ -2,6 +2,7 @@ package flow
 
 import (
 	"fmt"
+	"sync/atomic"
 )
 
 func (fc *FlowContext) OnInterrupt() {
@@ -29,8 +30,8 @@ func (fc *FlowContext) OnInterrupt() {
 
 func printShardStatus(shard *DatasetShard) {
 	if shard.Closed() {
-		fmt.Printf("     shard:%d time:%v completed %d\n", shard.Id, shard.TimeTaken(), shard.Counter)
+		fmt.Printf("     shard:%d time:%v completed %d\n", shard.Id, shard.TimeTaken(), atomic.LoadInt64(&shard.Counter))
 	} else {
-		fmt.Printf("     shard:%d time:%v processed %d\n", shard.Id, shard.TimeTaken(), shard.Counter)
+		fmt.Printf("     shard:%d time:%v processed %d\n", shard.Id, shard.TimeTaken(), atomic.LoadInt64(&shard.Counter))
 	}
 }
@@ -0,0 +1,24 @@
+package flow
+
+import (
+	"github.com/chrislusf/gleam/instruction"
+)
+
+// Stats sums up the stats of the tasks of the step which have run.
+func (step *Step) Stats() (stats instruction.Stats) {
+	for _, task := range step.Tasks {
+		if _, _, taskStats := task.GetRun(); taskStats != nil {
+			stats.Add(taskStats)
+		}
+	}
+	return
+}
+
+// Stats returns the rows and bytes written into the dataset.
+func (d *Dataset) Stats() (rows, bytes int64) {
+	if d.Step == nil {
+		return 0, 0
+	}
+	stats := d.Step.Stats()
+	return stats.OutputRows, stats.OutputBytes
+}
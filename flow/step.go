@@ -3,6 +3,7 @@ package flow
 import (
 	"io"
 	"log"
+	"time"
 
 	"github.com/chrislusf/gleam/instruction"
 	"github.com/chrislusf/gleam/script"
@@ -50,8 +51,13 @@ func (step *Step) RunFunction(task *Task) error {
 		}
 	}()
 
-	task.Stats = &instruction.Stats{}
-	err := task.Step.Function(readers, writers, task.Stats)
+	stats := &instruction.Stats{}
+	start := time.Now()
+	task.SetRun(start, time.Time{}, nil)
+	err := task.Step.Function(readers, writers, stats)
+	stop := time.Now()
+	stats.WallTime = stop.Sub(start)
+	task.SetRun(start, stop, stats)
 	if err != nil {
 		log.Printf("Failed to run task %s-%d: %v\n", task.Step.Name, task.Id, err)
 	}
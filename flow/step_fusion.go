@@ -0,0 +1,50 @@
+package flow
+
+// FusedAncestor returns the first of the steps fused with this step, which
+// run together as one task group without writing the datasets in between.
+// It returns the step itself if the step is not fused with its input step.
+func (step *Step) FusedAncestor() *Step {
+	current := step
+	taskCount := len(current.Tasks)
+
+	for taskCount == len(current.Tasks) {
+		if len(current.InputDatasets) > 1 {
+			// more than 2 dataset inputs
+			break
+		}
+		if len(current.InputDatasets) == 0 {
+			// no dataset inputs
+			break
+		}
+		if !isMergeableDataset(current.InputDatasets[0], taskCount) {
+			break
+		}
+		if (!current.IsOnDriverSide && current.InputDatasets[0].Step.IsOnDriverSide) ||
+			(current.IsOnDriverSide && !current.InputDatasets[0].Step.IsOnDriverSide) {
+			break
+		}
+
+		current = current.InputDatasets[0].Step
+		taskCount = len(current.Tasks)
+
+	}
+	return current
+}
+
+func isMergeableDataset(ds *Dataset, taskCount int) bool {
+	if taskCount != len(ds.Shards) {
+		return false
+	}
+	if taskCount != len(ds.Step.Tasks) {
+		return false
+	}
+	if len(ds.ReadingSteps) > 1 {
+		return false
+	}
+	for _, shard := range ds.Shards {
+		if len(shard.ReadingTasks) > 1 {
+			return false
+		}
+	}
+	return true
+}
@@ -1,6 +1,7 @@
 package flow
 
 import (
+	"fmt"
 	"io"
 	"sync"
 	"time"
@@ -21,6 +22,24 @@ const (
 	MergeTwoShardToOneShard
 )
 
+func (t NetworkType) String() string {
+	switch t {
+	case OneShardToOneShard:
+		return "OneShardToOneShard"
+	case OneShardToAllShard:
+		return "OneShardToAllShard"
+	case AllShardToOneShard:
+		return "AllShardToOneShard"
+	case OneShardToEveryNShard:
+		return "OneShardToEveryNShard"
+	case LinkedNShardToOneShard:
+		return "LinkedNShardToOneShard"
+	case MergeTwoShardToOneShard:
+		return "MergeTwoShardToOneShard"
+	}
+	return fmt.Sprintf("NetworkType(%d)", int(t))
+}
+
 type DatasetShardStatus int
 
 const (
@@ -32,6 +51,24 @@ const (
 	Successful
 )
 
+func (s DatasetShardStatus) String() string {
+	switch s {
+	case Untouched:
+		return "untouched"
+	case LocationAssigned:
+		return "location assigned"
+	case InProgress:
+		return "in progress"
+	case InRetry:
+		return "in retry"
+	case Failed:
+		return "failed"
+	case Successful:
+		return "successful"
+	}
+	return fmt.Sprintf("DatasetShardStatus(%d)", int(s))
+}
+
 type ModeIO int
 
 const (
@@ -55,6 +92,9 @@ type DasetsetShardMetadata struct {
 type StepMetadata struct {
 	IsRestartable bool
 	IsIdempotent  bool
+	CPUCount      int   // cpu cores used by each task, 0 means 1
+	CPULevel      int   // minimum relative computing power of the cpu cores
+	DiskMB        int64 // disk space needed by each task
 }
 
 type FlowContext struct {
@@ -64,6 +104,15 @@ type FlowContext struct {
 	Steps          []*Step
 	Datasets       []*Dataset
 	HashCode       uint32
+	RelatedFiles   []RelatedFile
+	TraceFile      string          // to write the timeline of the run
+	Progress       *ProgressOption // to print the progress while running
+}
+
+// RelatedFile is a local file shipped to the executors' working directory.
+type RelatedFile struct {
+	FullPath string // path on the driver
+	Name     string // slash separated path relative to the executor's working directory
 }
 
 type Dataset struct {
@@ -84,7 +133,8 @@ type DatasetShard struct {
 	ReadingTasks  []*Task
 	IncomingChan  *util.Piper
 	OutgoingChans []*util.Piper
-	Counter       int64
+	Counter       int64 // bytes written into the shard, accessed atomically while running
+	Rows          int64 // rows written into the shard, accessed atomically while running
 	ReadyTime     time.Time
 	CloseTime     time.Time
 	Meta          *DasetsetShardMetadata
@@ -110,12 +160,16 @@ type Step struct {
 }
 
 type Task struct {
-	Id           int
-	Step         *Step
-	InputShards  []*DatasetShard
-	InputChans   []*util.Piper // task specific input chans. InputShard may have multiple reading tasks
-	OutputShards []*DatasetShard
-	Stats        *instruction.Stats
+	Id             int
+	Step           *Step
+	InputShards    []*DatasetShard
+	InputChans     []*util.Piper // task specific input chans. InputShard may have multiple reading tasks
+	OutputShards   []*DatasetShard
+	Stats          *instruction.Stats
+	PreferredHosts []string // hosts with local access to the task's input, e.g., input splits
+	StartTime      time.Time
+	StopTime       time.Time
+	runLock        sync.Mutex // guards Stats, StartTime and StopTime, read while running
 }
 
 type RunLocked struct {
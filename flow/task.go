@@ -0,0 +1,23 @@
+package flow
+
+import (
+	"time"
+
+	"github.com/chrislusf/gleam/instruction"
+)
+
+// SetRun records when the task runs, and the stats of the run once done.
+// The stats should not be changed afterwards.
+func (task *Task) SetRun(start, stop time.Time, stats *instruction.Stats) {
+	task.runLock.Lock()
+	defer task.runLock.Unlock()
+	task.StartTime, task.StopTime, task.Stats = start, stop, stats
+}
+
+// GetRun returns when the task ran, and the stats of the run, which are
+// nil until the task is done.
+func (task *Task) GetRun() (start, stop time.Time, stats *instruction.Stats) {
+	task.runLock.Lock()
+	defer task.runLock.Unlock()
+	return task.StartTime, task.StopTime, task.Stats
+}
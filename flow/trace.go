@@ -0,0 +1,176 @@
+package flow
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sync/atomic"
+	"time"
+)
+
+// Trace is the timeline of a flow run in Chrome trace event format,
+// which can be opened in chrome://tracing or https://ui.perfetto.dev.
+// Each process of the trace is a step or step group, or a dataset, and
+// each of its threads is one task, task group or dataset shard.
+type Trace struct {
+	Events          []TraceEvent `json:"traceEvents"`
+	DisplayTimeUnit string       `json:"displayTimeUnit"`
+	threads         map[int]int  // the last thread id of each process
+}
+
+type TraceEvent struct {
+	Name     string                 `json:"name"`
+	Category string                 `json:"cat,omitempty"`
+	Phase    string                 `json:"ph"`
+	Ts       int64                  `json:"ts"` // in microseconds
+	Dur      int64                  `json:"dur,omitempty"`
+	Pid      int                    `json:"pid"`
+	Tid      int                    `json:"tid"`
+	Args     map[string]interface{} `json:"args,omitempty"`
+}
+
+func NewTrace() *Trace {
+	return &Trace{
+		Events:          []TraceEvent{},
+		DisplayTimeUnit: "ms",
+		threads:         make(map[int]int),
+	}
+}
+
+// AddProcess names a process of the trace.
+func (t *Trace) AddProcess(pid int, name string) {
+	t.Events = append(t.Events, TraceEvent{
+		Name: "process_name", Phase: "M", Pid: pid,
+		Args: map[string]interface{}{"name": name},
+	})
+	t.Events = append(t.Events, TraceEvent{
+		Name: "process_sort_index", Phase: "M", Pid: pid,
+		Args: map[string]interface{}{"sort_index": pid},
+	})
+}
+
+// AddThread adds a named thread to the process, and returns its id.
+// The threads are shown in the order they are added.
+func (t *Trace) AddThread(pid int, name string) (tid int) {
+	t.threads[pid]++
+	tid = t.threads[pid]
+	t.Events = append(t.Events, TraceEvent{
+		Name: "thread_name", Phase: "M", Pid: pid, Tid: tid,
+		Args: map[string]interface{}{"name": name},
+	})
+	t.Events = append(t.Events, TraceEvent{
+		Name: "thread_sort_index", Phase: "M", Pid: pid, Tid: tid,
+		Args: map[string]interface{}{"sort_index": tid},
+	})
+	return tid
+}
+
+// AddSpan adds a complete event from start to stop. Spans not started are
+// skipped. Spans not stopped, e.g., when the flow is interrupted, last
+// until now.
+func (t *Trace) AddSpan(pid, tid int, category, name string, start, stop time.Time, args map[string]interface{}) {
+	if start.IsZero() {
+		return
+	}
+	if stop.IsZero() || stop.Before(start) {
+		stop = time.Now()
+	}
+	t.Events = append(t.Events, TraceEvent{
+		Name:     name,
+		Category: category,
+		Phase:    "X",
+		Ts:       start.UnixNano() / int64(time.Microsecond),
+		Dur:      stop.Sub(start).Nanoseconds() / int64(time.Microsecond),
+		Pid:      pid,
+		Tid:      tid,
+		Args:     args,
+	})
+}
+
+// WriteFile writes the trace as JSON.
+func (t *Trace) WriteFile(fileName string) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, data, 0644)
+}
+
+// TraceTo writes the timeline of the tasks, step groups and network channels
+// to the file after the flow runs, in Chrome trace
+// event format.
+func (fc *FlowContext) TraceTo(fileName string) *FlowContext {
+	fc.TraceFile = fileName
+	return fc
+}
+
+// LocalTrace returns the timeline of running the flow locally. Each step
+// is a process, with the step itself and its tasks as threads, and each
+// dataset is a process with its shards as threads.
+func (fc *FlowContext) LocalTrace() *Trace {
+	t := NewTrace()
+	for _, step := range fc.Steps {
+		pid := step.Id + 1
+		t.AddProcess(pid, fmt.Sprintf("step %s%d", step.Name, step.Id))
+		tid := t.AddThread(pid, "step")
+		start, stop := step.TaskTimes()
+		t.AddSpan(pid, tid, "step", fmt.Sprintf("%s%d", step.Name, step.Id), start, stop, map[string]interface{}{
+			"tasks":       len(step.Tasks),
+			"networkType": step.NetworkType.String(),
+		})
+		for _, task := range step.Tasks {
+			tid := t.AddThread(pid, fmt.Sprintf("task %d", task.Id))
+			t.AddTask(pid, tid, task)
+		}
+	}
+	for _, d := range fc.Datasets {
+		pid := len(fc.Steps) + d.Id + 1
+		t.AddProcess(pid, fmt.Sprintf("dataset d%d", d.Id))
+		for _, shard := range d.Shards {
+			tid := t.AddThread(pid, fmt.Sprintf("shard %d", shard.Id))
+			t.AddSpan(pid, tid, "channel", shard.Name(), shard.ReadyTime, shard.CloseTime, map[string]interface{}{
+				"bytes": atomic.LoadInt64(&shard.Counter),
+			})
+		}
+	}
+	return t
+}
+
+// AddTask adds the span of the task, with its stats, and its command
+// line if the task runs a script.
+func (t *Trace) AddTask(pid, tid int, task *Task) {
+	start, stop, stats := task.GetRun()
+	args := map[string]interface{}{}
+	if stats != nil {
+		args["inputRows"] = stats.InputRows
+		args["inputBytes"] = stats.InputBytes
+		args["outputRows"] = stats.OutputRows
+		args["outputBytes"] = stats.OutputBytes
+		args["spillBytes"] = stats.SpillBytes
+	}
+	if task.Step.Function == nil && (task.Step.Script != nil || task.Step.Command != nil) {
+		args["command"] = commandLine(task.Step.GetScriptCommand())
+	}
+	t.AddSpan(pid, tid, "task", fmt.Sprintf("%s%d.%d", task.Step.Name, task.Step.Id, task.Id), start, stop, args)
+}
+
+// TaskTimes returns when the first task of the step started, and when the
+// last task stopped, or zero time if any task has not stopped.
+func (step *Step) TaskTimes() (start, stop time.Time) {
+	stopped := true
+	for _, task := range step.Tasks {
+		taskStart, taskStop, _ := task.GetRun()
+		if !taskStart.IsZero() && (start.IsZero() || taskStart.Before(start)) {
+			start = taskStart
+		}
+		if taskStop.IsZero() {
+			stopped = false
+		} else if taskStop.After(stop) {
+			stop = taskStop
+		}
+	}
+	if !stopped {
+		stop = time.Time{}
+	}
+	return
+}
@@ -0,0 +1,138 @@
+package flow
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/gleam/instruction"
+	"github.com/chrislusf/gleam/script"
+)
+
+func spansOf(trace *Trace) (spans []TraceEvent) {
+	for _, e := range trace.Events {
+		if e.Phase == "X" {
+			spans = append(spans, e)
+		}
+	}
+	return spans
+}
+
+func TestTraceSpans(t *testing.T) {
+	trace := NewTrace()
+	trace.AddProcess(1, "step Map1")
+	if tid := trace.AddThread(1, "task 0"); tid != 1 {
+		t.Errorf("expecting the first thread 1, got %d", tid)
+	}
+	if tid := trace.AddThread(1, "task 1"); tid != 2 {
+		t.Errorf("expecting the second thread 2, got %d", tid)
+	}
+	if tid := trace.AddThread(2, "shard 0"); tid != 1 {
+		t.Errorf("expecting threads counted per process, got %d", tid)
+	}
+
+	start := time.Unix(100, 0)
+	trace.AddSpan(1, 1, "task", "done", start, start.Add(1500*time.Microsecond), nil)
+	trace.AddSpan(1, 2, "task", "not started", time.Time{}, time.Time{}, nil)
+	trace.AddSpan(1, 2, "task", "not stopped", start, time.Time{}, nil)
+
+	spans := spansOf(trace)
+	if len(spans) != 2 {
+		t.Fatalf("expecting the span not started skipped, got %+v", spans)
+	}
+	if s := spans[0]; s.Name != "done" || s.Ts != 100*1000*1000 || s.Dur != 1500 || s.Pid != 1 || s.Tid != 1 {
+		t.Errorf("unexpected span %+v", s)
+	}
+	if s := spans[1]; s.Name != "not stopped" || time.Duration(s.Dur)*time.Microsecond < time.Since(start)-time.Minute {
+		t.Errorf("expecting the span not stopped to last until now, got %+v", s)
+	}
+}
+
+func TestTraceAddTask(t *testing.T) {
+	step := &Step{
+		Id:      1,
+		Name:    "Pipe",
+		Command: &script.Command{Path: "sh", Args: []string{"-c", "sort -u"}},
+	}
+	task := &Task{Id: 2, Step: step}
+	start := time.Unix(100, 0)
+	task.SetRun(start, start.Add(time.Second), &instruction.Stats{InputRows: 3, OutputRows: 2})
+
+	trace := NewTrace()
+	trace.AddTask(1, 1, task)
+
+	spans := spansOf(trace)
+	if len(spans) != 1 {
+		t.Fatalf("expecting one span for the task, got %+v", spans)
+	}
+	s := spans[0]
+	if s.Name != "Pipe1.2" || s.Category != "task" || s.Dur != int64(time.Second/time.Microsecond) {
+		t.Errorf("unexpected task span %+v", s)
+	}
+	if s.Args["inputRows"] != int64(3) || s.Args["outputRows"] != int64(2) || s.Args["command"] != `sh -c "sort -u"` {
+		t.Errorf("unexpected task args %+v", s.Args)
+	}
+}
+
+func TestLocalTrace(t *testing.T) {
+	fc := New()
+	fc.Strings([]string{"a", "b"}).Map(`
+		function(line)
+			return line
+		end
+	`)
+
+	start := time.Unix(100, 0)
+	for i, step := range fc.Steps {
+		for _, task := range step.Tasks {
+			task.SetRun(start.Add(time.Duration(i)*time.Second), start.Add(time.Duration(i+1)*time.Second), nil)
+		}
+	}
+	shard := fc.Datasets[0].Shards[0]
+	shard.ReadyTime, shard.CloseTime, shard.Counter = start, start.Add(time.Second), 42
+
+	trace := fc.LocalTrace()
+
+	processes := make(map[int]string)
+	for _, e := range trace.Events {
+		if e.Name == "process_name" {
+			processes[e.Pid] = e.Args["name"].(string)
+		}
+	}
+	if processes[1] != "step Channel0" || processes[2] != "step Map1" || processes[3] != "dataset d0" {
+		t.Errorf("unexpected processes %v", processes)
+	}
+
+	var steps, tasks, channels int
+	for _, s := range spansOf(trace) {
+		switch s.Category {
+		case "step":
+			steps++
+		case "task":
+			tasks++
+		case "channel":
+			channels++
+			if s.Name == shard.Name() && s.Args["bytes"] != int64(42) {
+				t.Errorf("unexpected shard span %+v", s)
+			}
+		}
+	}
+	if steps != 2 || tasks != 2 || channels != 1 {
+		t.Errorf("expecting 2 steps, 2 tasks and 1 channel, got %d, %d and %d", steps, tasks, channels)
+	}
+
+	fileName := filepath.Join(t.TempDir(), "trace.json")
+	if err := trace.WriteFile(fileName); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written Trace
+	if err := json.Unmarshal(data, &written); err != nil || len(written.Events) != len(trace.Events) {
+		t.Errorf("unexpected trace file, %d events: %v", len(written.Events), err)
+	}
+}
@@ -2,6 +2,7 @@ package instruction
 
 import (
 	"io"
+	"time"
 
 	"github.com/chrislusf/gleam/pb"
 )
@@ -22,8 +23,18 @@ type OrderBy struct {
 	Order Order // Ascending or Descending
 }
 
+// Stats are the metrics of running an instruction for a task.
+// The executor counts the rows and bytes passing through the instruction,
+// and the instruction adds the data it spills to disk to SpillBytes
+// atomically, since the stats can be read while it is running.
 type Stats struct {
-	Count int
+	InputRows   int64
+	InputBytes  int64
+	OutputRows  int64
+	OutputBytes int64
+	WallTime    time.Duration
+	CPUTime     time.Duration
+	SpillBytes  int64
 }
 
 type Instruction interface {
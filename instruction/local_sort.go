@@ -59,6 +59,7 @@ func (b *LocalSort) GetMemoryCostInMB(partitionSize int64) int64 {
 	return int64(math.Max(float64(b.memoryInMB), float64(partitionSize)))
 }
 
+// DoLocalSort sorts the rows in memory, so it spills nothing to disk.
 func DoLocalSort(reader io.Reader, writer io.Writer, orderBys []OrderBy) error {
 	var kvs []interface{}
 	indexes := getIndexesFromOrderBys(orderBys)
@@ -0,0 +1,50 @@
+package instruction
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/chrislusf/gleam/util"
+)
+
+func TestLocalSort(t *testing.T) {
+
+	var input bytes.Buffer
+	for _, x := range []int{5, 3, 9, 1, 7, 2, 8, 6, 4, 0} {
+		if err := util.WriteRow(&input, x, "value"); err != nil {
+			t.Fatalf("write row: %v", err)
+		}
+	}
+
+	stats := &Stats{}
+	var output bytes.Buffer
+	sort := NewLocalSort([]OrderBy{{Index: 1, Order: Ascending}}, 1)
+	if err := sort.Function()([]io.Reader{&input}, []io.Writer{&output}, stats); err != nil {
+		t.Fatalf("sort: %v", err)
+	}
+
+	var sorted []int
+	util.ProcessMessage(&output, func(message []byte) error {
+		row, err := util.DecodeRow(message)
+		if err != nil {
+			return err
+		}
+		sorted = append(sorted, int(row[0].(uint64)))
+		return nil
+	})
+	if len(sorted) != 10 {
+		t.Fatalf("expecting 10 sorted rows, got %v", sorted)
+	}
+	for i, x := range sorted {
+		if x != i {
+			t.Errorf("unexpected order: %v", sorted)
+			break
+		}
+	}
+
+	// the rows are sorted in memory
+	if stats.SpillBytes != 0 {
+		t.Errorf("expecting no spill, got %d bytes", stats.SpillBytes)
+	}
+}
@@ -0,0 +1,42 @@
+package instruction
+
+import (
+	"time"
+
+	"github.com/chrislusf/gleam/pb"
+)
+
+// Add sums up the stats of another task.
+func (s *Stats) Add(o *Stats) {
+	s.InputRows += o.InputRows
+	s.InputBytes += o.InputBytes
+	s.OutputRows += o.OutputRows
+	s.OutputBytes += o.OutputBytes
+	s.WallTime += o.WallTime
+	s.CPUTime += o.CPUTime
+	s.SpillBytes += o.SpillBytes
+}
+
+func (s *Stats) ToProto() *pb.InstructionStat {
+	return &pb.InstructionStat{
+		InputRows:   s.InputRows,
+		InputBytes:  s.InputBytes,
+		OutputRows:  s.OutputRows,
+		OutputBytes: s.OutputBytes,
+		WallTime:    int64(s.WallTime),
+		CpuTime:     int64(s.CPUTime),
+		SpillBytes:  s.SpillBytes,
+	}
+}
+
+func StatsFromProto(p *pb.InstructionStat) *Stats {
+	return &Stats{
+		InputRows:   p.GetInputRows(),
+		InputBytes:  p.GetInputBytes(),
+		OutputRows:  p.GetOutputRows(),
+		OutputBytes: p.GetOutputBytes(),
+		WallTime:    time.Duration(p.GetWallTime()),
+		CPUTime:     time.Duration(p.GetCpuTime()),
+		SpillBytes:  p.GetSpillBytes(),
+	}
+}
@@ -10,24 +10,63 @@ It is generated from these files:
 
 It has these top-level messages:
 	ComputeRequest
+	HostPreference
 	ComputeResource
 	DataResource
 	Location
 	Allocation
 	AllocationResult
+	DrainAgentRequest
+	DrainAgentResponse
+	TopologyRequest
+	TopologyResponse
+	DataCenterStatus
+	RackStatus
+	AgentStatus
+	ListFlowsRequest
+	ListFlowsResponse
+	FlowStatus
+	FlowProgress
+	StepProgress
+	DatasetProgress
+	DatasetShardProgress
+	TaskGroupProgress
+	FlowProgressResponse
+	ListHistoryRequest
+	ListHistoryResponse
+	GetHistoryRequest
+	KillFlowRequest
+	KillFlowResponse
+	LeaseRequest
+	LeaseResponse
 	Heartbeat
+	AgentLoad
+	DiskUsage
+	QueueAllocation
+	HeartbeatResponse
+	PreemptRequest
 	Empty
 	DataLocation
 	ControlMessage
 	NetChan
 	StartResponse
 	StopResponse
+	DrainRequest
+	DrainResponse
+	ListDatasetShardsRequest
+	ListDatasetShardsResponse
+	DatasetShardStatus
+	ReadLogRequest
+	ReadLogResponse
+	ExecutorLog
 	GetStatusRequest
 	ChannelStatus
 	GetStatusResponse
 	DeleteDatasetShardRequest
 	DeleteDatasetShardResponse
 	LocalStatusReportRequest
+	Profile
+	InstructionStat
 	LocalStatusReportResponse
 	WriteRequest
 	ReadRequest
@@ -79,6 +118,12 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type ComputeRequest struct {
 	DataCenter       string             `protobuf:"bytes,1,opt,name=data_center,json=dataCenter" json:"data_center,omitempty"`
 	ComputeResources []*ComputeResource `protobuf:"bytes,2,rep,name=compute_resources,json=computeResources" json:"compute_resources,omitempty"`
+	Queue            string             `protobuf:"bytes,3,opt,name=queue" json:"queue,omitempty"`
+	User             string             `protobuf:"bytes,4,opt,name=user" json:"user,omitempty"`
+	HostPreferences  []*HostPreference  `protobuf:"bytes,5,rep,name=hostPreferences" json:"hostPreferences,omitempty"`
+	FlowHashCode     uint32             `protobuf:"varint,6,opt,name=flowHashCode" json:"flowHashCode,omitempty"`
+	FlowName         string             `protobuf:"bytes,7,opt,name=flowName" json:"flowName,omitempty"`
+	Driver           string             `protobuf:"bytes,8,opt,name=driver" json:"driver,omitempty"`
 }
 
 func (m *ComputeRequest) Reset()                    { *m = ComputeRequest{} }
@@ -100,6 +145,65 @@ func (m *ComputeRequest) GetComputeResources() []*ComputeResource {
 	return nil
 }
 
+func (m *ComputeRequest) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *ComputeRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *ComputeRequest) GetHostPreferences() []*HostPreference {
+	if m != nil {
+		return m.HostPreferences
+	}
+	return nil
+}
+
+func (m *ComputeRequest) GetFlowHashCode() uint32 {
+	if m != nil {
+		return m.FlowHashCode
+	}
+	return 0
+}
+
+func (m *ComputeRequest) GetFlowName() string {
+	if m != nil {
+		return m.FlowName
+	}
+	return ""
+}
+
+func (m *ComputeRequest) GetDriver() string {
+	if m != nil {
+		return m.Driver
+	}
+	return ""
+}
+
+// hosts with local access to the inputs, most preferred first
+type HostPreference struct {
+	Hosts []string `protobuf:"bytes,1,rep,name=hosts" json:"hosts,omitempty"`
+}
+
+func (m *HostPreference) Reset()                    { *m = HostPreference{} }
+func (m *HostPreference) String() string            { return proto.CompactTextString(m) }
+func (*HostPreference) ProtoMessage()               {}
+func (*HostPreference) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *HostPreference) GetHosts() []string {
+	if m != nil {
+		return m.Hosts
+	}
+	return nil
+}
+
 type ComputeResource struct {
 	CpuCount int32 `protobuf:"varint,1,opt,name=cpu_count,json=cpuCount" json:"cpu_count,omitempty"`
 	CpuLevel int32 `protobuf:"varint,2,opt,name=cpu_level,json=cpuLevel" json:"cpu_level,omitempty"`
@@ -112,7 +216,7 @@ type ComputeResource struct {
 func (m *ComputeResource) Reset()                    { *m = ComputeResource{} }
 func (m *ComputeResource) String() string            { return proto.CompactTextString(m) }
 func (*ComputeResource) ProtoMessage()               {}
-func (*ComputeResource) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (*ComputeResource) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
 
 func (m *ComputeResource) GetCpuCount() int32 {
 	if m != nil {
@@ -164,7 +268,7 @@ type DataResource struct {
 func (m *DataResource) Reset()                    { *m = DataResource{} }
 func (m *DataResource) String() string            { return proto.CompactTextString(m) }
 func (*DataResource) ProtoMessage()               {}
-func (*DataResource) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*DataResource) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
 func (m *DataResource) GetLocation() *Location {
 	if m != nil {
@@ -190,7 +294,7 @@ type Location struct {
 func (m *Location) Reset()                    { *m = Location{} }
 func (m *Location) String() string            { return proto.CompactTextString(m) }
 func (*Location) ProtoMessage()               {}
-func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *Location) GetDataCenter() string {
 	if m != nil {
@@ -223,12 +327,13 @@ func (m *Location) GetPort() int32 {
 type Allocation struct {
 	Location  *Location        `protobuf:"bytes,1,opt,name=location" json:"location,omitempty"`
 	Allocated *ComputeResource `protobuf:"bytes,2,opt,name=allocated" json:"allocated,omitempty"`
+	LeaseId   uint64           `protobuf:"varint,3,opt,name=leaseId" json:"leaseId,omitempty"`
 }
 
 func (m *Allocation) Reset()                    { *m = Allocation{} }
 func (m *Allocation) String() string            { return proto.CompactTextString(m) }
 func (*Allocation) ProtoMessage()               {}
-func (*Allocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*Allocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *Allocation) GetLocation() *Location {
 	if m != nil {
@@ -244,14 +349,22 @@ func (m *Allocation) GetAllocated() *ComputeResource {
 	return nil
 }
 
+func (m *Allocation) GetLeaseId() uint64 {
+	if m != nil {
+		return m.LeaseId
+	}
+	return 0
+}
+
 type AllocationResult struct {
-	Allocations []*Allocation `protobuf:"bytes,1,rep,name=allocations" json:"allocations,omitempty"`
+	Allocations  []*Allocation `protobuf:"bytes,1,rep,name=allocations" json:"allocations,omitempty"`
+	LeaseSeconds int64         `protobuf:"varint,2,opt,name=leaseSeconds" json:"leaseSeconds,omitempty"`
 }
 
 func (m *AllocationResult) Reset()                    { *m = AllocationResult{} }
 func (m *AllocationResult) String() string            { return proto.CompactTextString(m) }
 func (*AllocationResult) ProtoMessage()               {}
-func (*AllocationResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*AllocationResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *AllocationResult) GetAllocations() []*Allocation {
 	if m != nil {
@@ -260,318 +373,1755 @@ func (m *AllocationResult) GetAllocations() []*Allocation {
 	return nil
 }
 
-// ////////////////////////////////////////////////
-type Heartbeat struct {
-	Location  *Location        `protobuf:"bytes,1,opt,name=location" json:"location,omitempty"`
-	Resource  *ComputeResource `protobuf:"bytes,2,opt,name=resource" json:"resource,omitempty"`
-	Allocated *ComputeResource `protobuf:"bytes,3,opt,name=allocated" json:"allocated,omitempty"`
+func (m *AllocationResult) GetLeaseSeconds() int64 {
+	if m != nil {
+		return m.LeaseSeconds
+	}
+	return 0
 }
 
-func (m *Heartbeat) Reset()                    { *m = Heartbeat{} }
-func (m *Heartbeat) String() string            { return proto.CompactTextString(m) }
-func (*Heartbeat) ProtoMessage()               {}
-func (*Heartbeat) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+// stop allocating on the agent, and find agents to take over its shards
+type DrainAgentRequest struct {
+	Location       *Location       `protobuf:"bytes,1,opt,name=location" json:"location,omitempty"`
+	MigratedShards []*DataLocation `protobuf:"bytes,2,rep,name=migratedShards" json:"migratedShards,omitempty"`
+}
 
-func (m *Heartbeat) GetLocation() *Location {
+func (m *DrainAgentRequest) Reset()                    { *m = DrainAgentRequest{} }
+func (m *DrainAgentRequest) String() string            { return proto.CompactTextString(m) }
+func (*DrainAgentRequest) ProtoMessage()               {}
+func (*DrainAgentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *DrainAgentRequest) GetLocation() *Location {
 	if m != nil {
 		return m.Location
 	}
 	return nil
 }
 
-func (m *Heartbeat) GetResource() *ComputeResource {
+func (m *DrainAgentRequest) GetMigratedShards() []*DataLocation {
 	if m != nil {
-		return m.Resource
+		return m.MigratedShards
 	}
 	return nil
 }
 
-func (m *Heartbeat) GetAllocated() *ComputeResource {
+type DrainAgentResponse struct {
+	Targets              []*Location `protobuf:"bytes,1,rep,name=targets" json:"targets,omitempty"`
+	RunningFlowHashCodes []uint32    `protobuf:"varint,2,rep,packed,name=runningFlowHashCodes" json:"runningFlowHashCodes,omitempty"`
+}
+
+func (m *DrainAgentResponse) Reset()                    { *m = DrainAgentResponse{} }
+func (m *DrainAgentResponse) String() string            { return proto.CompactTextString(m) }
+func (*DrainAgentResponse) ProtoMessage()               {}
+func (*DrainAgentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *DrainAgentResponse) GetTargets() []*Location {
 	if m != nil {
-		return m.Allocated
+		return m.Targets
 	}
 	return nil
 }
 
-type Empty struct {
+func (m *DrainAgentResponse) GetRunningFlowHashCodes() []uint32 {
+	if m != nil {
+		return m.RunningFlowHashCodes
+	}
+	return nil
 }
 
-func (m *Empty) Reset()                    { *m = Empty{} }
-func (m *Empty) String() string            { return proto.CompactTextString(m) }
-func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
-
 // ////////////////////////////////////////////////
-type DataLocation struct {
-	Name     string    `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	Location *Location `protobuf:"bytes,2,opt,name=location" json:"location,omitempty"`
-	OnDisk   bool      `protobuf:"varint,3,opt,name=onDisk" json:"onDisk,omitempty"`
+// cluster administration
+type TopologyRequest struct {
 }
 
-func (m *DataLocation) Reset()                    { *m = DataLocation{} }
-func (m *DataLocation) String() string            { return proto.CompactTextString(m) }
-func (*DataLocation) ProtoMessage()               {}
-func (*DataLocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (m *TopologyRequest) Reset()                    { *m = TopologyRequest{} }
+func (m *TopologyRequest) String() string            { return proto.CompactTextString(m) }
+func (*TopologyRequest) ProtoMessage()               {}
+func (*TopologyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
-func (m *DataLocation) GetName() string {
+type TopologyResponse struct {
+	Resource    *ComputeResource    `protobuf:"bytes,1,opt,name=resource" json:"resource,omitempty"`
+	Allocated   *ComputeResource    `protobuf:"bytes,2,opt,name=allocated" json:"allocated,omitempty"`
+	DataCenters []*DataCenterStatus `protobuf:"bytes,3,rep,name=dataCenters" json:"dataCenters,omitempty"`
+}
+
+func (m *TopologyResponse) Reset()                    { *m = TopologyResponse{} }
+func (m *TopologyResponse) String() string            { return proto.CompactTextString(m) }
+func (*TopologyResponse) ProtoMessage()               {}
+func (*TopologyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *TopologyResponse) GetResource() *ComputeResource {
 	if m != nil {
-		return m.Name
+		return m.Resource
 	}
-	return ""
+	return nil
 }
 
-func (m *DataLocation) GetLocation() *Location {
+func (m *TopologyResponse) GetAllocated() *ComputeResource {
 	if m != nil {
-		return m.Location
+		return m.Allocated
 	}
 	return nil
 }
 
-func (m *DataLocation) GetOnDisk() bool {
+func (m *TopologyResponse) GetDataCenters() []*DataCenterStatus {
 	if m != nil {
-		return m.OnDisk
+		return m.DataCenters
 	}
-	return false
+	return nil
 }
 
-type ControlMessage struct {
-	IsOnDiskIO                 bool                        `protobuf:"varint,1,opt,name=isOnDiskIO" json:"isOnDiskIO,omitempty"`
-	StartRequest               *StartRequest               `protobuf:"bytes,2,opt,name=startRequest" json:"startRequest,omitempty"`
-	StartResponse              *StartResponse              `protobuf:"bytes,3,opt,name=startResponse" json:"startResponse,omitempty"`
-	StopRequest                *StopRequest                `protobuf:"bytes,4,opt,name=stopRequest" json:"stopRequest,omitempty"`
-	StopResponse               *StopResponse               `protobuf:"bytes,5,opt,name=stopResponse" json:"stopResponse,omitempty"`
-	GetStatusRequest           *GetStatusRequest           `protobuf:"bytes,6,opt,name=getStatusRequest" json:"getStatusRequest,omitempty"`
-	GetStatusResponse          *GetStatusResponse          `protobuf:"bytes,7,opt,name=getStatusResponse" json:"getStatusResponse,omitempty"`
-	DeleteDatasetShardRequest  *DeleteDatasetShardRequest  `protobuf:"bytes,8,opt,name=deleteDatasetShardRequest" json:"deleteDatasetShardRequest,omitempty"`
-	DeleteDatasetShardResponse *DeleteDatasetShardResponse `protobuf:"bytes,9,opt,name=deleteDatasetShardResponse" json:"deleteDatasetShardResponse,omitempty"`
-	LocalStatusReportRequest   *LocalStatusReportRequest   `protobuf:"bytes,10,opt,name=localStatusReportRequest" json:"localStatusReportRequest,omitempty"`
-	LocalStatusReportResponse  *LocalStatusReportResponse  `protobuf:"bytes,11,opt,name=localStatusReportResponse" json:"localStatusReportResponse,omitempty"`
-	ReadRequest                *ReadRequest                `protobuf:"bytes,12,opt,name=readRequest" json:"readRequest,omitempty"`
-	WriteRequest               *WriteRequest               `protobuf:"bytes,13,opt,name=writeRequest" json:"writeRequest,omitempty"`
+type DataCenterStatus struct {
+	Name      string           `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Resource  *ComputeResource `protobuf:"bytes,2,opt,name=resource" json:"resource,omitempty"`
+	Allocated *ComputeResource `protobuf:"bytes,3,opt,name=allocated" json:"allocated,omitempty"`
+	Racks     []*RackStatus    `protobuf:"bytes,4,rep,name=racks" json:"racks,omitempty"`
 }
 
-func (m *ControlMessage) Reset()                    { *m = ControlMessage{} }
-func (m *ControlMessage) String() string            { return proto.CompactTextString(m) }
-func (*ControlMessage) ProtoMessage()               {}
-func (*ControlMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (m *DataCenterStatus) Reset()                    { *m = DataCenterStatus{} }
+func (m *DataCenterStatus) String() string            { return proto.CompactTextString(m) }
+func (*DataCenterStatus) ProtoMessage()               {}
+func (*DataCenterStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
-func (m *ControlMessage) GetIsOnDiskIO() bool {
+func (m *DataCenterStatus) GetName() string {
 	if m != nil {
-		return m.IsOnDiskIO
+		return m.Name
 	}
-	return false
+	return ""
 }
 
-func (m *ControlMessage) GetStartRequest() *StartRequest {
+func (m *DataCenterStatus) GetResource() *ComputeResource {
 	if m != nil {
-		return m.StartRequest
+		return m.Resource
 	}
 	return nil
 }
 
-func (m *ControlMessage) GetStartResponse() *StartResponse {
+func (m *DataCenterStatus) GetAllocated() *ComputeResource {
 	if m != nil {
-		return m.StartResponse
+		return m.Allocated
 	}
 	return nil
 }
 
-func (m *ControlMessage) GetStopRequest() *StopRequest {
+func (m *DataCenterStatus) GetRacks() []*RackStatus {
 	if m != nil {
-		return m.StopRequest
+		return m.Racks
 	}
 	return nil
 }
 
-func (m *ControlMessage) GetStopResponse() *StopResponse {
+type RackStatus struct {
+	Name      string           `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Resource  *ComputeResource `protobuf:"bytes,2,opt,name=resource" json:"resource,omitempty"`
+	Allocated *ComputeResource `protobuf:"bytes,3,opt,name=allocated" json:"allocated,omitempty"`
+	Agents    []*AgentStatus   `protobuf:"bytes,4,rep,name=agents" json:"agents,omitempty"`
+}
+
+func (m *RackStatus) Reset()                    { *m = RackStatus{} }
+func (m *RackStatus) String() string            { return proto.CompactTextString(m) }
+func (*RackStatus) ProtoMessage()               {}
+func (*RackStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *RackStatus) GetName() string {
 	if m != nil {
-		return m.StopResponse
+		return m.Name
 	}
-	return nil
+	return ""
 }
 
-func (m *ControlMessage) GetGetStatusRequest() *GetStatusRequest {
+func (m *RackStatus) GetResource() *ComputeResource {
 	if m != nil {
-		return m.GetStatusRequest
+		return m.Resource
 	}
 	return nil
 }
 
-func (m *ControlMessage) GetGetStatusResponse() *GetStatusResponse {
+func (m *RackStatus) GetAllocated() *ComputeResource {
 	if m != nil {
-		return m.GetStatusResponse
+		return m.Allocated
 	}
 	return nil
 }
 
-func (m *ControlMessage) GetDeleteDatasetShardRequest() *DeleteDatasetShardRequest {
+func (m *RackStatus) GetAgents() []*AgentStatus {
 	if m != nil {
-		return m.DeleteDatasetShardRequest
+		return m.Agents
 	}
 	return nil
 }
 
-func (m *ControlMessage) GetDeleteDatasetShardResponse() *DeleteDatasetShardResponse {
+type AgentStatus struct {
+	Location      *Location        `protobuf:"bytes,1,opt,name=location" json:"location,omitempty"`
+	Resource      *ComputeResource `protobuf:"bytes,2,opt,name=resource" json:"resource,omitempty"`
+	Allocated     *ComputeResource `protobuf:"bytes,3,opt,name=allocated" json:"allocated,omitempty"`
+	State         string           `protobuf:"bytes,4,opt,name=state" json:"state,omitempty"`
+	Draining      bool             `protobuf:"varint,5,opt,name=draining" json:"draining,omitempty"`
+	LastHeartbeat int64            `protobuf:"varint,6,opt,name=lastHeartbeat" json:"lastHeartbeat,omitempty"`
+	Load          *AgentLoad       `protobuf:"bytes,7,opt,name=load" json:"load,omitempty"`
+}
+
+func (m *AgentStatus) Reset()                    { *m = AgentStatus{} }
+func (m *AgentStatus) String() string            { return proto.CompactTextString(m) }
+func (*AgentStatus) ProtoMessage()               {}
+func (*AgentStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *AgentStatus) GetLocation() *Location {
 	if m != nil {
-		return m.DeleteDatasetShardResponse
+		return m.Location
 	}
 	return nil
 }
 
-func (m *ControlMessage) GetLocalStatusReportRequest() *LocalStatusReportRequest {
+func (m *AgentStatus) GetResource() *ComputeResource {
 	if m != nil {
-		return m.LocalStatusReportRequest
+		return m.Resource
 	}
 	return nil
 }
 
-func (m *ControlMessage) GetLocalStatusReportResponse() *LocalStatusReportResponse {
+func (m *AgentStatus) GetAllocated() *ComputeResource {
 	if m != nil {
-		return m.LocalStatusReportResponse
+		return m.Allocated
 	}
 	return nil
 }
 
-func (m *ControlMessage) GetReadRequest() *ReadRequest {
+func (m *AgentStatus) GetState() string {
 	if m != nil {
-		return m.ReadRequest
+		return m.State
 	}
-	return nil
+	return ""
 }
 
-func (m *ControlMessage) GetWriteRequest() *WriteRequest {
+func (m *AgentStatus) GetDraining() bool {
 	if m != nil {
-		return m.WriteRequest
+		return m.Draining
+	}
+	return false
+}
+
+func (m *AgentStatus) GetLastHeartbeat() int64 {
+	if m != nil {
+		return m.LastHeartbeat
+	}
+	return 0
+}
+
+func (m *AgentStatus) GetLoad() *AgentLoad {
+	if m != nil {
+		return m.Load
 	}
 	return nil
 }
 
-type NetChan struct {
-	Server string `protobuf:"bytes,1,opt,name=server" json:"server,omitempty"`
-	Port   int32  `protobuf:"varint,2,opt,name=port" json:"port,omitempty"`
+type ListFlowsRequest struct {
 }
 
-func (m *NetChan) Reset()                    { *m = NetChan{} }
-func (m *NetChan) String() string            { return proto.CompactTextString(m) }
-func (*NetChan) ProtoMessage()               {}
-func (*NetChan) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (m *ListFlowsRequest) Reset()                    { *m = ListFlowsRequest{} }
+func (m *ListFlowsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListFlowsRequest) ProtoMessage()               {}
+func (*ListFlowsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
-func (m *NetChan) GetServer() string {
+type ListFlowsResponse struct {
+	Flows []*FlowStatus `protobuf:"bytes,1,rep,name=flows" json:"flows,omitempty"`
+}
+
+func (m *ListFlowsResponse) Reset()                    { *m = ListFlowsResponse{} }
+func (m *ListFlowsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListFlowsResponse) ProtoMessage()               {}
+func (*ListFlowsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *ListFlowsResponse) GetFlows() []*FlowStatus {
 	if m != nil {
-		return m.Server
+		return m.Flows
 	}
-	return ""
+	return nil
 }
 
-func (m *NetChan) GetPort() int32 {
+// a running driver, with its active leases,
+// or a recently finished one reporting its progress
+type FlowStatus struct {
+	FlowHashCode        uint32        `protobuf:"varint,1,opt,name=flowHashCode" json:"flowHashCode,omitempty"`
+	Name                string        `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	User                string        `protobuf:"bytes,3,opt,name=user" json:"user,omitempty"`
+	Queue               string        `protobuf:"bytes,4,opt,name=queue" json:"queue,omitempty"`
+	Driver              string        `protobuf:"bytes,5,opt,name=driver" json:"driver,omitempty"`
+	StartTime           int64         `protobuf:"varint,6,opt,name=startTime" json:"startTime,omitempty"`
+	Allocations         []*Allocation `protobuf:"bytes,7,rep,name=allocations" json:"allocations,omitempty"`
+	State               string        `protobuf:"bytes,8,opt,name=state" json:"state,omitempty"`
+	StopTime            int64         `protobuf:"varint,9,opt,name=stopTime" json:"stopTime,omitempty"`
+	Error               string        `protobuf:"bytes,10,opt,name=error" json:"error,omitempty"`
+	TaskGroups          int32         `protobuf:"varint,11,opt,name=taskGroups" json:"taskGroups,omitempty"`
+	RunningTaskGroups   int32         `protobuf:"varint,12,opt,name=runningTaskGroups" json:"runningTaskGroups,omitempty"`
+	CompletedTaskGroups int32         `protobuf:"varint,13,opt,name=completedTaskGroups" json:"completedTaskGroups,omitempty"`
+	FailedTaskGroups    int32         `protobuf:"varint,14,opt,name=failedTaskGroups" json:"failedTaskGroups,omitempty"`
+}
+
+func (m *FlowStatus) Reset()                    { *m = FlowStatus{} }
+func (m *FlowStatus) String() string            { return proto.CompactTextString(m) }
+func (*FlowStatus) ProtoMessage()               {}
+func (*FlowStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *FlowStatus) GetFlowHashCode() uint32 {
 	if m != nil {
-		return m.Port
+		return m.FlowHashCode
 	}
 	return 0
 }
 
-type StartResponse struct {
-	Pid     int32      `protobuf:"varint,1,opt,name=pid" json:"pid,omitempty"`
-	Error   string     `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
-	Stderr  *NetChan   `protobuf:"bytes,3,opt,name=stderr" json:"stderr,omitempty"`
-	Outputs []*NetChan `protobuf:"bytes,4,rep,name=outputs" json:"outputs,omitempty"`
+func (m *FlowStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-func (m *StartResponse) Reset()                    { *m = StartResponse{} }
-func (m *StartResponse) String() string            { return proto.CompactTextString(m) }
-func (*StartResponse) ProtoMessage()               {}
-func (*StartResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (m *FlowStatus) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
 
-func (m *StartResponse) GetPid() int32 {
+func (m *FlowStatus) GetQueue() string {
 	if m != nil {
-		return m.Pid
+		return m.Queue
 	}
-	return 0
+	return ""
 }
 
-func (m *StartResponse) GetError() string {
+func (m *FlowStatus) GetDriver() string {
 	if m != nil {
-		return m.Error
+		return m.Driver
 	}
 	return ""
 }
 
-func (m *StartResponse) GetStderr() *NetChan {
+func (m *FlowStatus) GetStartTime() int64 {
 	if m != nil {
-		return m.Stderr
+		return m.StartTime
 	}
-	return nil
+	return 0
 }
 
-func (m *StartResponse) GetOutputs() []*NetChan {
+func (m *FlowStatus) GetAllocations() []*Allocation {
 	if m != nil {
-		return m.Outputs
+		return m.Allocations
 	}
 	return nil
 }
 
-type StopResponse struct {
-	StartRequestHash uint32 `protobuf:"varint,1,opt,name=startRequestHash" json:"startRequestHash,omitempty"`
-	Error            string `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+func (m *FlowStatus) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
 }
 
-func (m *StopResponse) Reset()                    { *m = StopResponse{} }
-func (m *StopResponse) String() string            { return proto.CompactTextString(m) }
-func (*StopResponse) ProtoMessage()               {}
-func (*StopResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
-
-func (m *StopResponse) GetStartRequestHash() uint32 {
+func (m *FlowStatus) GetStopTime() int64 {
 	if m != nil {
-		return m.StartRequestHash
+		return m.StopTime
 	}
 	return 0
 }
 
-func (m *StopResponse) GetError() string {
+func (m *FlowStatus) GetError() string {
 	if m != nil {
 		return m.Error
 	}
 	return ""
 }
 
-type GetStatusRequest struct {
-	StartRequestHash uint32 `protobuf:"varint,1,opt,name=startRequestHash" json:"startRequestHash,omitempty"`
-}
-
-func (m *GetStatusRequest) Reset()                    { *m = GetStatusRequest{} }
-func (m *GetStatusRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetStatusRequest) ProtoMessage()               {}
-func (*GetStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
-
-func (m *GetStatusRequest) GetStartRequestHash() uint32 {
+func (m *FlowStatus) GetTaskGroups() int32 {
 	if m != nil {
-		return m.StartRequestHash
+		return m.TaskGroups
 	}
 	return 0
 }
 
-type ChannelStatus struct {
-	Length    int64  `protobuf:"varint,1,opt,name=length" json:"length,omitempty"`
-	StartTime int64  `protobuf:"varint,2,opt,name=startTime" json:"startTime,omitempty"`
-	StopTime  int64  `protobuf:"varint,3,opt,name=stopTime" json:"stopTime,omitempty"`
-	Name      string `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
+func (m *FlowStatus) GetRunningTaskGroups() int32 {
+	if m != nil {
+		return m.RunningTaskGroups
+	}
+	return 0
 }
 
-func (m *ChannelStatus) Reset()                    { *m = ChannelStatus{} }
-func (m *ChannelStatus) String() string            { return proto.CompactTextString(m) }
-func (*ChannelStatus) ProtoMessage()               {}
-func (*ChannelStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
-
-func (m *ChannelStatus) GetLength() int64 {
+func (m *FlowStatus) GetCompletedTaskGroups() int32 {
 	if m != nil {
-		return m.Length
+		return m.CompletedTaskGroups
 	}
 	return 0
 }
 
-func (m *ChannelStatus) GetStartTime() int64 {
+func (m *FlowStatus) GetFailedTaskGroups() int32 {
 	if m != nil {
-		return m.StartTime
+		return m.FailedTaskGroups
+	}
+	return 0
+}
+
+// progress of a flow, reported by its driver periodically
+type FlowProgress struct {
+	FlowHashCode uint32               `protobuf:"varint,1,opt,name=flowHashCode" json:"flowHashCode,omitempty"`
+	Name         string               `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	User         string               `protobuf:"bytes,3,opt,name=user" json:"user,omitempty"`
+	Queue        string               `protobuf:"bytes,4,opt,name=queue" json:"queue,omitempty"`
+	Driver       string               `protobuf:"bytes,5,opt,name=driver" json:"driver,omitempty"`
+	State        string               `protobuf:"bytes,6,opt,name=state" json:"state,omitempty"`
+	StartTime    int64                `protobuf:"varint,7,opt,name=startTime" json:"startTime,omitempty"`
+	StopTime     int64                `protobuf:"varint,8,opt,name=stopTime" json:"stopTime,omitempty"`
+	Error        string               `protobuf:"bytes,9,opt,name=error" json:"error,omitempty"`
+	TaskGroups   []*TaskGroupProgress `protobuf:"bytes,10,rep,name=taskGroups" json:"taskGroups,omitempty"`
+	Steps        []*StepProgress      `protobuf:"bytes,11,rep,name=steps" json:"steps,omitempty"`
+	Datasets     []*DatasetProgress   `protobuf:"bytes,12,rep,name=datasets" json:"datasets,omitempty"`
+	Plan         string               `protobuf:"bytes,13,opt,name=plan" json:"plan,omitempty"`
+}
+
+func (m *FlowProgress) Reset()                    { *m = FlowProgress{} }
+func (m *FlowProgress) String() string            { return proto.CompactTextString(m) }
+func (*FlowProgress) ProtoMessage()               {}
+func (*FlowProgress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *FlowProgress) GetFlowHashCode() uint32 {
+	if m != nil {
+		return m.FlowHashCode
+	}
+	return 0
+}
+
+func (m *FlowProgress) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FlowProgress) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *FlowProgress) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *FlowProgress) GetDriver() string {
+	if m != nil {
+		return m.Driver
+	}
+	return ""
+}
+
+func (m *FlowProgress) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *FlowProgress) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *FlowProgress) GetStopTime() int64 {
+	if m != nil {
+		return m.StopTime
+	}
+	return 0
+}
+
+func (m *FlowProgress) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *FlowProgress) GetTaskGroups() []*TaskGroupProgress {
+	if m != nil {
+		return m.TaskGroups
+	}
+	return nil
+}
+
+func (m *FlowProgress) GetSteps() []*StepProgress {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+func (m *FlowProgress) GetDatasets() []*DatasetProgress {
+	if m != nil {
+		return m.Datasets
+	}
+	return nil
+}
+
+func (m *FlowProgress) GetPlan() string {
+	if m != nil {
+		return m.Plan
+	}
+	return ""
+}
+
+// a step of the flow, with the stats of its tasks run so far
+type StepProgress struct {
+	Id             int32            `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Name           string           `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	NetworkType    string           `protobuf:"bytes,3,opt,name=networkType" json:"networkType,omitempty"`
+	IsOnDriverSide bool             `protobuf:"varint,4,opt,name=isOnDriverSide" json:"isOnDriverSide,omitempty"`
+	TaskCount      int32            `protobuf:"varint,5,opt,name=taskCount" json:"taskCount,omitempty"`
+	Stats          *InstructionStat `protobuf:"bytes,6,opt,name=stats" json:"stats,omitempty"`
+}
+
+func (m *StepProgress) Reset()                    { *m = StepProgress{} }
+func (m *StepProgress) String() string            { return proto.CompactTextString(m) }
+func (*StepProgress) ProtoMessage()               {}
+func (*StepProgress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *StepProgress) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *StepProgress) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *StepProgress) GetNetworkType() string {
+	if m != nil {
+		return m.NetworkType
+	}
+	return ""
+}
+
+func (m *StepProgress) GetIsOnDriverSide() bool {
+	if m != nil {
+		return m.IsOnDriverSide
+	}
+	return false
+}
+
+func (m *StepProgress) GetTaskCount() int32 {
+	if m != nil {
+		return m.TaskCount
+	}
+	return 0
+}
+
+func (m *StepProgress) GetStats() *InstructionStat {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+// a dataset links the step writing it to the steps reading it
+type DatasetProgress struct {
+	Id             int32                   `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	StepId         int32                   `protobuf:"varint,2,opt,name=stepId" json:"stepId,omitempty"`
+	ReadingStepIds []int32                 `protobuf:"varint,3,rep,packed,name=readingStepIds" json:"readingStepIds,omitempty"`
+	OnDisk         bool                    `protobuf:"varint,4,opt,name=onDisk" json:"onDisk,omitempty"`
+	Shards         []*DatasetShardProgress `protobuf:"bytes,5,rep,name=shards" json:"shards,omitempty"`
+}
+
+func (m *DatasetProgress) Reset()                    { *m = DatasetProgress{} }
+func (m *DatasetProgress) String() string            { return proto.CompactTextString(m) }
+func (*DatasetProgress) ProtoMessage()               {}
+func (*DatasetProgress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *DatasetProgress) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DatasetProgress) GetStepId() int32 {
+	if m != nil {
+		return m.StepId
+	}
+	return 0
+}
+
+func (m *DatasetProgress) GetReadingStepIds() []int32 {
+	if m != nil {
+		return m.ReadingStepIds
+	}
+	return nil
+}
+
+func (m *DatasetProgress) GetOnDisk() bool {
+	if m != nil {
+		return m.OnDisk
+	}
+	return false
+}
+
+func (m *DatasetProgress) GetShards() []*DatasetShardProgress {
+	if m != nil {
+		return m.Shards
+	}
+	return nil
+}
+
+type DatasetShardProgress struct {
+	Id       int32     `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Status   string    `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+	Location *Location `protobuf:"bytes,3,opt,name=location" json:"location,omitempty"`
+	Rows     int64     `protobuf:"varint,4,opt,name=rows" json:"rows,omitempty"`
+	Bytes    int64     `protobuf:"varint,5,opt,name=bytes" json:"bytes,omitempty"`
+}
+
+func (m *DatasetShardProgress) Reset()                    { *m = DatasetShardProgress{} }
+func (m *DatasetShardProgress) String() string            { return proto.CompactTextString(m) }
+func (*DatasetShardProgress) ProtoMessage()               {}
+func (*DatasetShardProgress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *DatasetShardProgress) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DatasetShardProgress) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *DatasetShardProgress) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *DatasetShardProgress) GetRows() int64 {
+	if m != nil {
+		return m.Rows
+	}
+	return 0
+}
+
+func (m *DatasetShardProgress) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+type TaskGroupProgress struct {
+	Id          int32              `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Name        string             `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Steps       []string           `protobuf:"bytes,3,rep,name=steps" json:"steps,omitempty"`
+	State       string             `protobuf:"bytes,4,opt,name=state" json:"state,omitempty"`
+	Location    *Location          `protobuf:"bytes,5,opt,name=location" json:"location,omitempty"`
+	WaitTime    int64              `protobuf:"varint,6,opt,name=waitTime" json:"waitTime,omitempty"`
+	StartTime   int64              `protobuf:"varint,7,opt,name=startTime" json:"startTime,omitempty"`
+	StopTime    int64              `protobuf:"varint,8,opt,name=stopTime" json:"stopTime,omitempty"`
+	Error       string             `protobuf:"bytes,9,opt,name=error" json:"error,omitempty"`
+	InputRows   int64              `protobuf:"varint,10,opt,name=inputRows" json:"inputRows,omitempty"`
+	InputBytes  int64              `protobuf:"varint,11,opt,name=inputBytes" json:"inputBytes,omitempty"`
+	OutputRows  int64              `protobuf:"varint,12,opt,name=outputRows" json:"outputRows,omitempty"`
+	OutputBytes int64              `protobuf:"varint,13,opt,name=outputBytes" json:"outputBytes,omitempty"`
+	StepIds     []int32            `protobuf:"varint,14,rep,packed,name=stepIds" json:"stepIds,omitempty"`
+	TaskStats   []*InstructionStat `protobuf:"bytes,15,rep,name=taskStats" json:"taskStats,omitempty"`
+}
+
+func (m *TaskGroupProgress) Reset()                    { *m = TaskGroupProgress{} }
+func (m *TaskGroupProgress) String() string            { return proto.CompactTextString(m) }
+func (*TaskGroupProgress) ProtoMessage()               {}
+func (*TaskGroupProgress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *TaskGroupProgress) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *TaskGroupProgress) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TaskGroupProgress) GetSteps() []string {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+func (m *TaskGroupProgress) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *TaskGroupProgress) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *TaskGroupProgress) GetWaitTime() int64 {
+	if m != nil {
+		return m.WaitTime
+	}
+	return 0
+}
+
+func (m *TaskGroupProgress) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *TaskGroupProgress) GetStopTime() int64 {
+	if m != nil {
+		return m.StopTime
+	}
+	return 0
+}
+
+func (m *TaskGroupProgress) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *TaskGroupProgress) GetInputRows() int64 {
+	if m != nil {
+		return m.InputRows
+	}
+	return 0
+}
+
+func (m *TaskGroupProgress) GetInputBytes() int64 {
+	if m != nil {
+		return m.InputBytes
+	}
+	return 0
+}
+
+func (m *TaskGroupProgress) GetOutputRows() int64 {
+	if m != nil {
+		return m.OutputRows
+	}
+	return 0
+}
+
+func (m *TaskGroupProgress) GetOutputBytes() int64 {
+	if m != nil {
+		return m.OutputBytes
+	}
+	return 0
+}
+
+func (m *TaskGroupProgress) GetStepIds() []int32 {
+	if m != nil {
+		return m.StepIds
+	}
+	return nil
+}
+
+func (m *TaskGroupProgress) GetTaskStats() []*InstructionStat {
+	if m != nil {
+		return m.TaskStats
+	}
+	return nil
+}
+
+type FlowProgressResponse struct {
+	MigratedShards []*DataLocation `protobuf:"bytes,1,rep,name=migratedShards" json:"migratedShards,omitempty"`
+}
+
+func (m *FlowProgressResponse) Reset()                    { *m = FlowProgressResponse{} }
+func (m *FlowProgressResponse) String() string            { return proto.CompactTextString(m) }
+func (*FlowProgressResponse) ProtoMessage()               {}
+func (*FlowProgressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *FlowProgressResponse) GetMigratedShards() []*DataLocation {
+	if m != nil {
+		return m.MigratedShards
+	}
+	return nil
+}
+
+// past runs, kept by the master with a history directory
+type ListHistoryRequest struct {
+	Name  string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Limit int32  `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *ListHistoryRequest) Reset()                    { *m = ListHistoryRequest{} }
+func (m *ListHistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListHistoryRequest) ProtoMessage()               {}
+func (*ListHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *ListHistoryRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ListHistoryRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListHistoryResponse struct {
+	Flows []*FlowStatus `protobuf:"bytes,1,rep,name=flows" json:"flows,omitempty"`
+}
+
+func (m *ListHistoryResponse) Reset()                    { *m = ListHistoryResponse{} }
+func (m *ListHistoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListHistoryResponse) ProtoMessage()               {}
+func (*ListHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *ListHistoryResponse) GetFlows() []*FlowStatus {
+	if m != nil {
+		return m.Flows
+	}
+	return nil
+}
+
+type GetHistoryRequest struct {
+	FlowHashCode uint32 `protobuf:"varint,1,opt,name=flowHashCode" json:"flowHashCode,omitempty"`
+}
+
+func (m *GetHistoryRequest) Reset()                    { *m = GetHistoryRequest{} }
+func (m *GetHistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetHistoryRequest) ProtoMessage()               {}
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *GetHistoryRequest) GetFlowHashCode() uint32 {
+	if m != nil {
+		return m.FlowHashCode
+	}
+	return 0
+}
+
+type KillFlowRequest struct {
+	FlowHashCode uint32 `protobuf:"varint,1,opt,name=flowHashCode" json:"flowHashCode,omitempty"`
+}
+
+func (m *KillFlowRequest) Reset()                    { *m = KillFlowRequest{} }
+func (m *KillFlowRequest) String() string            { return proto.CompactTextString(m) }
+func (*KillFlowRequest) ProtoMessage()               {}
+func (*KillFlowRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *KillFlowRequest) GetFlowHashCode() uint32 {
+	if m != nil {
+		return m.FlowHashCode
+	}
+	return 0
+}
+
+type KillFlowResponse struct {
+	KilledLeases int32 `protobuf:"varint,1,opt,name=killedLeases" json:"killedLeases,omitempty"`
+}
+
+func (m *KillFlowResponse) Reset()                    { *m = KillFlowResponse{} }
+func (m *KillFlowResponse) String() string            { return proto.CompactTextString(m) }
+func (*KillFlowResponse) ProtoMessage()               {}
+func (*KillFlowResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *KillFlowResponse) GetKilledLeases() int32 {
+	if m != nil {
+		return m.KilledLeases
+	}
+	return 0
+}
+
+type LeaseRequest struct {
+	LeaseIds     []uint64 `protobuf:"varint,1,rep,packed,name=leaseIds" json:"leaseIds,omitempty"`
+	FlowHashCode uint32   `protobuf:"varint,2,opt,name=flowHashCode" json:"flowHashCode,omitempty"`
+}
+
+func (m *LeaseRequest) Reset()                    { *m = LeaseRequest{} }
+func (m *LeaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*LeaseRequest) ProtoMessage()               {}
+func (*LeaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *LeaseRequest) GetLeaseIds() []uint64 {
+	if m != nil {
+		return m.LeaseIds
+	}
+	return nil
+}
+
+func (m *LeaseRequest) GetFlowHashCode() uint32 {
+	if m != nil {
+		return m.FlowHashCode
+	}
+	return 0
+}
+
+type LeaseResponse struct {
+	ExpiredLeaseIds []uint64    `protobuf:"varint,1,rep,packed,name=expiredLeaseIds" json:"expiredLeaseIds,omitempty"`
+	DeadAgents      []*Location `protobuf:"bytes,2,rep,name=deadAgents" json:"deadAgents,omitempty"`
+	FlowKilled      bool        `protobuf:"varint,3,opt,name=flowKilled" json:"flowKilled,omitempty"`
+}
+
+func (m *LeaseResponse) Reset()                    { *m = LeaseResponse{} }
+func (m *LeaseResponse) String() string            { return proto.CompactTextString(m) }
+func (*LeaseResponse) ProtoMessage()               {}
+func (*LeaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *LeaseResponse) GetExpiredLeaseIds() []uint64 {
+	if m != nil {
+		return m.ExpiredLeaseIds
+	}
+	return nil
+}
+
+func (m *LeaseResponse) GetDeadAgents() []*Location {
+	if m != nil {
+		return m.DeadAgents
+	}
+	return nil
+}
+
+func (m *LeaseResponse) GetFlowKilled() bool {
+	if m != nil {
+		return m.FlowKilled
+	}
+	return false
+}
+
+// ////////////////////////////////////////////////
+type Heartbeat struct {
+	Location         *Location          `protobuf:"bytes,1,opt,name=location" json:"location,omitempty"`
+	Resource         *ComputeResource   `protobuf:"bytes,2,opt,name=resource" json:"resource,omitempty"`
+	Allocated        *ComputeResource   `protobuf:"bytes,3,opt,name=allocated" json:"allocated,omitempty"`
+	QueueAllocations []*QueueAllocation `protobuf:"bytes,4,rep,name=queueAllocations" json:"queueAllocations,omitempty"`
+	RunningLeaseIds  []uint64           `protobuf:"varint,5,rep,packed,name=runningLeaseIds" json:"runningLeaseIds,omitempty"`
+	Load             *AgentLoad         `protobuf:"bytes,6,opt,name=load" json:"load,omitempty"`
+	Draining         bool               `protobuf:"varint,7,opt,name=draining" json:"draining,omitempty"`
+}
+
+func (m *Heartbeat) Reset()                    { *m = Heartbeat{} }
+func (m *Heartbeat) String() string            { return proto.CompactTextString(m) }
+func (*Heartbeat) ProtoMessage()               {}
+func (*Heartbeat) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *Heartbeat) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *Heartbeat) GetResource() *ComputeResource {
+	if m != nil {
+		return m.Resource
+	}
+	return nil
+}
+
+func (m *Heartbeat) GetAllocated() *ComputeResource {
+	if m != nil {
+		return m.Allocated
+	}
+	return nil
+}
+
+func (m *Heartbeat) GetQueueAllocations() []*QueueAllocation {
+	if m != nil {
+		return m.QueueAllocations
+	}
+	return nil
+}
+
+func (m *Heartbeat) GetRunningLeaseIds() []uint64 {
+	if m != nil {
+		return m.RunningLeaseIds
+	}
+	return nil
+}
+
+func (m *Heartbeat) GetLoad() *AgentLoad {
+	if m != nil {
+		return m.Load
+	}
+	return nil
+}
+
+func (m *Heartbeat) GetDraining() bool {
+	if m != nil {
+		return m.Draining
+	}
+	return false
+}
+
+// measured usage of the agent's machine, including other processes
+type AgentLoad struct {
+	CpuUsage         float64      `protobuf:"fixed64,1,opt,name=cpuUsage" json:"cpuUsage,omitempty"`
+	FreeMemoryMb     int64        `protobuf:"varint,2,opt,name=freeMemoryMb" json:"freeMemoryMb,omitempty"`
+	Disks            []*DiskUsage `protobuf:"bytes,3,rep,name=disks" json:"disks,omitempty"`
+	RunningExecutors int32        `protobuf:"varint,4,opt,name=runningExecutors" json:"runningExecutors,omitempty"`
+	ShardBytes       int64        `protobuf:"varint,5,opt,name=shardBytes" json:"shardBytes,omitempty"`
+}
+
+func (m *AgentLoad) Reset()                    { *m = AgentLoad{} }
+func (m *AgentLoad) String() string            { return proto.CompactTextString(m) }
+func (*AgentLoad) ProtoMessage()               {}
+func (*AgentLoad) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *AgentLoad) GetCpuUsage() float64 {
+	if m != nil {
+		return m.CpuUsage
+	}
+	return 0
+}
+
+func (m *AgentLoad) GetFreeMemoryMb() int64 {
+	if m != nil {
+		return m.FreeMemoryMb
+	}
+	return 0
+}
+
+func (m *AgentLoad) GetDisks() []*DiskUsage {
+	if m != nil {
+		return m.Disks
+	}
+	return nil
+}
+
+func (m *AgentLoad) GetRunningExecutors() int32 {
+	if m != nil {
+		return m.RunningExecutors
+	}
+	return 0
+}
+
+func (m *AgentLoad) GetShardBytes() int64 {
+	if m != nil {
+		return m.ShardBytes
+	}
+	return 0
+}
+
+type DiskUsage struct {
+	Dir    string `protobuf:"bytes,1,opt,name=dir" json:"dir,omitempty"`
+	FreeMb int64  `protobuf:"varint,2,opt,name=freeMb" json:"freeMb,omitempty"`
+}
+
+func (m *DiskUsage) Reset()                    { *m = DiskUsage{} }
+func (m *DiskUsage) String() string            { return proto.CompactTextString(m) }
+func (*DiskUsage) ProtoMessage()               {}
+func (*DiskUsage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *DiskUsage) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+func (m *DiskUsage) GetFreeMb() int64 {
+	if m != nil {
+		return m.FreeMb
+	}
+	return 0
+}
+
+type QueueAllocation struct {
+	Queue     string           `protobuf:"bytes,1,opt,name=queue" json:"queue,omitempty"`
+	Allocated *ComputeResource `protobuf:"bytes,2,opt,name=allocated" json:"allocated,omitempty"`
+}
+
+func (m *QueueAllocation) Reset()                    { *m = QueueAllocation{} }
+func (m *QueueAllocation) String() string            { return proto.CompactTextString(m) }
+func (*QueueAllocation) ProtoMessage()               {}
+func (*QueueAllocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *QueueAllocation) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *QueueAllocation) GetAllocated() *ComputeResource {
+	if m != nil {
+		return m.Allocated
+	}
+	return nil
+}
+
+type HeartbeatResponse struct {
+	PreemptRequests []*PreemptRequest `protobuf:"bytes,1,rep,name=preemptRequests" json:"preemptRequests,omitempty"`
+	ExpiredLeaseIds []uint64          `protobuf:"varint,2,rep,packed,name=expiredLeaseIds" json:"expiredLeaseIds,omitempty"`
+}
+
+func (m *HeartbeatResponse) Reset()                    { *m = HeartbeatResponse{} }
+func (m *HeartbeatResponse) String() string            { return proto.CompactTextString(m) }
+func (*HeartbeatResponse) ProtoMessage()               {}
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *HeartbeatResponse) GetPreemptRequests() []*PreemptRequest {
+	if m != nil {
+		return m.PreemptRequests
+	}
+	return nil
+}
+
+func (m *HeartbeatResponse) GetExpiredLeaseIds() []uint64 {
+	if m != nil {
+		return m.ExpiredLeaseIds
+	}
+	return nil
+}
+
+// stop the newest executors of the queue to free up the resource
+type PreemptRequest struct {
+	Queue    string           `protobuf:"bytes,1,opt,name=queue" json:"queue,omitempty"`
+	Resource *ComputeResource `protobuf:"bytes,2,opt,name=resource" json:"resource,omitempty"`
+}
+
+func (m *PreemptRequest) Reset()                    { *m = PreemptRequest{} }
+func (m *PreemptRequest) String() string            { return proto.CompactTextString(m) }
+func (*PreemptRequest) ProtoMessage()               {}
+func (*PreemptRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *PreemptRequest) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *PreemptRequest) GetResource() *ComputeResource {
+	if m != nil {
+		return m.Resource
+	}
+	return nil
+}
+
+type Empty struct {
+}
+
+func (m *Empty) Reset()                    { *m = Empty{} }
+func (m *Empty) String() string            { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()               {}
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+// ////////////////////////////////////////////////
+type DataLocation struct {
+	Name     string    `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Location *Location `protobuf:"bytes,2,opt,name=location" json:"location,omitempty"`
+	OnDisk   bool      `protobuf:"varint,3,opt,name=onDisk" json:"onDisk,omitempty"`
+}
+
+func (m *DataLocation) Reset()                    { *m = DataLocation{} }
+func (m *DataLocation) String() string            { return proto.CompactTextString(m) }
+func (*DataLocation) ProtoMessage()               {}
+func (*DataLocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *DataLocation) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DataLocation) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *DataLocation) GetOnDisk() bool {
+	if m != nil {
+		return m.OnDisk
+	}
+	return false
+}
+
+type ControlMessage struct {
+	IsOnDiskIO                 bool                        `protobuf:"varint,1,opt,name=isOnDiskIO" json:"isOnDiskIO,omitempty"`
+	StartRequest               *StartRequest               `protobuf:"bytes,2,opt,name=startRequest" json:"startRequest,omitempty"`
+	StartResponse              *StartResponse              `protobuf:"bytes,3,opt,name=startResponse" json:"startResponse,omitempty"`
+	StopRequest                *StopRequest                `protobuf:"bytes,4,opt,name=stopRequest" json:"stopRequest,omitempty"`
+	StopResponse               *StopResponse               `protobuf:"bytes,5,opt,name=stopResponse" json:"stopResponse,omitempty"`
+	GetStatusRequest           *GetStatusRequest           `protobuf:"bytes,6,opt,name=getStatusRequest" json:"getStatusRequest,omitempty"`
+	GetStatusResponse          *GetStatusResponse          `protobuf:"bytes,7,opt,name=getStatusResponse" json:"getStatusResponse,omitempty"`
+	DeleteDatasetShardRequest  *DeleteDatasetShardRequest  `protobuf:"bytes,8,opt,name=deleteDatasetShardRequest" json:"deleteDatasetShardRequest,omitempty"`
+	DeleteDatasetShardResponse *DeleteDatasetShardResponse `protobuf:"bytes,9,opt,name=deleteDatasetShardResponse" json:"deleteDatasetShardResponse,omitempty"`
+	LocalStatusReportRequest   *LocalStatusReportRequest   `protobuf:"bytes,10,opt,name=localStatusReportRequest" json:"localStatusReportRequest,omitempty"`
+	LocalStatusReportResponse  *LocalStatusReportResponse  `protobuf:"bytes,11,opt,name=localStatusReportResponse" json:"localStatusReportResponse,omitempty"`
+	ReadRequest                *ReadRequest                `protobuf:"bytes,12,opt,name=readRequest" json:"readRequest,omitempty"`
+	WriteRequest               *WriteRequest               `protobuf:"bytes,13,opt,name=writeRequest" json:"writeRequest,omitempty"`
+	Token                      string                      `protobuf:"bytes,14,opt,name=token" json:"token,omitempty"`
+	Error                      string                      `protobuf:"bytes,15,opt,name=error" json:"error,omitempty"`
+	DrainRequest               *DrainRequest               `protobuf:"bytes,16,opt,name=drainRequest" json:"drainRequest,omitempty"`
+	DrainResponse              *DrainResponse              `protobuf:"bytes,17,opt,name=drainResponse" json:"drainResponse,omitempty"`
+	ListDatasetShardsRequest   *ListDatasetShardsRequest   `protobuf:"bytes,18,opt,name=listDatasetShardsRequest" json:"listDatasetShardsRequest,omitempty"`
+	ListDatasetShardsResponse  *ListDatasetShardsResponse  `protobuf:"bytes,19,opt,name=listDatasetShardsResponse" json:"listDatasetShardsResponse,omitempty"`
+	ReadLogRequest             *ReadLogRequest             `protobuf:"bytes,20,opt,name=readLogRequest" json:"readLogRequest,omitempty"`
+	ReadLogResponse            *ReadLogResponse            `protobuf:"bytes,21,opt,name=readLogResponse" json:"readLogResponse,omitempty"`
+}
+
+func (m *ControlMessage) Reset()                    { *m = ControlMessage{} }
+func (m *ControlMessage) String() string            { return proto.CompactTextString(m) }
+func (*ControlMessage) ProtoMessage()               {}
+func (*ControlMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *ControlMessage) GetIsOnDiskIO() bool {
+	if m != nil {
+		return m.IsOnDiskIO
+	}
+	return false
+}
+
+func (m *ControlMessage) GetStartRequest() *StartRequest {
+	if m != nil {
+		return m.StartRequest
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetStartResponse() *StartResponse {
+	if m != nil {
+		return m.StartResponse
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetStopRequest() *StopRequest {
+	if m != nil {
+		return m.StopRequest
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetStopResponse() *StopResponse {
+	if m != nil {
+		return m.StopResponse
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetGetStatusRequest() *GetStatusRequest {
+	if m != nil {
+		return m.GetStatusRequest
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetGetStatusResponse() *GetStatusResponse {
+	if m != nil {
+		return m.GetStatusResponse
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetDeleteDatasetShardRequest() *DeleteDatasetShardRequest {
+	if m != nil {
+		return m.DeleteDatasetShardRequest
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetDeleteDatasetShardResponse() *DeleteDatasetShardResponse {
+	if m != nil {
+		return m.DeleteDatasetShardResponse
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetLocalStatusReportRequest() *LocalStatusReportRequest {
+	if m != nil {
+		return m.LocalStatusReportRequest
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetLocalStatusReportResponse() *LocalStatusReportResponse {
+	if m != nil {
+		return m.LocalStatusReportResponse
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetReadRequest() *ReadRequest {
+	if m != nil {
+		return m.ReadRequest
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetWriteRequest() *WriteRequest {
+	if m != nil {
+		return m.WriteRequest
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *ControlMessage) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *ControlMessage) GetDrainRequest() *DrainRequest {
+	if m != nil {
+		return m.DrainRequest
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetDrainResponse() *DrainResponse {
+	if m != nil {
+		return m.DrainResponse
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetListDatasetShardsRequest() *ListDatasetShardsRequest {
+	if m != nil {
+		return m.ListDatasetShardsRequest
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetListDatasetShardsResponse() *ListDatasetShardsResponse {
+	if m != nil {
+		return m.ListDatasetShardsResponse
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetReadLogRequest() *ReadLogRequest {
+	if m != nil {
+		return m.ReadLogRequest
+	}
+	return nil
+}
+
+func (m *ControlMessage) GetReadLogResponse() *ReadLogResponse {
+	if m != nil {
+		return m.ReadLogResponse
+	}
+	return nil
+}
+
+type NetChan struct {
+	Server string `protobuf:"bytes,1,opt,name=server" json:"server,omitempty"`
+	Port   int32  `protobuf:"varint,2,opt,name=port" json:"port,omitempty"`
+}
+
+func (m *NetChan) Reset()                    { *m = NetChan{} }
+func (m *NetChan) String() string            { return proto.CompactTextString(m) }
+func (*NetChan) ProtoMessage()               {}
+func (*NetChan) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *NetChan) GetServer() string {
+	if m != nil {
+		return m.Server
+	}
+	return ""
+}
+
+func (m *NetChan) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+type StartResponse struct {
+	Pid     int32      `protobuf:"varint,1,opt,name=pid" json:"pid,omitempty"`
+	Error   string     `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+	Stderr  *NetChan   `protobuf:"bytes,3,opt,name=stderr" json:"stderr,omitempty"`
+	Outputs []*NetChan `protobuf:"bytes,4,rep,name=outputs" json:"outputs,omitempty"`
+}
+
+func (m *StartResponse) Reset()                    { *m = StartResponse{} }
+func (m *StartResponse) String() string            { return proto.CompactTextString(m) }
+func (*StartResponse) ProtoMessage()               {}
+func (*StartResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *StartResponse) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *StartResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *StartResponse) GetStderr() *NetChan {
+	if m != nil {
+		return m.Stderr
+	}
+	return nil
+}
+
+func (m *StartResponse) GetOutputs() []*NetChan {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+type StopResponse struct {
+	StartRequestHash uint32 `protobuf:"varint,1,opt,name=startRequestHash" json:"startRequestHash,omitempty"`
+	Error            string `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *StopResponse) Reset()                    { *m = StopResponse{} }
+func (m *StopResponse) String() string            { return proto.CompactTextString(m) }
+func (*StopResponse) ProtoMessage()               {}
+func (*StopResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *StopResponse) GetStartRequestHash() uint32 {
+	if m != nil {
+		return m.StartRequestHash
+	}
+	return 0
+}
+
+func (m *StopResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type DrainRequest struct {
+	MigrateShards  bool  `protobuf:"varint,1,opt,name=migrateShards" json:"migrateShards,omitempty"`
+	TimeoutSeconds int64 `protobuf:"varint,2,opt,name=timeoutSeconds" json:"timeoutSeconds,omitempty"`
+}
+
+func (m *DrainRequest) Reset()                    { *m = DrainRequest{} }
+func (m *DrainRequest) String() string            { return proto.CompactTextString(m) }
+func (*DrainRequest) ProtoMessage()               {}
+func (*DrainRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *DrainRequest) GetMigrateShards() bool {
+	if m != nil {
+		return m.MigrateShards
+	}
+	return false
+}
+
+func (m *DrainRequest) GetTimeoutSeconds() int64 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+type DrainResponse struct {
+	MigratedShards []*DataLocation `protobuf:"bytes,1,rep,name=migratedShards" json:"migratedShards,omitempty"`
+	Error          string          `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *DrainResponse) Reset()                    { *m = DrainResponse{} }
+func (m *DrainResponse) String() string            { return proto.CompactTextString(m) }
+func (*DrainResponse) ProtoMessage()               {}
+func (*DrainResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *DrainResponse) GetMigratedShards() []*DataLocation {
+	if m != nil {
+		return m.MigratedShards
+	}
+	return nil
+}
+
+func (m *DrainResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ListDatasetShardsRequest struct {
+}
+
+func (m *ListDatasetShardsRequest) Reset()                    { *m = ListDatasetShardsRequest{} }
+func (m *ListDatasetShardsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListDatasetShardsRequest) ProtoMessage()               {}
+func (*ListDatasetShardsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+type ListDatasetShardsResponse struct {
+	Shards []*DatasetShardStatus `protobuf:"bytes,1,rep,name=shards" json:"shards,omitempty"`
+}
+
+func (m *ListDatasetShardsResponse) Reset()                    { *m = ListDatasetShardsResponse{} }
+func (m *ListDatasetShardsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListDatasetShardsResponse) ProtoMessage()               {}
+func (*ListDatasetShardsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *ListDatasetShardsResponse) GetShards() []*DatasetShardStatus {
+	if m != nil {
+		return m.Shards
+	}
+	return nil
+}
+
+type DatasetShardStatus struct {
+	Name          string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	OnDisk        bool   `protobuf:"varint,2,opt,name=onDisk" json:"onDisk,omitempty"`
+	Size          int64  `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
+	Compression   string `protobuf:"bytes,4,opt,name=compression" json:"compression,omitempty"`
+	CreateTime    int64  `protobuf:"varint,5,opt,name=createTime" json:"createTime,omitempty"`
+	LastWriteTime int64  `protobuf:"varint,6,opt,name=lastWriteTime" json:"lastWriteTime,omitempty"`
+	LastReadTime  int64  `protobuf:"varint,7,opt,name=lastReadTime" json:"lastReadTime,omitempty"`
+}
+
+func (m *DatasetShardStatus) Reset()                    { *m = DatasetShardStatus{} }
+func (m *DatasetShardStatus) String() string            { return proto.CompactTextString(m) }
+func (*DatasetShardStatus) ProtoMessage()               {}
+func (*DatasetShardStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *DatasetShardStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DatasetShardStatus) GetOnDisk() bool {
+	if m != nil {
+		return m.OnDisk
+	}
+	return false
+}
+
+func (m *DatasetShardStatus) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *DatasetShardStatus) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
+func (m *DatasetShardStatus) GetCreateTime() int64 {
+	if m != nil {
+		return m.CreateTime
+	}
+	return 0
+}
+
+func (m *DatasetShardStatus) GetLastWriteTime() int64 {
+	if m != nil {
+		return m.LastWriteTime
+	}
+	return 0
+}
+
+func (m *DatasetShardStatus) GetLastReadTime() int64 {
+	if m != nil {
+		return m.LastReadTime
+	}
+	return 0
+}
+
+// logs of the executors of a flow, kept by the agent
+type ReadLogRequest struct {
+	FlowHashCode uint32  `protobuf:"varint,1,opt,name=flowHashCode" json:"flowHashCode,omitempty"`
+	TaskGroupIds []int32 `protobuf:"varint,2,rep,packed,name=taskGroupIds" json:"taskGroupIds,omitempty"`
+	TailBytes    int64   `protobuf:"varint,3,opt,name=tailBytes" json:"tailBytes,omitempty"`
+}
+
+func (m *ReadLogRequest) Reset()                    { *m = ReadLogRequest{} }
+func (m *ReadLogRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReadLogRequest) ProtoMessage()               {}
+func (*ReadLogRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *ReadLogRequest) GetFlowHashCode() uint32 {
+	if m != nil {
+		return m.FlowHashCode
+	}
+	return 0
+}
+
+func (m *ReadLogRequest) GetTaskGroupIds() []int32 {
+	if m != nil {
+		return m.TaskGroupIds
+	}
+	return nil
+}
+
+func (m *ReadLogRequest) GetTailBytes() int64 {
+	if m != nil {
+		return m.TailBytes
+	}
+	return 0
+}
+
+type ReadLogResponse struct {
+	Logs  []*ExecutorLog `protobuf:"bytes,1,rep,name=logs" json:"logs,omitempty"`
+	Error string         `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *ReadLogResponse) Reset()                    { *m = ReadLogResponse{} }
+func (m *ReadLogResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReadLogResponse) ProtoMessage()               {}
+func (*ReadLogResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *ReadLogResponse) GetLogs() []*ExecutorLog {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+func (m *ReadLogResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ExecutorLog struct {
+	TaskGroupId   int32  `protobuf:"varint,1,opt,name=taskGroupId" json:"taskGroupId,omitempty"`
+	Name          string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Size          int64  `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
+	LastWriteTime int64  `protobuf:"varint,4,opt,name=lastWriteTime" json:"lastWriteTime,omitempty"`
+	Content       []byte `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (m *ExecutorLog) Reset()                    { *m = ExecutorLog{} }
+func (m *ExecutorLog) String() string            { return proto.CompactTextString(m) }
+func (*ExecutorLog) ProtoMessage()               {}
+func (*ExecutorLog) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *ExecutorLog) GetTaskGroupId() int32 {
+	if m != nil {
+		return m.TaskGroupId
+	}
+	return 0
+}
+
+func (m *ExecutorLog) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ExecutorLog) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *ExecutorLog) GetLastWriteTime() int64 {
+	if m != nil {
+		return m.LastWriteTime
+	}
+	return 0
+}
+
+func (m *ExecutorLog) GetContent() []byte {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+type GetStatusRequest struct {
+	StartRequestHash uint32 `protobuf:"varint,1,opt,name=startRequestHash" json:"startRequestHash,omitempty"`
+	ClearProfiles    bool   `protobuf:"varint,2,opt,name=clearProfiles" json:"clearProfiles,omitempty"`
+}
+
+func (m *GetStatusRequest) Reset()                    { *m = GetStatusRequest{} }
+func (m *GetStatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetStatusRequest) ProtoMessage()               {}
+func (*GetStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *GetStatusRequest) GetStartRequestHash() uint32 {
+	if m != nil {
+		return m.StartRequestHash
+	}
+	return 0
+}
+
+func (m *GetStatusRequest) GetClearProfiles() bool {
+	if m != nil {
+		return m.ClearProfiles
+	}
+	return false
+}
+
+type ChannelStatus struct {
+	Length      int64  `protobuf:"varint,1,opt,name=length" json:"length,omitempty"`
+	StartTime   int64  `protobuf:"varint,2,opt,name=startTime" json:"startTime,omitempty"`
+	StopTime    int64  `protobuf:"varint,3,opt,name=stopTime" json:"stopTime,omitempty"`
+	Name        string `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
+	StartTimeNs int64  `protobuf:"varint,5,opt,name=startTimeNs" json:"startTimeNs,omitempty"`
+	StopTimeNs  int64  `protobuf:"varint,6,opt,name=stopTimeNs" json:"stopTimeNs,omitempty"`
+}
+
+func (m *ChannelStatus) Reset()                    { *m = ChannelStatus{} }
+func (m *ChannelStatus) String() string            { return proto.CompactTextString(m) }
+func (*ChannelStatus) ProtoMessage()               {}
+func (*ChannelStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *ChannelStatus) GetLength() int64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *ChannelStatus) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
 	}
 	return 0
 }
@@ -590,20 +2140,40 @@ func (m *ChannelStatus) GetName() string {
 	return ""
 }
 
+func (m *ChannelStatus) GetStartTimeNs() int64 {
+	if m != nil {
+		return m.StartTimeNs
+	}
+	return 0
+}
+
+func (m *ChannelStatus) GetStopTimeNs() int64 {
+	if m != nil {
+		return m.StopTimeNs
+	}
+	return 0
+}
+
 type GetStatusResponse struct {
-	StartRequestHash uint32           `protobuf:"varint,1,opt,name=startRequestHash" json:"startRequestHash,omitempty"`
-	Error            string           `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
-	InputStatuses    []*ChannelStatus `protobuf:"bytes,3,rep,name=inputStatuses" json:"inputStatuses,omitempty"`
-	OutputStatuses   []*ChannelStatus `protobuf:"bytes,4,rep,name=outputStatuses" json:"outputStatuses,omitempty"`
-	RequestTime      int64            `protobuf:"varint,5,opt,name=requestTime" json:"requestTime,omitempty"`
-	StartTime        int64            `protobuf:"varint,6,opt,name=startTime" json:"startTime,omitempty"`
-	StopTime         int64            `protobuf:"varint,7,opt,name=stopTime" json:"stopTime,omitempty"`
+	StartRequestHash  uint32             `protobuf:"varint,1,opt,name=startRequestHash" json:"startRequestHash,omitempty"`
+	Error             string             `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+	InputStatuses     []*ChannelStatus   `protobuf:"bytes,3,rep,name=inputStatuses" json:"inputStatuses,omitempty"`
+	OutputStatuses    []*ChannelStatus   `protobuf:"bytes,4,rep,name=outputStatuses" json:"outputStatuses,omitempty"`
+	RequestTime       int64              `protobuf:"varint,5,opt,name=requestTime" json:"requestTime,omitempty"`
+	StartTime         int64              `protobuf:"varint,6,opt,name=startTime" json:"startTime,omitempty"`
+	StopTime          int64              `protobuf:"varint,7,opt,name=stopTime" json:"stopTime,omitempty"`
+	MemoryMb          int64              `protobuf:"varint,8,opt,name=memoryMb" json:"memoryMb,omitempty"`
+	PeakMemoryMb      int64              `protobuf:"varint,9,opt,name=peakMemoryMb" json:"peakMemoryMb,omitempty"`
+	AllocatedMemoryMb int64              `protobuf:"varint,10,opt,name=allocatedMemoryMb" json:"allocatedMemoryMb,omitempty"`
+	InstructionStats  []*InstructionStat `protobuf:"bytes,11,rep,name=instructionStats" json:"instructionStats,omitempty"`
+	Profiles          []*Profile         `protobuf:"bytes,12,rep,name=profiles" json:"profiles,omitempty"`
+	Preempted         bool               `protobuf:"varint,13,opt,name=preempted" json:"preempted,omitempty"`
 }
 
 func (m *GetStatusResponse) Reset()                    { *m = GetStatusResponse{} }
 func (m *GetStatusResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetStatusResponse) ProtoMessage()               {}
-func (*GetStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*GetStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
 
 func (m *GetStatusResponse) GetStartRequestHash() uint32 {
 	if m != nil {
@@ -654,6 +2224,48 @@ func (m *GetStatusResponse) GetStopTime() int64 {
 	return 0
 }
 
+func (m *GetStatusResponse) GetMemoryMb() int64 {
+	if m != nil {
+		return m.MemoryMb
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetPeakMemoryMb() int64 {
+	if m != nil {
+		return m.PeakMemoryMb
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetAllocatedMemoryMb() int64 {
+	if m != nil {
+		return m.AllocatedMemoryMb
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetInstructionStats() []*InstructionStat {
+	if m != nil {
+		return m.InstructionStats
+	}
+	return nil
+}
+
+func (m *GetStatusResponse) GetProfiles() []*Profile {
+	if m != nil {
+		return m.Profiles
+	}
+	return nil
+}
+
+func (m *GetStatusResponse) GetPreempted() bool {
+	if m != nil {
+		return m.Preempted
+	}
+	return false
+}
+
 type DeleteDatasetShardRequest struct {
 	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 }
@@ -661,7 +2273,7 @@ type DeleteDatasetShardRequest struct {
 func (m *DeleteDatasetShardRequest) Reset()                    { *m = DeleteDatasetShardRequest{} }
 func (m *DeleteDatasetShardRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteDatasetShardRequest) ProtoMessage()               {}
-func (*DeleteDatasetShardRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*DeleteDatasetShardRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
 
 func (m *DeleteDatasetShardRequest) GetName() string {
 	if m != nil {
@@ -677,7 +2289,7 @@ type DeleteDatasetShardResponse struct {
 func (m *DeleteDatasetShardResponse) Reset()                    { *m = DeleteDatasetShardResponse{} }
 func (m *DeleteDatasetShardResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteDatasetShardResponse) ProtoMessage()               {}
-func (*DeleteDatasetShardResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*DeleteDatasetShardResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
 
 func (m *DeleteDatasetShardResponse) GetError() string {
 	if m != nil {
@@ -687,16 +2299,18 @@ func (m *DeleteDatasetShardResponse) GetError() string {
 }
 
 type LocalStatusReportRequest struct {
-	StartRequestHash uint32           `protobuf:"varint,1,opt,name=startRequestHash" json:"startRequestHash,omitempty"`
-	Error            string           `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
-	InputStatuses    []*ChannelStatus `protobuf:"bytes,3,rep,name=inputStatuses" json:"inputStatuses,omitempty"`
-	OutputStatuses   []*ChannelStatus `protobuf:"bytes,4,rep,name=outputStatuses" json:"outputStatuses,omitempty"`
+	StartRequestHash uint32             `protobuf:"varint,1,opt,name=startRequestHash" json:"startRequestHash,omitempty"`
+	Error            string             `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+	InputStatuses    []*ChannelStatus   `protobuf:"bytes,3,rep,name=inputStatuses" json:"inputStatuses,omitempty"`
+	OutputStatuses   []*ChannelStatus   `protobuf:"bytes,4,rep,name=outputStatuses" json:"outputStatuses,omitempty"`
+	InstructionStats []*InstructionStat `protobuf:"bytes,5,rep,name=instructionStats" json:"instructionStats,omitempty"`
+	Profiles         []*Profile         `protobuf:"bytes,6,rep,name=profiles" json:"profiles,omitempty"`
 }
 
 func (m *LocalStatusReportRequest) Reset()                    { *m = LocalStatusReportRequest{} }
 func (m *LocalStatusReportRequest) String() string            { return proto.CompactTextString(m) }
 func (*LocalStatusReportRequest) ProtoMessage()               {}
-func (*LocalStatusReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*LocalStatusReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
 
 func (m *LocalStatusReportRequest) GetStartRequestHash() uint32 {
 	if m != nil {
@@ -712,18 +2326,162 @@ func (m *LocalStatusReportRequest) GetError() string {
 	return ""
 }
 
-func (m *LocalStatusReportRequest) GetInputStatuses() []*ChannelStatus {
+func (m *LocalStatusReportRequest) GetInputStatuses() []*ChannelStatus {
+	if m != nil {
+		return m.InputStatuses
+	}
+	return nil
+}
+
+func (m *LocalStatusReportRequest) GetOutputStatuses() []*ChannelStatus {
+	if m != nil {
+		return m.OutputStatuses
+	}
+	return nil
+}
+
+func (m *LocalStatusReportRequest) GetInstructionStats() []*InstructionStat {
+	if m != nil {
+		return m.InstructionStats
+	}
+	return nil
+}
+
+func (m *LocalStatusReportRequest) GetProfiles() []*Profile {
+	if m != nil {
+		return m.Profiles
+	}
+	return nil
+}
+
+// a pprof profile of an executor
+type Profile struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *Profile) Reset()                    { *m = Profile{} }
+func (m *Profile) String() string            { return proto.CompactTextString(m) }
+func (*Profile) ProtoMessage()               {}
+func (*Profile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *Profile) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Profile) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// metrics of running one instruction for a task
+type InstructionStat struct {
+	StepId      int32  `protobuf:"varint,1,opt,name=stepId" json:"stepId,omitempty"`
+	TaskId      int32  `protobuf:"varint,2,opt,name=taskId" json:"taskId,omitempty"`
+	Name        string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	InputRows   int64  `protobuf:"varint,4,opt,name=inputRows" json:"inputRows,omitempty"`
+	InputBytes  int64  `protobuf:"varint,5,opt,name=inputBytes" json:"inputBytes,omitempty"`
+	OutputRows  int64  `protobuf:"varint,6,opt,name=outputRows" json:"outputRows,omitempty"`
+	OutputBytes int64  `protobuf:"varint,7,opt,name=outputBytes" json:"outputBytes,omitempty"`
+	WallTime    int64  `protobuf:"varint,8,opt,name=wallTime" json:"wallTime,omitempty"`
+	CpuTime     int64  `protobuf:"varint,9,opt,name=cpuTime" json:"cpuTime,omitempty"`
+	SpillBytes  int64  `protobuf:"varint,10,opt,name=spillBytes" json:"spillBytes,omitempty"`
+	StartTime   int64  `protobuf:"varint,11,opt,name=startTime" json:"startTime,omitempty"`
+	StopTime    int64  `protobuf:"varint,12,opt,name=stopTime" json:"stopTime,omitempty"`
+}
+
+func (m *InstructionStat) Reset()                    { *m = InstructionStat{} }
+func (m *InstructionStat) String() string            { return proto.CompactTextString(m) }
+func (*InstructionStat) ProtoMessage()               {}
+func (*InstructionStat) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *InstructionStat) GetStepId() int32 {
+	if m != nil {
+		return m.StepId
+	}
+	return 0
+}
+
+func (m *InstructionStat) GetTaskId() int32 {
+	if m != nil {
+		return m.TaskId
+	}
+	return 0
+}
+
+func (m *InstructionStat) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *InstructionStat) GetInputRows() int64 {
+	if m != nil {
+		return m.InputRows
+	}
+	return 0
+}
+
+func (m *InstructionStat) GetInputBytes() int64 {
+	if m != nil {
+		return m.InputBytes
+	}
+	return 0
+}
+
+func (m *InstructionStat) GetOutputRows() int64 {
+	if m != nil {
+		return m.OutputRows
+	}
+	return 0
+}
+
+func (m *InstructionStat) GetOutputBytes() int64 {
+	if m != nil {
+		return m.OutputBytes
+	}
+	return 0
+}
+
+func (m *InstructionStat) GetWallTime() int64 {
+	if m != nil {
+		return m.WallTime
+	}
+	return 0
+}
+
+func (m *InstructionStat) GetCpuTime() int64 {
+	if m != nil {
+		return m.CpuTime
+	}
+	return 0
+}
+
+func (m *InstructionStat) GetSpillBytes() int64 {
+	if m != nil {
+		return m.SpillBytes
+	}
+	return 0
+}
+
+func (m *InstructionStat) GetStartTime() int64 {
 	if m != nil {
-		return m.InputStatuses
+		return m.StartTime
 	}
-	return nil
+	return 0
 }
 
-func (m *LocalStatusReportRequest) GetOutputStatuses() []*ChannelStatus {
+func (m *InstructionStat) GetStopTime() int64 {
 	if m != nil {
-		return m.OutputStatuses
+		return m.StopTime
 	}
-	return nil
+	return 0
 }
 
 type LocalStatusReportResponse struct {
@@ -733,7 +2491,7 @@ type LocalStatusReportResponse struct {
 func (m *LocalStatusReportResponse) Reset()                    { *m = LocalStatusReportResponse{} }
 func (m *LocalStatusReportResponse) String() string            { return proto.CompactTextString(m) }
 func (*LocalStatusReportResponse) ProtoMessage()               {}
-func (*LocalStatusReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*LocalStatusReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
 
 func (m *LocalStatusReportResponse) GetError() string {
 	if m != nil {
@@ -746,12 +2504,13 @@ type WriteRequest struct {
 	ChannelName string `protobuf:"bytes,1,opt,name=channelName" json:"channelName,omitempty"`
 	WriterName  string `protobuf:"bytes,2,opt,name=writerName" json:"writerName,omitempty"`
 	ReaderCount int32  `protobuf:"varint,3,opt,name=readerCount" json:"readerCount,omitempty"`
+	Compression string `protobuf:"bytes,4,opt,name=compression" json:"compression,omitempty"`
 }
 
 func (m *WriteRequest) Reset()                    { *m = WriteRequest{} }
 func (m *WriteRequest) String() string            { return proto.CompactTextString(m) }
 func (*WriteRequest) ProtoMessage()               {}
-func (*WriteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*WriteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
 
 func (m *WriteRequest) GetChannelName() string {
 	if m != nil {
@@ -774,15 +2533,23 @@ func (m *WriteRequest) GetReaderCount() int32 {
 	return 0
 }
 
+func (m *WriteRequest) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
 type ReadRequest struct {
 	ChannelName string `protobuf:"bytes,1,opt,name=channelName" json:"channelName,omitempty"`
 	ReaderName  string `protobuf:"bytes,2,opt,name=readerName" json:"readerName,omitempty"`
+	Compression string `protobuf:"bytes,3,opt,name=compression" json:"compression,omitempty"`
 }
 
 func (m *ReadRequest) Reset()                    { *m = ReadRequest{} }
 func (m *ReadRequest) String() string            { return proto.CompactTextString(m) }
 func (*ReadRequest) ProtoMessage()               {}
-func (*ReadRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*ReadRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
 
 func (m *ReadRequest) GetChannelName() string {
 	if m != nil {
@@ -798,6 +2565,13 @@ func (m *ReadRequest) GetReaderName() string {
 	return ""
 }
 
+func (m *ReadRequest) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
 type StartRequest struct {
 	Instructions *InstructionSet  `protobuf:"bytes,1,opt,name=instructions" json:"instructions,omitempty"`
 	Files        []string         `protobuf:"bytes,2,rep,name=files" json:"files,omitempty"`
@@ -806,12 +2580,18 @@ type StartRequest struct {
 	Host         string           `protobuf:"bytes,5,opt,name=host" json:"host,omitempty"`
 	Port         int32            `protobuf:"varint,6,opt,name=port" json:"port,omitempty"`
 	Name         string           `protobuf:"bytes,7,opt,name=name" json:"name,omitempty"`
+	Envs         []string         `protobuf:"bytes,8,rep,name=envs" json:"envs,omitempty"`
+	Config       []string         `protobuf:"bytes,9,rep,name=config" json:"config,omitempty"`
+	Queue        string           `protobuf:"bytes,10,opt,name=queue" json:"queue,omitempty"`
+	LeaseId      uint64           `protobuf:"varint,11,opt,name=leaseId" json:"leaseId,omitempty"`
+	TaskGroupId  int32            `protobuf:"varint,12,opt,name=taskGroupId" json:"taskGroupId,omitempty"`
+	Restartable  bool             `protobuf:"varint,13,opt,name=restartable" json:"restartable,omitempty"`
 }
 
 func (m *StartRequest) Reset()                    { *m = StartRequest{} }
 func (m *StartRequest) String() string            { return proto.CompactTextString(m) }
 func (*StartRequest) ProtoMessage()               {}
-func (*StartRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*StartRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
 
 func (m *StartRequest) GetInstructions() *InstructionSet {
 	if m != nil {
@@ -862,6 +2642,48 @@ func (m *StartRequest) GetName() string {
 	return ""
 }
 
+func (m *StartRequest) GetEnvs() []string {
+	if m != nil {
+		return m.Envs
+	}
+	return nil
+}
+
+func (m *StartRequest) GetConfig() []string {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *StartRequest) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *StartRequest) GetLeaseId() uint64 {
+	if m != nil {
+		return m.LeaseId
+	}
+	return 0
+}
+
+func (m *StartRequest) GetTaskGroupId() int32 {
+	if m != nil {
+		return m.TaskGroupId
+	}
+	return 0
+}
+
+func (m *StartRequest) GetRestartable() bool {
+	if m != nil {
+		return m.Restartable
+	}
+	return false
+}
+
 type StopRequest struct {
 	StartRequestHash uint32 `protobuf:"varint,1,opt,name=startRequestHash" json:"startRequestHash,omitempty"`
 }
@@ -869,7 +2691,7 @@ type StopRequest struct {
 func (m *StopRequest) Reset()                    { *m = StopRequest{} }
 func (m *StopRequest) String() string            { return proto.CompactTextString(m) }
 func (*StopRequest) ProtoMessage()               {}
-func (*StopRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*StopRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
 
 func (m *StopRequest) GetStartRequestHash() uint32 {
 	if m != nil {
@@ -883,12 +2705,13 @@ type InstructionSet struct {
 	ReaderCount  int32          `protobuf:"varint,2,opt,name=readerCount" json:"readerCount,omitempty"`
 	FlowHashCode uint32         `protobuf:"varint,3,opt,name=flowHashCode" json:"flowHashCode,omitempty"`
 	IsProfiling  bool           `protobuf:"varint,4,opt,name=isProfiling" json:"isProfiling,omitempty"`
+	Compression  string         `protobuf:"bytes,5,opt,name=compression" json:"compression,omitempty"`
 }
 
 func (m *InstructionSet) Reset()                    { *m = InstructionSet{} }
 func (m *InstructionSet) String() string            { return proto.CompactTextString(m) }
 func (*InstructionSet) ProtoMessage()               {}
-func (*InstructionSet) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*InstructionSet) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
 
 func (m *InstructionSet) GetInstructions() []*Instruction {
 	if m != nil {
@@ -918,6 +2741,13 @@ func (m *InstructionSet) GetIsProfiling() bool {
 	return false
 }
 
+func (m *InstructionSet) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
 type Instruction struct {
 	Name                     string                    `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	InputShardLocations      []*DatasetShardLocation   `protobuf:"bytes,2,rep,name=inputShardLocations" json:"inputShardLocations,omitempty"`
@@ -937,12 +2767,14 @@ type Instruction struct {
 	LocalSort                *LocalSort                `protobuf:"bytes,16,opt,name=localSort" json:"localSort,omitempty"`
 	AdapterSplitReader       *AdapterSplitReader       `protobuf:"bytes,17,opt,name=adapterSplitReader" json:"adapterSplitReader,omitempty"`
 	MergeSortedTo            *MergeSortedTo            `protobuf:"bytes,18,opt,name=mergeSortedTo" json:"mergeSortedTo,omitempty"`
+	StepId                   int32                     `protobuf:"varint,19,opt,name=stepId" json:"stepId,omitempty"`
+	TaskId                   int32                     `protobuf:"varint,20,opt,name=taskId" json:"taskId,omitempty"`
 }
 
 func (m *Instruction) Reset()                    { *m = Instruction{} }
 func (m *Instruction) String() string            { return proto.CompactTextString(m) }
 func (*Instruction) ProtoMessage()               {}
-func (*Instruction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*Instruction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
 
 func (m *Instruction) GetName() string {
 	if m != nil {
@@ -1070,6 +2902,20 @@ func (m *Instruction) GetMergeSortedTo() *MergeSortedTo {
 	return nil
 }
 
+func (m *Instruction) GetStepId() int32 {
+	if m != nil {
+		return m.StepId
+	}
+	return 0
+}
+
+func (m *Instruction) GetTaskId() int32 {
+	if m != nil {
+		return m.TaskId
+	}
+	return 0
+}
+
 type ScatterPartitions struct {
 	Indexes []int32 `protobuf:"varint,1,rep,packed,name=indexes" json:"indexes,omitempty"`
 }
@@ -1077,7 +2923,7 @@ type ScatterPartitions struct {
 func (m *ScatterPartitions) Reset()                    { *m = ScatterPartitions{} }
 func (m *ScatterPartitions) String() string            { return proto.CompactTextString(m) }
 func (*ScatterPartitions) ProtoMessage()               {}
-func (*ScatterPartitions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*ScatterPartitions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
 
 func (m *ScatterPartitions) GetIndexes() []int32 {
 	if m != nil {
@@ -1092,7 +2938,7 @@ type RoundRobin struct {
 func (m *RoundRobin) Reset()                    { *m = RoundRobin{} }
 func (m *RoundRobin) String() string            { return proto.CompactTextString(m) }
 func (*RoundRobin) ProtoMessage()               {}
-func (*RoundRobin) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*RoundRobin) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
 
 type CollectPartitions struct {
 }
@@ -1100,7 +2946,7 @@ type CollectPartitions struct {
 func (m *CollectPartitions) Reset()                    { *m = CollectPartitions{} }
 func (m *CollectPartitions) String() string            { return proto.CompactTextString(m) }
 func (*CollectPartitions) ProtoMessage()               {}
-func (*CollectPartitions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*CollectPartitions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
 
 type LocalSort struct {
 	OrderBys []*OrderBy `protobuf:"bytes,1,rep,name=orderBys" json:"orderBys,omitempty"`
@@ -1109,7 +2955,7 @@ type LocalSort struct {
 func (m *LocalSort) Reset()                    { *m = LocalSort{} }
 func (m *LocalSort) String() string            { return proto.CompactTextString(m) }
 func (*LocalSort) ProtoMessage()               {}
-func (*LocalSort) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*LocalSort) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
 
 func (m *LocalSort) GetOrderBys() []*OrderBy {
 	if m != nil {
@@ -1126,7 +2972,7 @@ type LocalTop struct {
 func (m *LocalTop) Reset()                    { *m = LocalTop{} }
 func (m *LocalTop) String() string            { return proto.CompactTextString(m) }
 func (*LocalTop) ProtoMessage()               {}
-func (*LocalTop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*LocalTop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
 
 func (m *LocalTop) GetN() int32 {
 	if m != nil {
@@ -1149,7 +2995,7 @@ type MergeSortedTo struct {
 func (m *MergeSortedTo) Reset()                    { *m = MergeSortedTo{} }
 func (m *MergeSortedTo) String() string            { return proto.CompactTextString(m) }
 func (*MergeSortedTo) ProtoMessage()               {}
-func (*MergeSortedTo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*MergeSortedTo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
 
 func (m *MergeSortedTo) GetOrderBys() []*OrderBy {
 	if m != nil {
@@ -1166,7 +3012,7 @@ type OrderBy struct {
 func (m *OrderBy) Reset()                    { *m = OrderBy{} }
 func (m *OrderBy) String() string            { return proto.CompactTextString(m) }
 func (*OrderBy) ProtoMessage()               {}
-func (*OrderBy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*OrderBy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
 
 func (m *OrderBy) GetIndex() int32 {
 	if m != nil {
@@ -1191,7 +3037,7 @@ type JoinPartitionedSorted struct {
 func (m *JoinPartitionedSorted) Reset()                    { *m = JoinPartitionedSorted{} }
 func (m *JoinPartitionedSorted) String() string            { return proto.CompactTextString(m) }
 func (*JoinPartitionedSorted) ProtoMessage()               {}
-func (*JoinPartitionedSorted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*JoinPartitionedSorted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
 
 func (m *JoinPartitionedSorted) GetIndexes() []int32 {
 	if m != nil {
@@ -1221,7 +3067,7 @@ type CoGroupPartitionedSorted struct {
 func (m *CoGroupPartitionedSorted) Reset()                    { *m = CoGroupPartitionedSorted{} }
 func (m *CoGroupPartitionedSorted) String() string            { return proto.CompactTextString(m) }
 func (*CoGroupPartitionedSorted) ProtoMessage()               {}
-func (*CoGroupPartitionedSorted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (*CoGroupPartitionedSorted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
 
 func (m *CoGroupPartitionedSorted) GetIndexes() []int32 {
 	if m != nil {
@@ -1237,7 +3083,7 @@ type PipeAsArgs struct {
 func (m *PipeAsArgs) Reset()                    { *m = PipeAsArgs{} }
 func (m *PipeAsArgs) String() string            { return proto.CompactTextString(m) }
 func (*PipeAsArgs) ProtoMessage()               {}
-func (*PipeAsArgs) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*PipeAsArgs) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
 
 func (m *PipeAsArgs) GetCode() string {
 	if m != nil {
@@ -1256,7 +3102,7 @@ type Script struct {
 func (m *Script) Reset()                    { *m = Script{} }
 func (m *Script) String() string            { return proto.CompactTextString(m) }
 func (*Script) ProtoMessage()               {}
-func (*Script) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+func (*Script) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
 
 func (m *Script) GetIsPipe() bool {
 	if m != nil {
@@ -1293,7 +3139,7 @@ type InputSplitReader struct {
 func (m *InputSplitReader) Reset()                    { *m = InputSplitReader{} }
 func (m *InputSplitReader) String() string            { return proto.CompactTextString(m) }
 func (*InputSplitReader) ProtoMessage()               {}
-func (*InputSplitReader) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+func (*InputSplitReader) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
 
 func (m *InputSplitReader) GetInputType() string {
 	if m != nil {
@@ -1310,7 +3156,7 @@ type AdapterSplitReader struct {
 func (m *AdapterSplitReader) Reset()                    { *m = AdapterSplitReader{} }
 func (m *AdapterSplitReader) String() string            { return proto.CompactTextString(m) }
 func (*AdapterSplitReader) ProtoMessage()               {}
-func (*AdapterSplitReader) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+func (*AdapterSplitReader) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
 
 func (m *AdapterSplitReader) GetAdapterName() string {
 	if m != nil {
@@ -1332,7 +3178,7 @@ type Broadcast struct {
 func (m *Broadcast) Reset()                    { *m = Broadcast{} }
 func (m *Broadcast) String() string            { return proto.CompactTextString(m) }
 func (*Broadcast) ProtoMessage()               {}
-func (*Broadcast) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+func (*Broadcast) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
 
 type LocalHashAndJoinWith struct {
 	Indexes []int32 `protobuf:"varint,1,rep,packed,name=indexes" json:"indexes,omitempty"`
@@ -1341,7 +3187,7 @@ type LocalHashAndJoinWith struct {
 func (m *LocalHashAndJoinWith) Reset()                    { *m = LocalHashAndJoinWith{} }
 func (m *LocalHashAndJoinWith) String() string            { return proto.CompactTextString(m) }
 func (*LocalHashAndJoinWith) ProtoMessage()               {}
-func (*LocalHashAndJoinWith) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+func (*LocalHashAndJoinWith) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
 
 func (m *LocalHashAndJoinWith) GetIndexes() []int32 {
 	if m != nil {
@@ -1360,7 +3206,7 @@ type DatasetShard struct {
 func (m *DatasetShard) Reset()                    { *m = DatasetShard{} }
 func (m *DatasetShard) String() string            { return proto.CompactTextString(m) }
 func (*DatasetShard) ProtoMessage()               {}
-func (*DatasetShard) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+func (*DatasetShard) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
 
 func (m *DatasetShard) GetFlowName() string {
 	if m != nil {
@@ -1400,7 +3246,7 @@ type DatasetShardLocation struct {
 func (m *DatasetShardLocation) Reset()                    { *m = DatasetShardLocation{} }
 func (m *DatasetShardLocation) String() string            { return proto.CompactTextString(m) }
 func (*DatasetShardLocation) ProtoMessage()               {}
-func (*DatasetShardLocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+func (*DatasetShardLocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
 
 func (m *DatasetShardLocation) GetName() string {
 	if m != nil {
@@ -1432,24 +3278,63 @@ func (m *DatasetShardLocation) GetOnDisk() bool {
 
 func init() {
 	proto.RegisterType((*ComputeRequest)(nil), "pb.ComputeRequest")
+	proto.RegisterType((*HostPreference)(nil), "pb.HostPreference")
 	proto.RegisterType((*ComputeResource)(nil), "pb.ComputeResource")
 	proto.RegisterType((*DataResource)(nil), "pb.DataResource")
 	proto.RegisterType((*Location)(nil), "pb.Location")
 	proto.RegisterType((*Allocation)(nil), "pb.Allocation")
 	proto.RegisterType((*AllocationResult)(nil), "pb.AllocationResult")
+	proto.RegisterType((*DrainAgentRequest)(nil), "pb.DrainAgentRequest")
+	proto.RegisterType((*DrainAgentResponse)(nil), "pb.DrainAgentResponse")
+	proto.RegisterType((*TopologyRequest)(nil), "pb.TopologyRequest")
+	proto.RegisterType((*TopologyResponse)(nil), "pb.TopologyResponse")
+	proto.RegisterType((*DataCenterStatus)(nil), "pb.DataCenterStatus")
+	proto.RegisterType((*RackStatus)(nil), "pb.RackStatus")
+	proto.RegisterType((*AgentStatus)(nil), "pb.AgentStatus")
+	proto.RegisterType((*ListFlowsRequest)(nil), "pb.ListFlowsRequest")
+	proto.RegisterType((*ListFlowsResponse)(nil), "pb.ListFlowsResponse")
+	proto.RegisterType((*FlowStatus)(nil), "pb.FlowStatus")
+	proto.RegisterType((*FlowProgress)(nil), "pb.FlowProgress")
+	proto.RegisterType((*StepProgress)(nil), "pb.StepProgress")
+	proto.RegisterType((*DatasetProgress)(nil), "pb.DatasetProgress")
+	proto.RegisterType((*DatasetShardProgress)(nil), "pb.DatasetShardProgress")
+	proto.RegisterType((*TaskGroupProgress)(nil), "pb.TaskGroupProgress")
+	proto.RegisterType((*FlowProgressResponse)(nil), "pb.FlowProgressResponse")
+	proto.RegisterType((*ListHistoryRequest)(nil), "pb.ListHistoryRequest")
+	proto.RegisterType((*ListHistoryResponse)(nil), "pb.ListHistoryResponse")
+	proto.RegisterType((*GetHistoryRequest)(nil), "pb.GetHistoryRequest")
+	proto.RegisterType((*KillFlowRequest)(nil), "pb.KillFlowRequest")
+	proto.RegisterType((*KillFlowResponse)(nil), "pb.KillFlowResponse")
+	proto.RegisterType((*LeaseRequest)(nil), "pb.LeaseRequest")
+	proto.RegisterType((*LeaseResponse)(nil), "pb.LeaseResponse")
 	proto.RegisterType((*Heartbeat)(nil), "pb.Heartbeat")
+	proto.RegisterType((*AgentLoad)(nil), "pb.AgentLoad")
+	proto.RegisterType((*DiskUsage)(nil), "pb.DiskUsage")
+	proto.RegisterType((*QueueAllocation)(nil), "pb.QueueAllocation")
+	proto.RegisterType((*HeartbeatResponse)(nil), "pb.HeartbeatResponse")
+	proto.RegisterType((*PreemptRequest)(nil), "pb.PreemptRequest")
 	proto.RegisterType((*Empty)(nil), "pb.Empty")
 	proto.RegisterType((*DataLocation)(nil), "pb.DataLocation")
 	proto.RegisterType((*ControlMessage)(nil), "pb.ControlMessage")
 	proto.RegisterType((*NetChan)(nil), "pb.NetChan")
 	proto.RegisterType((*StartResponse)(nil), "pb.StartResponse")
 	proto.RegisterType((*StopResponse)(nil), "pb.StopResponse")
+	proto.RegisterType((*DrainRequest)(nil), "pb.DrainRequest")
+	proto.RegisterType((*DrainResponse)(nil), "pb.DrainResponse")
+	proto.RegisterType((*ListDatasetShardsRequest)(nil), "pb.ListDatasetShardsRequest")
+	proto.RegisterType((*ListDatasetShardsResponse)(nil), "pb.ListDatasetShardsResponse")
+	proto.RegisterType((*DatasetShardStatus)(nil), "pb.DatasetShardStatus")
+	proto.RegisterType((*ReadLogRequest)(nil), "pb.ReadLogRequest")
+	proto.RegisterType((*ReadLogResponse)(nil), "pb.ReadLogResponse")
+	proto.RegisterType((*ExecutorLog)(nil), "pb.ExecutorLog")
 	proto.RegisterType((*GetStatusRequest)(nil), "pb.GetStatusRequest")
 	proto.RegisterType((*ChannelStatus)(nil), "pb.ChannelStatus")
 	proto.RegisterType((*GetStatusResponse)(nil), "pb.GetStatusResponse")
 	proto.RegisterType((*DeleteDatasetShardRequest)(nil), "pb.DeleteDatasetShardRequest")
 	proto.RegisterType((*DeleteDatasetShardResponse)(nil), "pb.DeleteDatasetShardResponse")
 	proto.RegisterType((*LocalStatusReportRequest)(nil), "pb.LocalStatusReportRequest")
+	proto.RegisterType((*Profile)(nil), "pb.Profile")
+	proto.RegisterType((*InstructionStat)(nil), "pb.InstructionStat")
 	proto.RegisterType((*LocalStatusReportResponse)(nil), "pb.LocalStatusReportResponse")
 	proto.RegisterType((*WriteRequest)(nil), "pb.WriteRequest")
 	proto.RegisterType((*ReadRequest)(nil), "pb.ReadRequest")
@@ -1489,6 +3374,15 @@ const _ = grpc.SupportPackageIsVersion4
 type GleamMasterClient interface {
 	GetResources(ctx context.Context, in *ComputeRequest, opts ...grpc.CallOption) (*AllocationResult, error)
 	SendHeartbeat(ctx context.Context, opts ...grpc.CallOption) (GleamMaster_SendHeartbeatClient, error)
+	RenewLeases(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*LeaseResponse, error)
+	ReleaseLeases(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*LeaseResponse, error)
+	DrainAgent(ctx context.Context, in *DrainAgentRequest, opts ...grpc.CallOption) (*DrainAgentResponse, error)
+	GetTopology(ctx context.Context, in *TopologyRequest, opts ...grpc.CallOption) (*TopologyResponse, error)
+	ListFlows(ctx context.Context, in *ListFlowsRequest, opts ...grpc.CallOption) (*ListFlowsResponse, error)
+	KillFlow(ctx context.Context, in *KillFlowRequest, opts ...grpc.CallOption) (*KillFlowResponse, error)
+	ReportFlowProgress(ctx context.Context, in *FlowProgress, opts ...grpc.CallOption) (*FlowProgressResponse, error)
+	ListHistory(ctx context.Context, in *ListHistoryRequest, opts ...grpc.CallOption) (*ListHistoryResponse, error)
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*FlowProgress, error)
 }
 
 type gleamMasterClient struct {
@@ -1519,7 +3413,7 @@ func (c *gleamMasterClient) SendHeartbeat(ctx context.Context, opts ...grpc.Call
 
 type GleamMaster_SendHeartbeatClient interface {
 	Send(*Heartbeat) error
-	CloseAndRecv() (*Empty, error)
+	Recv() (*HeartbeatResponse, error)
 	grpc.ClientStream
 }
 
@@ -1531,22 +3425,109 @@ func (x *gleamMasterSendHeartbeatClient) Send(m *Heartbeat) error {
 	return x.ClientStream.SendMsg(m)
 }
 
-func (x *gleamMasterSendHeartbeatClient) CloseAndRecv() (*Empty, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(Empty)
+func (x *gleamMasterSendHeartbeatClient) Recv() (*HeartbeatResponse, error) {
+	m := new(HeartbeatResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
+func (c *gleamMasterClient) RenewLeases(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*LeaseResponse, error) {
+	out := new(LeaseResponse)
+	err := grpc.Invoke(ctx, "/pb.GleamMaster/RenewLeases", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gleamMasterClient) ReleaseLeases(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*LeaseResponse, error) {
+	out := new(LeaseResponse)
+	err := grpc.Invoke(ctx, "/pb.GleamMaster/ReleaseLeases", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gleamMasterClient) DrainAgent(ctx context.Context, in *DrainAgentRequest, opts ...grpc.CallOption) (*DrainAgentResponse, error) {
+	out := new(DrainAgentResponse)
+	err := grpc.Invoke(ctx, "/pb.GleamMaster/DrainAgent", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gleamMasterClient) GetTopology(ctx context.Context, in *TopologyRequest, opts ...grpc.CallOption) (*TopologyResponse, error) {
+	out := new(TopologyResponse)
+	err := grpc.Invoke(ctx, "/pb.GleamMaster/GetTopology", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gleamMasterClient) ListFlows(ctx context.Context, in *ListFlowsRequest, opts ...grpc.CallOption) (*ListFlowsResponse, error) {
+	out := new(ListFlowsResponse)
+	err := grpc.Invoke(ctx, "/pb.GleamMaster/ListFlows", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gleamMasterClient) KillFlow(ctx context.Context, in *KillFlowRequest, opts ...grpc.CallOption) (*KillFlowResponse, error) {
+	out := new(KillFlowResponse)
+	err := grpc.Invoke(ctx, "/pb.GleamMaster/KillFlow", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gleamMasterClient) ReportFlowProgress(ctx context.Context, in *FlowProgress, opts ...grpc.CallOption) (*FlowProgressResponse, error) {
+	out := new(FlowProgressResponse)
+	err := grpc.Invoke(ctx, "/pb.GleamMaster/ReportFlowProgress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gleamMasterClient) ListHistory(ctx context.Context, in *ListHistoryRequest, opts ...grpc.CallOption) (*ListHistoryResponse, error) {
+	out := new(ListHistoryResponse)
+	err := grpc.Invoke(ctx, "/pb.GleamMaster/ListHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gleamMasterClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*FlowProgress, error) {
+	out := new(FlowProgress)
+	err := grpc.Invoke(ctx, "/pb.GleamMaster/GetHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for GleamMaster service
 
 type GleamMasterServer interface {
 	GetResources(context.Context, *ComputeRequest) (*AllocationResult, error)
 	SendHeartbeat(GleamMaster_SendHeartbeatServer) error
+	RenewLeases(context.Context, *LeaseRequest) (*LeaseResponse, error)
+	ReleaseLeases(context.Context, *LeaseRequest) (*LeaseResponse, error)
+	DrainAgent(context.Context, *DrainAgentRequest) (*DrainAgentResponse, error)
+	GetTopology(context.Context, *TopologyRequest) (*TopologyResponse, error)
+	ListFlows(context.Context, *ListFlowsRequest) (*ListFlowsResponse, error)
+	KillFlow(context.Context, *KillFlowRequest) (*KillFlowResponse, error)
+	ReportFlowProgress(context.Context, *FlowProgress) (*FlowProgressResponse, error)
+	ListHistory(context.Context, *ListHistoryRequest) (*ListHistoryResponse, error)
+	GetHistory(context.Context, *GetHistoryRequest) (*FlowProgress, error)
 }
 
 func RegisterGleamMasterServer(s *grpc.Server, srv GleamMasterServer) {
@@ -1576,7 +3557,7 @@ func _GleamMaster_SendHeartbeat_Handler(srv interface{}, stream grpc.ServerStrea
 }
 
 type GleamMaster_SendHeartbeatServer interface {
-	SendAndClose(*Empty) error
+	Send(*HeartbeatResponse) error
 	Recv() (*Heartbeat, error)
 	grpc.ServerStream
 }
@@ -1585,7 +3566,7 @@ type gleamMasterSendHeartbeatServer struct {
 	grpc.ServerStream
 }
 
-func (x *gleamMasterSendHeartbeatServer) SendAndClose(m *Empty) error {
+func (x *gleamMasterSendHeartbeatServer) Send(m *HeartbeatResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
@@ -1597,6 +3578,168 @@ func (x *gleamMasterSendHeartbeatServer) Recv() (*Heartbeat, error) {
 	return m, nil
 }
 
+func _GleamMaster_RenewLeases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GleamMasterServer).RenewLeases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GleamMaster/RenewLeases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GleamMasterServer).RenewLeases(ctx, req.(*LeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GleamMaster_ReleaseLeases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GleamMasterServer).ReleaseLeases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GleamMaster/ReleaseLeases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GleamMasterServer).ReleaseLeases(ctx, req.(*LeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GleamMaster_DrainAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GleamMasterServer).DrainAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GleamMaster/DrainAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GleamMasterServer).DrainAgent(ctx, req.(*DrainAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GleamMaster_GetTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GleamMasterServer).GetTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GleamMaster/GetTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GleamMasterServer).GetTopology(ctx, req.(*TopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GleamMaster_ListFlows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFlowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GleamMasterServer).ListFlows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GleamMaster/ListFlows",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GleamMasterServer).ListFlows(ctx, req.(*ListFlowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GleamMaster_KillFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KillFlowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GleamMasterServer).KillFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GleamMaster/KillFlow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GleamMasterServer).KillFlow(ctx, req.(*KillFlowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GleamMaster_ReportFlowProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlowProgress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GleamMasterServer).ReportFlowProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GleamMaster/ReportFlowProgress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GleamMasterServer).ReportFlowProgress(ctx, req.(*FlowProgress))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GleamMaster_ListHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GleamMasterServer).ListHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GleamMaster/ListHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GleamMasterServer).ListHistory(ctx, req.(*ListHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GleamMaster_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GleamMasterServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GleamMaster/GetHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GleamMasterServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _GleamMaster_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.GleamMaster",
 	HandlerType: (*GleamMasterServer)(nil),
@@ -1605,11 +3748,48 @@ var _GleamMaster_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetResources",
 			Handler:    _GleamMaster_GetResources_Handler,
 		},
+		{
+			MethodName: "RenewLeases",
+			Handler:    _GleamMaster_RenewLeases_Handler,
+		},
+		{
+			MethodName: "ReleaseLeases",
+			Handler:    _GleamMaster_ReleaseLeases_Handler,
+		},
+		{
+			MethodName: "DrainAgent",
+			Handler:    _GleamMaster_DrainAgent_Handler,
+		},
+		{
+			MethodName: "GetTopology",
+			Handler:    _GleamMaster_GetTopology_Handler,
+		},
+		{
+			MethodName: "ListFlows",
+			Handler:    _GleamMaster_ListFlows_Handler,
+		},
+		{
+			MethodName: "KillFlow",
+			Handler:    _GleamMaster_KillFlow_Handler,
+		},
+		{
+			MethodName: "ReportFlowProgress",
+			Handler:    _GleamMaster_ReportFlowProgress_Handler,
+		},
+		{
+			MethodName: "ListHistory",
+			Handler:    _GleamMaster_ListHistory_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _GleamMaster_GetHistory_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "SendHeartbeat",
 			Handler:       _GleamMaster_SendHeartbeat_Handler,
+			ServerStreams: true,
 			ClientStreams: true,
 		},
 	},
@@ -1619,124 +3799,248 @@ var _GleamMaster_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("master_agent.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1898 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xd4, 0x58, 0xcd, 0x72, 0x1b, 0xb9,
-	0xf1, 0x5f, 0x8a, 0x14, 0x3f, 0x9a, 0xa4, 0x4c, 0xc1, 0xb2, 0xff, 0xb4, 0xfe, 0x9b, 0x8d, 0x0a,
-	0xa9, 0x24, 0xaa, 0xa4, 0xd6, 0x6b, 0x7b, 0xbd, 0x49, 0x36, 0x87, 0xd4, 0xca, 0x74, 0x6c, 0x6b,
-	0x8b, 0xb2, 0x54, 0x90, 0xab, 0x36, 0xb5, 0xa9, 0x8a, 0x6a, 0x38, 0x03, 0x51, 0xb3, 0x1e, 0x0e,
-	0x26, 0x00, 0x68, 0xaf, 0x73, 0xcd, 0x21, 0x2f, 0x90, 0x4b, 0x1e, 0x20, 0x87, 0x1c, 0xf2, 0x04,
-	0x79, 0x89, 0xdc, 0xf3, 0x0a, 0x79, 0x88, 0x54, 0x03, 0x98, 0x19, 0x0c, 0xc9, 0xd1, 0xca, 0x95,
-	0x53, 0x6e, 0x83, 0x5f, 0x7f, 0xa0, 0xd1, 0x68, 0x34, 0x7e, 0x03, 0x20, 0x8b, 0x40, 0x69, 0x2e,
-	0x2f, 0x82, 0x39, 0x4f, 0xf5, 0xfd, 0x4c, 0x0a, 0x2d, 0xc8, 0x56, 0x36, 0xa3, 0x0a, 0x76, 0x26,
-	0x62, 0x91, 0x2d, 0x35, 0x67, 0xfc, 0xf7, 0x4b, 0xae, 0x34, 0xf9, 0x3e, 0xf4, 0xa3, 0x40, 0x07,
-	0x17, 0x21, 0x4f, 0x35, 0x97, 0xe3, 0xc6, 0x41, 0xe3, 0xb0, 0xc7, 0x00, 0xa1, 0x89, 0x41, 0xc8,
-	0x17, 0xb0, 0x1b, 0x5a, 0x93, 0x0b, 0xc9, 0x95, 0x58, 0xca, 0x90, 0xab, 0xf1, 0xd6, 0x41, 0xf3,
-	0xb0, 0xff, 0xe8, 0xf6, 0xfd, 0x6c, 0x76, 0xbf, 0xf0, 0x67, 0x65, 0x6c, 0x14, 0x56, 0x01, 0x45,
-	0xff, 0xd1, 0x80, 0x5b, 0x2b, 0x5a, 0xe4, 0xff, 0xa1, 0x17, 0x66, 0xcb, 0x8b, 0x50, 0x2c, 0x53,
-	0x6d, 0x26, 0xdd, 0x66, 0xdd, 0x30, 0x5b, 0x4e, 0x70, 0x9c, 0x0b, 0x13, 0xfe, 0x86, 0x27, 0xe3,
-	0xad, 0x42, 0x38, 0xc5, 0x31, 0x0a, 0xe7, 0x85, 0x65, 0xd3, 0x0a, 0xe7, 0x9e, 0xe5, 0xbc, 0xb0,
-	0x6c, 0x15, 0xc2, 0xc2, 0x72, 0xc1, 0x17, 0x42, 0xbe, 0xbb, 0x58, 0xcc, 0xc6, 0xdb, 0x07, 0x8d,
-	0xc3, 0x26, 0xeb, 0x5a, 0xe0, 0x64, 0x46, 0xfe, 0x0f, 0x3a, 0x51, 0xac, 0x5e, 0xa3, 0xa8, 0x6d,
-	0x44, 0x6d, 0x1c, 0x9e, 0xcc, 0xe8, 0x14, 0x06, 0x4f, 0x03, 0x1d, 0x14, 0x91, 0x1f, 0x42, 0x37,
-	0x11, 0x61, 0xa0, 0x63, 0x91, 0x9a, 0xc0, 0xfb, 0x8f, 0x06, 0x98, 0x86, 0xa9, 0xc3, 0x58, 0x21,
-	0x25, 0x04, 0x5a, 0x2a, 0xfe, 0x03, 0x37, 0x2b, 0x68, 0x32, 0xf3, 0x4d, 0x5f, 0x43, 0x37, 0xd7,
-	0xfc, 0xee, 0xd4, 0x13, 0x68, 0xc9, 0x20, 0x7c, 0x6d, 0x1c, 0xf4, 0x98, 0xf9, 0x26, 0x77, 0xa1,
-	0xad, 0xb8, 0x7c, 0xc3, 0xa5, 0x59, 0x7b, 0x8f, 0xb9, 0x11, 0xea, 0x66, 0x42, 0x6a, 0xb7, 0x68,
-	0xf3, 0x4d, 0x63, 0x80, 0xa3, 0xa4, 0x08, 0xe7, 0xe6, 0x81, 0x3f, 0x84, 0x5e, 0x60, 0xed, 0x78,
-	0x64, 0x26, 0xaf, 0xd9, 0xea, 0x52, 0x8b, 0x3e, 0x85, 0x51, 0x39, 0x15, 0xe3, 0x6a, 0x99, 0x68,
-	0xf2, 0x00, 0xfa, 0x41, 0x81, 0xa9, 0x71, 0xc3, 0xd4, 0xcc, 0x0e, 0x3a, 0xf2, 0x54, 0x7d, 0x15,
-	0xfa, 0x97, 0x06, 0xf4, 0x5e, 0xf0, 0x40, 0xea, 0x19, 0x0f, 0xf4, 0x7b, 0x04, 0xfc, 0x09, 0x74,
-	0xf3, 0xda, 0xbc, 0x2e, 0xde, 0x42, 0xa9, 0xba, 0xc2, 0xe6, 0x8d, 0x56, 0xd8, 0x81, 0xed, 0x5f,
-	0x2f, 0x32, 0xfd, 0x8e, 0x46, 0xb6, 0x20, 0xa6, 0xde, 0x36, 0xa7, 0xc1, 0x82, 0xbb, 0xfd, 0x33,
-	0xdf, 0x95, 0xd0, 0xb7, 0xae, 0x0d, 0xfd, 0x2e, 0xb4, 0x45, 0xfa, 0x34, 0x56, 0xaf, 0x4d, 0x18,
-	0x5d, 0xe6, 0x46, 0xf4, 0xdf, 0x6d, 0x3c, 0xaa, 0xa9, 0x96, 0x22, 0x39, 0xe1, 0x4a, 0x05, 0x73,
-	0x4e, 0x3e, 0x02, 0x88, 0xd5, 0xa9, 0x11, 0x1f, 0x9f, 0x9a, 0xe9, 0xba, 0xcc, 0x43, 0xc8, 0x63,
-	0x18, 0x28, 0x1d, 0x48, 0xed, 0x8e, 0xb6, 0x9b, 0x78, 0x84, 0x13, 0x9f, 0x7b, 0x38, 0xab, 0x68,
-	0x91, 0x9f, 0xc3, 0xd0, 0x8d, 0x55, 0x26, 0x52, 0xc5, 0x5d, 0x3a, 0x76, 0x3d, 0x33, 0x2b, 0x60,
-	0x55, 0x3d, 0xf2, 0x10, 0xfa, 0x4a, 0x8b, 0x2c, 0x9f, 0xad, 0x65, 0xcc, 0x6e, 0x59, 0xb3, 0x02,
-	0x66, 0xbe, 0x8e, 0x8d, 0x10, 0x87, 0x6e, 0xaa, 0x6d, 0x3f, 0xc2, 0x12, 0x67, 0x15, 0x2d, 0xf2,
-	0x05, 0x8c, 0xe6, 0x5c, 0x9f, 0xeb, 0x40, 0x2f, 0x55, 0x3e, 0x5b, 0xdb, 0x58, 0xee, 0xa1, 0xe5,
-	0xf3, 0x15, 0x19, 0x5b, 0xd3, 0x26, 0x13, 0xd8, 0xf5, 0x30, 0x37, 0x79, 0xc7, 0xb8, 0xb8, 0xb3,
-	0xe2, 0xc2, 0x45, 0xb0, 0xae, 0x4f, 0x7e, 0x0b, 0xf7, 0x22, 0x9e, 0x70, 0xcd, 0x71, 0xf7, 0x15,
-	0xd7, 0xe7, 0x57, 0x81, 0x8c, 0xf2, 0x78, 0xba, 0xc6, 0xd9, 0xf7, 0xd0, 0xd9, 0xd3, 0x3a, 0x25,
-	0x56, 0x6f, 0x4f, 0x7e, 0x07, 0xfb, 0x9b, 0x84, 0x2e, 0xd4, 0x9e, 0xf1, 0xfe, 0x51, 0x9d, 0x77,
-	0x17, 0xf3, 0x35, 0x1e, 0xc8, 0x6f, 0x60, 0x8c, 0x25, 0x97, 0xe4, 0x6b, 0xc2, 0xfe, 0x90, 0xc7,
-	0x0e, 0xc6, 0xfb, 0x87, 0x79, 0x81, 0x6e, 0xd2, 0x61, 0xb5, 0xd6, 0x98, 0x96, 0x0d, 0x32, 0x17,
-	0x78, 0xbf, 0x4c, 0xcb, 0xb4, 0x4e, 0x89, 0xd5, 0xdb, 0x63, 0x8d, 0x49, 0x1e, 0x14, 0x59, 0x1e,
-	0x94, 0x35, 0xc6, 0x4a, 0x98, 0xf9, 0x3a, 0x58, 0x63, 0x6f, 0x65, 0x5c, 0x5c, 0x70, 0xe3, 0x61,
-	0x59, 0x63, 0x5f, 0x79, 0x38, 0xab, 0x68, 0xd1, 0xcf, 0xa0, 0xf3, 0x92, 0xeb, 0xc9, 0x55, 0x90,
-	0x7a, 0x1d, 0xb6, 0xb1, 0xb1, 0xc3, 0x6e, 0x79, 0x1d, 0xf6, 0x8f, 0x0d, 0x18, 0x56, 0x0e, 0x09,
-	0x19, 0x41, 0x33, 0x8b, 0x23, 0x77, 0xa5, 0xe1, 0x27, 0xd9, 0x83, 0x6d, 0x2e, 0xa5, 0x90, 0xae,
-	0x8d, 0xdb, 0x01, 0xf9, 0x01, 0xb4, 0x95, 0x8e, 0xb8, 0x94, 0xee, 0xbc, 0xf5, 0x31, 0x40, 0x17,
-	0x02, 0x73, 0x22, 0xf2, 0x43, 0xe8, 0x88, 0xa5, 0xce, 0x96, 0x5a, 0x8d, 0x5b, 0xa6, 0x7b, 0x56,
-	0xb4, 0x72, 0x19, 0x3d, 0x83, 0x81, 0x7f, 0x7c, 0xc8, 0x4f, 0x60, 0xe4, 0x1f, 0xf1, 0x17, 0x81,
-	0xba, 0x32, 0x01, 0x0d, 0xd9, 0x1a, 0xbe, 0x39, 0x3a, 0xfa, 0x2b, 0x18, 0xad, 0x1e, 0xab, 0xf7,
-	0xf1, 0x4a, 0x97, 0x30, 0xc4, 0x10, 0x53, 0xee, 0xb6, 0x15, 0x93, 0x9a, 0xf0, 0x74, 0xae, 0xad,
-	0x49, 0x93, 0xb9, 0x11, 0xf9, 0x10, 0x7a, 0xc6, 0xf8, 0x55, 0xbc, 0xc8, 0x2f, 0xca, 0x12, 0x20,
-	0xfb, 0xd0, 0xc5, 0x4e, 0x60, 0x84, 0x4d, 0x7b, 0x61, 0xe7, 0xe3, 0xa2, 0xed, 0xb6, 0xca, 0xb6,
-	0x4b, 0xff, 0xba, 0x05, 0xbb, 0x6b, 0x67, 0xf9, 0xbf, 0x4f, 0x07, 0xf6, 0xc8, 0x38, 0xcd, 0x96,
-	0xce, 0x31, 0x57, 0xe3, 0xa6, 0xd9, 0x0d, 0xd3, 0x23, 0x2b, 0xeb, 0x64, 0x55, 0x3d, 0xf2, 0x39,
-	0xec, 0xd8, 0x4d, 0x2a, 0x2c, 0x5b, 0x75, 0x96, 0x2b, 0x8a, 0xe4, 0x00, 0x4b, 0xdf, 0x04, 0x66,
-	0x96, 0x6f, 0xf9, 0x8a, 0x0f, 0x55, 0x73, 0xd7, 0xbe, 0x2e, 0x77, 0x9d, 0x6a, 0xee, 0xe8, 0x27,
-	0x70, 0xaf, 0xb6, 0x4b, 0x6d, 0xba, 0xcf, 0xe8, 0x23, 0xd8, 0xaf, 0x6f, 0x3c, 0x65, 0xd2, 0x1a,
-	0x7e, 0x0d, 0xfd, 0xb3, 0x01, 0xe3, 0xba, 0x7e, 0xf2, 0xbf, 0xb9, 0x27, 0xf4, 0x21, 0xdc, 0xab,
-	0x6d, 0x63, 0x35, 0x59, 0x90, 0x30, 0xf0, 0xdb, 0x0e, 0x6e, 0x6b, 0x68, 0xe7, 0x78, 0x59, 0x26,
-	0xd9, 0x87, 0xf0, 0x9a, 0x37, 0xad, 0x49, 0x1a, 0x05, 0xbb, 0x66, 0x0f, 0xb1, 0x85, 0x11, 0x44,
-	0x5c, 0x4e, 0x3c, 0x0a, 0xec, 0x43, 0xf4, 0x14, 0xfa, 0x5e, 0x7b, 0xbc, 0xd9, 0x94, 0xd6, 0xde,
-	0x9f, 0xb2, 0x44, 0xe8, 0xbf, 0x1a, 0xd8, 0x61, 0x3c, 0xd2, 0xf0, 0x33, 0x18, 0xc4, 0xa9, 0xd2,
-	0x72, 0x19, 0xe6, 0xdc, 0x0e, 0x7b, 0x18, 0xc1, 0x0c, 0x1e, 0x97, 0xf8, 0x39, 0xd7, 0xac, 0xa2,
-	0x87, 0x39, 0xba, 0x8c, 0x13, 0xf7, 0x03, 0xd1, 0x63, 0x76, 0x80, 0x3d, 0x33, 0x8a, 0x73, 0x42,
-	0x8b, 0x9f, 0x15, 0x42, 0xd7, 0xba, 0x09, 0xa1, 0x23, 0xd0, 0xba, 0x12, 0x4a, 0x9b, 0x63, 0xd2,
-	0x63, 0xe6, 0xbb, 0x68, 0xd8, 0xed, 0xb2, 0x61, 0x17, 0xc5, 0xdd, 0xf1, 0x8a, 0xfb, 0x73, 0xe8,
-	0x7b, 0x8c, 0xe5, 0xbd, 0xfa, 0xdc, 0xdf, 0x1b, 0xb0, 0x53, 0x5d, 0x30, 0xf9, 0x74, 0x2d, 0x35,
-	0xcd, 0xfc, 0xce, 0xf2, 0x34, 0x57, 0xf2, 0xb2, 0xb2, 0xa7, 0x5b, 0x6b, 0x7b, 0x4a, 0x28, 0x0c,
-	0x2e, 0x13, 0xf1, 0x16, 0x67, 0x9d, 0x88, 0xc8, 0xb6, 0xc3, 0x21, 0xab, 0x60, 0xe8, 0x25, 0x56,
-	0x67, 0x52, 0x5c, 0xc6, 0x49, 0x9c, 0xce, 0x4d, 0xe2, 0xba, 0xcc, 0x87, 0xe8, 0xdf, 0xba, 0xd0,
-	0xf7, 0xa2, 0xd8, 0xc8, 0x5d, 0xbf, 0x84, 0xdb, 0xf6, 0xc0, 0xe0, 0x19, 0x9f, 0x16, 0xf4, 0xdd,
-	0xfe, 0xf2, 0x8d, 0x0d, 0x07, 0xf1, 0x9a, 0x40, 0x41, 0x69, 0x37, 0x19, 0x91, 0x29, 0xec, 0x9d,
-	0xda, 0x23, 0x54, 0x75, 0xd6, 0xfc, 0x0e, 0x67, 0x7b, 0x62, 0x83, 0x15, 0x96, 0xa9, 0xfd, 0x5f,
-	0x3b, 0x4e, 0x4f, 0x9e, 0xb8, 0x3f, 0x1d, 0x0f, 0x21, 0xa7, 0x70, 0xe7, 0x1b, 0x11, 0xa7, 0x67,
-	0x81, 0xd4, 0x31, 0x5a, 0xf0, 0xe8, 0x5c, 0x48, 0x64, 0xf8, 0x96, 0x67, 0xde, 0xc3, 0xe9, 0xbe,
-	0xdc, 0xa4, 0xc0, 0x36, 0xdb, 0x21, 0x6b, 0x0a, 0xc5, 0x73, 0x29, 0x96, 0xd9, 0xba, 0xcf, 0x76,
-	0xc9, 0x9a, 0x26, 0x35, 0x3a, 0xac, 0xd6, 0x9a, 0xdc, 0x07, 0xc8, 0xe2, 0x8c, 0x1f, 0xa9, 0x23,
-	0x39, 0x57, 0x8e, 0x8a, 0x9a, 0x5f, 0xa3, 0xb3, 0x02, 0x65, 0x9e, 0x06, 0x32, 0x58, 0x15, 0x06,
-	0x5a, 0x73, 0x59, 0xf8, 0x52, 0x8e, 0x74, 0x1a, 0x06, 0x7b, 0xbe, 0x2a, 0x64, 0xeb, 0xfa, 0xe8,
-	0x24, 0x14, 0x49, 0xc2, 0x43, 0xed, 0x39, 0xe9, 0x95, 0x4e, 0x26, 0xab, 0x42, 0xb6, 0xae, 0x8f,
-	0x6c, 0xdc, 0xee, 0x74, 0x96, 0xc4, 0x9a, 0x99, 0x0a, 0x75, 0x0c, 0x72, 0xcf, 0xd6, 0x78, 0x55,
-	0xc6, 0xd6, 0xb4, 0x71, 0xed, 0x52, 0x2c, 0xd3, 0x88, 0x89, 0x59, 0x9c, 0x3a, 0x8a, 0x68, 0xd6,
-	0xce, 0x0a, 0x94, 0x79, 0x1a, 0xf9, 0xcf, 0x54, 0xf2, 0x4a, 0x64, 0x8e, 0x01, 0x16, 0x3f, 0x53,
-	0x88, 0xb1, 0x42, 0x4a, 0x7e, 0x0a, 0xbd, 0x99, 0x14, 0x41, 0x14, 0x06, 0x05, 0xf1, 0x1b, 0xa2,
-	0xea, 0x93, 0x1c, 0x64, 0xa5, 0x1c, 0x6b, 0xd3, 0x18, 0xe2, 0xf1, 0x39, 0x4a, 0x23, 0x2c, 0x8c,
-	0xaf, 0x62, 0x7d, 0x35, 0xde, 0x31, 0x76, 0xe3, 0x62, 0x8a, 0x15, 0x39, 0xdb, 0x68, 0x45, 0x28,
-	0xb4, 0x55, 0x28, 0xe3, 0x4c, 0x8f, 0x6f, 0x19, 0x7b, 0xb0, 0xbb, 0x82, 0x08, 0x73, 0x12, 0x0c,
-	0xcf, 0x52, 0x5d, 0xec, 0x4a, 0xa3, 0x32, 0xbc, 0x69, 0x0e, 0xb2, 0x52, 0x4e, 0x9e, 0x01, 0x09,
-	0xa2, 0x20, 0xd3, 0x5c, 0xfa, 0x99, 0xde, 0x35, 0x56, 0x77, 0xcd, 0x4f, 0xf4, 0x9a, 0x94, 0x6d,
-	0xb0, 0xc0, 0x7b, 0x72, 0xc1, 0xe5, 0x9c, 0xdb, 0xc2, 0x7b, 0x25, 0xc6, 0xa4, 0xfc, 0xbf, 0x3b,
-	0xf1, 0x05, 0xac, 0xaa, 0x47, 0x3f, 0x86, 0xdd, 0xb5, 0xaa, 0x22, 0x63, 0xe8, 0xc4, 0x69, 0xc4,
-	0xbf, 0xe5, 0xb6, 0xb1, 0x6d, 0xb3, 0x7c, 0x48, 0x07, 0x00, 0xe5, 0xfe, 0xd1, 0xdb, 0xb0, 0xbb,
-	0x56, 0x4d, 0xf4, 0x31, 0xf4, 0x8a, 0xa5, 0x92, 0x1f, 0x43, 0x57, 0xc8, 0x88, 0xcb, 0x27, 0xef,
-	0xf2, 0x1e, 0x69, 0xc8, 0xed, 0xa9, 0xc5, 0x58, 0x21, 0xa4, 0x47, 0xf6, 0xc9, 0xc4, 0x6c, 0xf0,
-	0x00, 0x1a, 0xa9, 0xe3, 0xd6, 0x8d, 0xb4, 0xe2, 0x62, 0xeb, 0x3a, 0x17, 0xbf, 0x80, 0x61, 0x65,
-	0xa9, 0x37, 0x9f, 0xfc, 0x33, 0xe8, 0x38, 0x10, 0xef, 0x2e, 0xb3, 0x56, 0x37, 0xbf, 0x1d, 0x20,
-	0x6a, 0x94, 0x5d, 0xcf, 0xb6, 0x03, 0xfa, 0xa7, 0x06, 0xdc, 0xd9, 0xd8, 0x69, 0xea, 0x13, 0x48,
-	0x0e, 0xe1, 0x56, 0xac, 0xa6, 0xfc, 0x52, 0x9f, 0x2e, 0x35, 0x97, 0x68, 0x6d, 0x7c, 0x76, 0xd9,
-	0x2a, 0x8c, 0x37, 0x54, 0xac, 0x58, 0x3c, 0xbf, 0xf2, 0x54, 0xed, 0xeb, 0xc1, 0x1a, 0x4e, 0x1f,
-	0xc3, 0xb8, 0xae, 0x3d, 0x5d, 0xb3, 0x99, 0x07, 0x00, 0x65, 0x23, 0xc2, 0x5b, 0x22, 0xc4, 0x3b,
-	0xc7, 0xdd, 0x12, 0xf8, 0x4d, 0xbf, 0x86, 0xb6, 0xad, 0x6e, 0xa4, 0xf6, 0xb1, 0x42, 0x6d, 0xf7,
-	0x24, 0xe1, 0x46, 0xe6, 0xfa, 0x0d, 0xf4, 0x55, 0xfe, 0x7a, 0x85, 0xdf, 0x88, 0x05, 0xd8, 0xf0,
-	0x9a, 0xe6, 0xfa, 0x37, 0xdf, 0x78, 0xfb, 0xf3, 0xf4, 0x8d, 0x21, 0x61, 0x3d, 0x86, 0x9f, 0xf4,
-	0x01, 0x8c, 0x56, 0xdb, 0x08, 0x92, 0x5d, 0xd3, 0x48, 0x5e, 0xbd, 0xcb, 0xf2, 0x40, 0x4a, 0x80,
-	0x7e, 0x0d, 0x64, 0xfd, 0x38, 0xe0, 0x7d, 0xe8, 0x0e, 0x84, 0x4f, 0x7c, 0x3c, 0x08, 0x6f, 0xd5,
-	0x50, 0xa4, 0x29, 0x37, 0xb7, 0xe1, 0x71, 0xe4, 0x62, 0xad, 0x60, 0xb4, 0x0f, 0xbd, 0xa2, 0x7f,
-	0xd0, 0x07, 0xb0, 0xb7, 0xa9, 0x29, 0x5c, 0x93, 0xca, 0x3f, 0x37, 0xec, 0x7b, 0x51, 0x7e, 0xc7,
-	0x21, 0x31, 0x7f, 0x96, 0x88, 0xb7, 0x5e, 0x48, 0xdd, 0x4b, 0x37, 0xc6, 0x55, 0x3a, 0x5d, 0x17,
-	0xcc, 0x36, 0xeb, 0x45, 0x39, 0x40, 0x7e, 0x04, 0x3b, 0xbe, 0xa7, 0xe3, 0xc8, 0x91, 0xbf, 0x9d,
-	0xa8, 0x82, 0xe2, 0xaa, 0x9e, 0xf9, 0x5c, 0xa1, 0xb5, 0xce, 0x15, 0xe8, 0x37, 0xb0, 0xb7, 0xe9,
-	0xe6, 0xc5, 0x1d, 0x7a, 0xb9, 0xca, 0x08, 0x08, 0xb4, 0x5e, 0x08, 0xf7, 0xa0, 0xe4, 0x91, 0xab,
-	0x33, 0x6c, 0x63, 0x4d, 0x8f, 0x5c, 0x95, 0x6f, 0x59, 0x2d, 0xff, 0x2d, 0xeb, 0xd1, 0xb7, 0xd0,
-	0x7f, 0x9e, 0xf0, 0x60, 0x71, 0x62, 0x1e, 0xa5, 0xc9, 0x2f, 0x61, 0xf0, 0x9c, 0xeb, 0xe2, 0x7d,
-	0x98, 0x90, 0x0a, 0xb5, 0x33, 0xe4, 0x6a, 0x7f, 0x6f, 0xe5, 0x99, 0xd0, 0xbc, 0x28, 0xd2, 0x0f,
-	0xc8, 0xc7, 0x30, 0x3c, 0xe7, 0x69, 0x54, 0x3e, 0x12, 0x9a, 0x06, 0x5a, 0x0c, 0xf7, 0x7b, 0x38,
-	0xb4, 0xef, 0x74, 0x1f, 0x1c, 0x36, 0x66, 0x6d, 0xf3, 0xf4, 0xfd, 0xe9, 0x7f, 0x02, 0x00, 0x00,
-	0xff, 0xff, 0x44, 0x82, 0x4f, 0x8d, 0x10, 0x17, 0x00, 0x00,
+	// 3880 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x3b, 0x4d, 0x93, 0x1c, 0x47,
+	0x56, 0xae, 0xfe, 0x98, 0xe9, 0x7e, 0xdd, 0x33, 0xd3, 0x93, 0x6a, 0x69, 0x4b, 0x83, 0x59, 0x86,
+	0xb4, 0xf1, 0x8a, 0x65, 0xd1, 0xca, 0x5a, 0xc9, 0x5e, 0x7b, 0x77, 0xc1, 0xa3, 0xd1, 0x5a, 0xd6,
+	0x7a, 0x24, 0x0d, 0x39, 0x22, 0x4c, 0x18, 0x02, 0x45, 0x4d, 0x57, 0xaa, 0xa7, 0x76, 0x6a, 0xaa,
+	0xca, 0x55, 0xd5, 0x92, 0x05, 0x47, 0x0e, 0xdc, 0x37, 0x08, 0x38, 0x70, 0xe4, 0x48, 0x40, 0x10,
+	0xc1, 0x81, 0xe0, 0xe3, 0x46, 0xc0, 0x81, 0x0b, 0x67, 0x6e, 0x5c, 0xf8, 0x15, 0x5c, 0x88, 0x97,
+	0x5f, 0x95, 0x59, 0x1f, 0xa3, 0x19, 0x43, 0x60, 0xb8, 0x55, 0xbe, 0xf7, 0x32, 0xf3, 0x65, 0xe6,
+	0xfb, 0xce, 0x2c, 0x20, 0x67, 0x41, 0x51, 0xf2, 0xfc, 0x59, 0xb0, 0xe4, 0x49, 0x79, 0x33, 0xcb,
+	0xd3, 0x32, 0x25, 0xbd, 0xec, 0x98, 0xfe, 0x75, 0x0f, 0x36, 0xf7, 0xd3, 0xb3, 0x6c, 0x55, 0x72,
+	0xc6, 0xbf, 0x58, 0xf1, 0xa2, 0x24, 0xbf, 0x00, 0x93, 0x30, 0x28, 0x83, 0x67, 0x0b, 0x9e, 0x94,
+	0x3c, 0xf7, 0xbd, 0x5d, 0xef, 0xc6, 0x98, 0x01, 0x82, 0xf6, 0x05, 0x84, 0x7c, 0x04, 0xdb, 0x0b,
+	0xd9, 0xe5, 0x59, 0xce, 0x8b, 0x74, 0x95, 0x2f, 0x78, 0xe1, 0xf7, 0x76, 0xfb, 0x37, 0x26, 0xb7,
+	0xaf, 0xdc, 0xcc, 0x8e, 0x6f, 0x9a, 0xf1, 0x24, 0x8e, 0xcd, 0x16, 0x2e, 0xa0, 0x20, 0x73, 0x18,
+	0x7e, 0xb1, 0xe2, 0x2b, 0xee, 0xf7, 0xc5, 0xe0, 0xb2, 0x41, 0x08, 0x0c, 0x56, 0x05, 0xcf, 0xfd,
+	0x81, 0x00, 0x8a, 0x6f, 0xf2, 0x43, 0xd8, 0x3a, 0x49, 0x8b, 0xf2, 0x30, 0xe7, 0xcf, 0x79, 0xce,
+	0x13, 0x9c, 0x69, 0x28, 0x66, 0x22, 0x38, 0xd3, 0x27, 0x0e, 0x8a, 0xd5, 0x49, 0x09, 0x85, 0xe9,
+	0xf3, 0x38, 0x7d, 0xf9, 0x49, 0x50, 0x9c, 0xec, 0xa7, 0x21, 0xf7, 0xd7, 0x76, 0xbd, 0x1b, 0x1b,
+	0xcc, 0x81, 0x91, 0x1d, 0x18, 0x61, 0xfb, 0x71, 0x70, 0xc6, 0xfd, 0x75, 0x31, 0xb3, 0x69, 0x93,
+	0x6b, 0xb0, 0x16, 0xe6, 0xd1, 0x0b, 0x9e, 0xfb, 0x23, 0x81, 0x51, 0x2d, 0xfa, 0x0e, 0x6c, 0xba,
+	0x53, 0xe3, 0x8a, 0x70, 0xf2, 0xc2, 0xf7, 0x76, 0xfb, 0xb8, 0x22, 0xd1, 0xa0, 0x7f, 0xef, 0xc1,
+	0x56, 0x6d, 0x37, 0xc8, 0xcf, 0xc1, 0x78, 0x91, 0xad, 0x9e, 0x2d, 0xd2, 0x55, 0x52, 0x8a, 0xcd,
+	0x1d, 0xb2, 0xd1, 0x22, 0x5b, 0xed, 0x63, 0x5b, 0x23, 0x63, 0xfe, 0x82, 0xc7, 0x7e, 0xcf, 0x20,
+	0x0f, 0xb0, 0x8d, 0xc8, 0xa5, 0xe9, 0xd9, 0x97, 0xc8, 0xa5, 0xd5, 0x73, 0x69, 0x7a, 0x0e, 0x0c,
+	0xd2, 0xf4, 0x3c, 0xe3, 0x67, 0x69, 0xfe, 0xea, 0xd9, 0xd9, 0xb1, 0x3f, 0xdc, 0xf5, 0x6e, 0xf4,
+	0xd9, 0x48, 0x02, 0x1e, 0x1d, 0x93, 0x6f, 0xc0, 0x7a, 0x18, 0x15, 0xa7, 0x88, 0x5a, 0x13, 0xa8,
+	0x35, 0x6c, 0x3e, 0x3a, 0xa6, 0x07, 0x30, 0xbd, 0x1f, 0x94, 0x81, 0xe1, 0xfc, 0x06, 0x8c, 0xe2,
+	0x74, 0x11, 0x94, 0x51, 0x9a, 0x08, 0xc6, 0x27, 0xb7, 0xa7, 0x78, 0x08, 0x07, 0x0a, 0xc6, 0x0c,
+	0x16, 0x4f, 0xb2, 0x88, 0x7e, 0x8f, 0x8b, 0x15, 0xf4, 0x99, 0xf8, 0xa6, 0xa7, 0x30, 0xd2, 0x94,
+	0xaf, 0x17, 0x31, 0x02, 0x83, 0x3c, 0x58, 0x9c, 0x8a, 0x01, 0xc6, 0x4c, 0x7c, 0xe3, 0x61, 0x14,
+	0x3c, 0xc7, 0xc3, 0x90, 0x52, 0xa3, 0x5a, 0x48, 0x9b, 0xa5, 0x79, 0xa9, 0x16, 0x2d, 0xbe, 0xe9,
+	0x1f, 0x78, 0x00, 0x7b, 0xb1, 0xe1, 0xe7, 0xe2, 0x9c, 0xbf, 0x0b, 0xe3, 0x40, 0xf6, 0xe3, 0xa1,
+	0x98, 0xbd, 0x43, 0xa6, 0x2b, 0x2a, 0xe2, 0xc3, 0x7a, 0xcc, 0x83, 0x82, 0x3f, 0x0c, 0x05, 0x63,
+	0x03, 0xa6, 0x9b, 0xf4, 0x04, 0x66, 0x15, 0x13, 0x8c, 0x17, 0xab, 0xb8, 0x24, 0xb7, 0x60, 0x12,
+	0x18, 0x98, 0x14, 0x97, 0xc9, 0xed, 0x4d, 0x9c, 0xc2, 0x22, 0xb5, 0x49, 0x50, 0x88, 0xc5, 0x80,
+	0x47, 0x7c, 0x91, 0x26, 0x61, 0xa1, 0x36, 0xd5, 0x81, 0xd1, 0x97, 0xb0, 0x7d, 0x3f, 0x0f, 0xa2,
+	0x64, 0x0f, 0xd5, 0x5b, 0x2b, 0xf2, 0xc5, 0x57, 0xfd, 0x7d, 0xd8, 0x3c, 0x8b, 0x96, 0x39, 0x2e,
+	0xe7, 0xe8, 0x24, 0xc8, 0x43, 0xad, 0xce, 0x33, 0xa4, 0x47, 0x19, 0x30, 0x7d, 0x6a, 0x74, 0x34,
+	0x03, 0x62, 0x4f, 0x5c, 0x64, 0x69, 0x52, 0x70, 0xf2, 0x0e, 0xac, 0x97, 0x41, 0xbe, 0xe4, 0xa5,
+	0x5e, 0xa0, 0x3b, 0xb1, 0x46, 0x92, 0xdb, 0x30, 0xcf, 0x57, 0x49, 0x12, 0x25, 0xcb, 0x8f, 0x2d,
+	0x95, 0x94, 0xb3, 0x6f, 0xb0, 0x56, 0x1c, 0xdd, 0x86, 0xad, 0xa7, 0x69, 0x96, 0xc6, 0xe9, 0xf2,
+	0x95, 0x5a, 0x28, 0xfd, 0x4b, 0x0f, 0x66, 0x15, 0x4c, 0xf1, 0xf0, 0x5d, 0x18, 0x69, 0xeb, 0xe4,
+	0x7b, 0xdd, 0x07, 0x69, 0x88, 0xbe, 0xca, 0xd1, 0xbf, 0x07, 0x93, 0x4a, 0x68, 0x0b, 0xbf, 0x2f,
+	0xd6, 0x3a, 0xd7, 0x9b, 0x26, 0xc1, 0x47, 0x65, 0x50, 0xae, 0x0a, 0x66, 0x13, 0xd2, 0xbf, 0xf2,
+	0x60, 0x56, 0xa7, 0x40, 0x39, 0x4e, 0x82, 0x33, 0xc9, 0xec, 0x98, 0x89, 0x6f, 0x67, 0x11, 0xbd,
+	0x4b, 0x2f, 0xa2, 0x7f, 0xa1, 0x45, 0xbc, 0x0d, 0x43, 0xd4, 0xaf, 0xc2, 0x1f, 0x54, 0xb2, 0xc8,
+	0x82, 0xc5, 0xa9, 0x62, 0x5c, 0x22, 0xe9, 0x5f, 0x78, 0x00, 0x15, 0xf4, 0x6b, 0x63, 0xf6, 0x5b,
+	0xb0, 0x26, 0x5c, 0x98, 0xe6, 0x76, 0x4b, 0x68, 0x0e, 0x42, 0x14, 0xbb, 0x0a, 0x4d, 0xff, 0xb8,
+	0x07, 0x13, 0x0b, 0x7e, 0x09, 0x65, 0xf8, 0xdf, 0x58, 0xc6, 0x1c, 0x86, 0x45, 0x19, 0x94, 0x5c,
+	0xf9, 0x3a, 0xd9, 0x40, 0x57, 0x14, 0xa2, 0x32, 0x45, 0xc9, 0x52, 0x58, 0xe9, 0x11, 0x33, 0x6d,
+	0xf2, 0x36, 0x6c, 0xc4, 0x41, 0x51, 0x7e, 0xc2, 0x83, 0xbc, 0x3c, 0xe6, 0x41, 0xa9, 0x6c, 0xb5,
+	0x0b, 0x24, 0xbf, 0x08, 0x83, 0x38, 0x0d, 0x42, 0xe1, 0xc8, 0x26, 0xb7, 0x37, 0xcc, 0xe6, 0x1c,
+	0xa4, 0x41, 0xc8, 0x04, 0x8a, 0x12, 0x98, 0x1d, 0x44, 0x45, 0x89, 0x4a, 0x55, 0x68, 0x05, 0xfa,
+	0x00, 0xb6, 0x2d, 0x98, 0x52, 0xa0, 0xb7, 0x61, 0x88, 0x8e, 0xd0, 0xb1, 0x51, 0x48, 0xa1, 0xe5,
+	0x42, 0x20, 0xe9, 0xbf, 0xf4, 0x01, 0x2a, 0x68, 0xc3, 0xe3, 0x7a, 0x2d, 0x1e, 0x57, 0xcb, 0x4e,
+	0xcf, 0x92, 0x1d, 0xed, 0xfb, 0xfb, 0x96, 0xef, 0x37, 0x51, 0xc2, 0xc0, 0x8e, 0x12, 0x2a, 0x9f,
+	0x3c, 0xb4, 0x7d, 0x32, 0x79, 0x13, 0xc6, 0x45, 0x19, 0xe4, 0xe5, 0xd3, 0xe8, 0x8c, 0xab, 0xcd,
+	0xa9, 0x00, 0x75, 0xb3, 0xbb, 0xfe, 0x7a, 0xb3, 0x6b, 0x8e, 0x68, 0x54, 0x3b, 0xa2, 0xa2, 0x4c,
+	0x33, 0x31, 0xc9, 0x58, 0x3a, 0x52, 0xdd, 0xc6, 0x1e, 0x3c, 0xcf, 0xd3, 0xdc, 0x07, 0xd9, 0x43,
+	0x34, 0xc8, 0x37, 0x01, 0xca, 0xa0, 0x38, 0x7d, 0x90, 0xa7, 0xab, 0xac, 0xf0, 0x27, 0xc2, 0x49,
+	0x59, 0x10, 0xf2, 0x1d, 0xd8, 0x56, 0x76, 0xee, 0x69, 0x45, 0x36, 0x15, 0x64, 0x4d, 0x04, 0xb9,
+	0x05, 0x57, 0x30, 0x9a, 0x8a, 0x79, 0xc9, 0x43, 0x8b, 0x7e, 0x43, 0xd0, 0xb7, 0xa1, 0xc8, 0xb7,
+	0x61, 0xf6, 0x3c, 0x88, 0x62, 0x87, 0x7c, 0x53, 0x90, 0x37, 0xe0, 0xf4, 0x67, 0x7d, 0x98, 0xe2,
+	0x61, 0x1e, 0xe6, 0xe9, 0x32, 0xe7, 0xc5, 0xd7, 0x79, 0x9c, 0x66, 0xfb, 0xd7, 0xec, 0xed, 0x77,
+	0x0e, 0x79, 0xbd, 0x7e, 0xc8, 0xf6, 0xe1, 0x8c, 0xba, 0x0e, 0x67, 0x6c, 0x1f, 0xce, 0x5d, 0xe7,
+	0x70, 0x40, 0x48, 0xc5, 0x55, 0x94, 0x0a, 0xb3, 0x29, 0x7a, 0x2b, 0x9c, 0x33, 0x7b, 0x07, 0x99,
+	0xe3, 0xe2, 0x38, 0x8d, 0x9b, 0x3c, 0x2a, 0x79, 0x45, 0x2c, 0xd1, 0x68, 0x4a, 0xd0, 0xec, 0x17,
+	0xe8, 0x08, 0xa7, 0x55, 0x80, 0x7c, 0x5f, 0xc2, 0x0c, 0xb5, 0x21, 0x12, 0xb1, 0x4c, 0x1c, 0x24,
+	0xe2, 0x3c, 0xc7, 0x4c, 0x7c, 0xd3, 0x7f, 0xf2, 0x60, 0x6a, 0x0f, 0x4e, 0x36, 0xa1, 0x17, 0x85,
+	0x2a, 0x74, 0xec, 0x45, 0x61, 0xeb, 0x01, 0xec, 0xc2, 0x24, 0xe1, 0xe5, 0xcb, 0x34, 0x3f, 0x7d,
+	0xfa, 0x2a, 0xd3, 0x71, 0xb6, 0x0d, 0x22, 0xef, 0xc0, 0x66, 0x54, 0x3c, 0x49, 0xee, 0x8b, 0xed,
+	0x3e, 0x8a, 0x42, 0x79, 0x2e, 0x23, 0x56, 0x83, 0xe2, 0x96, 0xe3, 0xca, 0x45, 0x94, 0x29, 0xce,
+	0x68, 0xc8, 0x2a, 0x00, 0xf9, 0x65, 0x79, 0x4c, 0x85, 0x38, 0x26, 0xb5, 0xbc, 0x87, 0x49, 0x51,
+	0xe6, 0xab, 0x05, 0xaa, 0x11, 0x5a, 0x05, 0x79, 0x76, 0x05, 0xfd, 0x73, 0x0f, 0xb6, 0x6a, 0x2b,
+	0x6f, 0x2c, 0x05, 0x63, 0xbc, 0x92, 0x67, 0x0f, 0x43, 0x15, 0xfc, 0xaa, 0x16, 0x32, 0x9b, 0xf3,
+	0x20, 0x8c, 0x92, 0xe5, 0x91, 0x00, 0x48, 0x5f, 0x3b, 0x64, 0x35, 0x28, 0xf6, 0x4f, 0x93, 0xfb,
+	0x51, 0x71, 0xaa, 0x16, 0xa3, 0x5a, 0xe4, 0x16, 0xac, 0x15, 0x32, 0xb0, 0x91, 0xd9, 0x83, 0x6f,
+	0x1d, 0x83, 0x88, 0x64, 0xcc, 0x59, 0x28, 0x3a, 0xfa, 0x33, 0x0f, 0xe6, 0x6d, 0x04, 0xed, 0x2c,
+	0xa3, 0xed, 0x53, 0xfb, 0xbf, 0x56, 0x34, 0x1d, 0x4e, 0xff, 0x75, 0xd1, 0x72, 0x8e, 0x76, 0x76,
+	0x20, 0xa3, 0x65, 0xfc, 0x46, 0x71, 0x3d, 0x7e, 0x55, 0x8a, 0x6c, 0x07, 0x81, 0xb2, 0x41, 0xff,
+	0xb9, 0x0f, 0xdb, 0x0d, 0xc9, 0xbc, 0x90, 0x3c, 0xcc, 0xb5, 0xc4, 0xf6, 0x65, 0x7e, 0x22, 0x1a,
+	0x1d, 0x6e, 0xc8, 0xe6, 0x7c, 0x78, 0x2e, 0xe7, 0x3b, 0x30, 0x7a, 0x19, 0x44, 0xb6, 0xc9, 0x35,
+	0xed, 0xff, 0x71, 0x55, 0x7d, 0x13, 0xc6, 0x51, 0x92, 0xad, 0x4a, 0x86, 0x5b, 0x05, 0x72, 0x3c,
+	0x03, 0x40, 0x2b, 0x2b, 0x1a, 0xf7, 0xc4, 0xa6, 0x4d, 0x04, 0xda, 0x82, 0x20, 0x3e, 0x5d, 0x95,
+	0xba, 0xfb, 0x54, 0xe2, 0x2b, 0x08, 0xea, 0x8b, 0x6c, 0xc9, 0x01, 0x36, 0x04, 0x81, 0x0d, 0xc2,
+	0x30, 0xbf, 0x50, 0xb2, 0xb7, 0x29, 0x64, 0x4f, 0x37, 0xd1, 0xff, 0xa3, 0x42, 0x1c, 0x09, 0x3d,
+	0xd8, 0xda, 0xed, 0x77, 0xe9, 0x41, 0x45, 0x45, 0x0f, 0x61, 0x6e, 0xdb, 0x59, 0xe3, 0x73, 0x9b,
+	0x81, 0xb8, 0x77, 0xc1, 0x40, 0xfc, 0xd7, 0x80, 0xa0, 0x0b, 0xff, 0x24, 0x2a, 0xca, 0x34, 0xd7,
+	0x91, 0x71, 0x6b, 0x98, 0x36, 0x87, 0x61, 0x1c, 0x9d, 0x45, 0xa5, 0x52, 0x31, 0xd9, 0xa0, 0x3f,
+	0x80, 0x2b, 0x4e, 0xff, 0x4b, 0x05, 0x01, 0xef, 0xc3, 0xf6, 0x03, 0x5e, 0x9f, 0xfb, 0x02, 0xbe,
+	0x83, 0xde, 0x85, 0xad, 0x4f, 0xa3, 0x38, 0xc6, 0x11, 0x2f, 0xd3, 0xed, 0x3d, 0x98, 0x55, 0xdd,
+	0x14, 0xa7, 0x14, 0xa6, 0xa7, 0x51, 0x1c, 0xf3, 0xf0, 0x80, 0x07, 0x05, 0x2f, 0x94, 0x3e, 0x38,
+	0x30, 0xfa, 0x18, 0xa6, 0xe2, 0x4b, 0xcf, 0xb5, 0x03, 0x23, 0x95, 0xab, 0xc9, 0x05, 0x0e, 0x98,
+	0x69, 0x37, 0xf8, 0xe8, 0xb5, 0xf0, 0xf1, 0x87, 0x1e, 0x6c, 0xa8, 0x01, 0x15, 0x17, 0x37, 0x60,
+	0x8b, 0x7f, 0x99, 0x45, 0xb9, 0x9a, 0xb2, 0x1a, 0xb8, 0x0e, 0x26, 0xdf, 0x01, 0x08, 0x79, 0x10,
+	0xee, 0xc9, 0x68, 0xb6, 0xd7, 0x92, 0x26, 0x59, 0x78, 0x94, 0x5f, 0x9c, 0xf9, 0x53, 0xb1, 0x1a,
+	0x61, 0x4f, 0x46, 0xcc, 0x82, 0xd0, 0x7f, 0xec, 0xc1, 0xb8, 0x0a, 0x03, 0xff, 0x6f, 0x05, 0xbb,
+	0xbf, 0x0e, 0x33, 0xe1, 0xeb, 0xf7, 0xac, 0x00, 0x6c, 0x50, 0xa9, 0xc9, 0x6f, 0xb8, 0x38, 0xd6,
+	0x20, 0xc6, 0x4d, 0x55, 0x91, 0x90, 0xd9, 0xd4, 0xa1, 0xdc, 0xd4, 0x1a, 0xd8, 0xc4, 0xbf, 0x6b,
+	0x9d, 0xf1, 0xaf, 0x13, 0x64, 0xaf, 0xbb, 0x41, 0x36, 0xfd, 0x07, 0x0f, 0xc6, 0x86, 0x1e, 0x29,
+	0x17, 0xd9, 0xea, 0x37, 0x8b, 0x60, 0x29, 0xa5, 0xd0, 0x63, 0xa6, 0x2d, 0xa4, 0x23, 0xe7, 0xfc,
+	0x91, 0x2a, 0xa2, 0xe8, 0xa4, 0xdc, 0x86, 0x91, 0xb7, 0x60, 0x88, 0x95, 0x14, 0x9d, 0x17, 0x0a,
+	0x6e, 0xd0, 0x1b, 0x89, 0x11, 0x98, 0xc4, 0x61, 0x78, 0xa6, 0x16, 0xf1, 0xe3, 0x2f, 0xf9, 0x62,
+	0x55, 0xa6, 0x79, 0xa1, 0x2a, 0x19, 0x0d, 0x38, 0x0a, 0x81, 0xf0, 0x4e, 0xf7, 0x2c, 0xcf, 0x60,
+	0x41, 0xe8, 0x5d, 0x18, 0x9b, 0xf1, 0xc9, 0x0c, 0xfa, 0x61, 0xa4, 0x6b, 0x2b, 0xf8, 0x89, 0x9e,
+	0x4a, 0xf0, 0xa7, 0xb9, 0x55, 0x2d, 0xfa, 0x39, 0x6c, 0xd5, 0xce, 0xa0, 0x8a, 0xd5, 0x3c, 0x3b,
+	0x56, 0xbb, 0x7c, 0x86, 0x4c, 0x7f, 0x1f, 0xb6, 0x8d, 0x58, 0x1a, 0x25, 0xf9, 0x21, 0x6c, 0x65,
+	0x39, 0xe7, 0x67, 0x99, 0x2e, 0x55, 0x68, 0xf3, 0x22, 0x8a, 0x7a, 0x87, 0x0e, 0x8a, 0xd5, 0x49,
+	0xdb, 0x54, 0xac, 0xd7, 0xaa, 0x62, 0xf4, 0x33, 0xd8, 0x74, 0x07, 0xeb, 0x58, 0xd7, 0x65, 0x95,
+	0x80, 0xae, 0xc3, 0xf0, 0xc7, 0x67, 0x59, 0xf9, 0x8a, 0x86, 0xb2, 0x44, 0x76, 0x60, 0xb9, 0xf2,
+	0x86, 0xbd, 0xb5, 0x95, 0xb1, 0x77, 0xae, 0x32, 0x56, 0xd1, 0x4b, 0xdf, 0x8e, 0x5e, 0xe8, 0xdf,
+	0x02, 0x16, 0x69, 0x93, 0x32, 0x4f, 0xe3, 0x47, 0xbc, 0x10, 0xa7, 0x8b, 0xfe, 0x0e, 0xe3, 0xb4,
+	0xa8, 0x38, 0x7d, 0xf8, 0x44, 0x4c, 0x37, 0x62, 0x16, 0x84, 0xdc, 0x81, 0xa9, 0x70, 0xb6, 0x6a,
+	0xe1, 0x6a, 0x62, 0x15, 0xa8, 0x56, 0x70, 0xe6, 0x50, 0x91, 0xf7, 0x61, 0x43, 0xb5, 0xe5, 0x49,
+	0x29, 0x05, 0xdf, 0xb6, 0xba, 0x49, 0x04, 0x73, 0xe9, 0xc8, 0xbb, 0x30, 0x41, 0xf7, 0xad, 0x67,
+	0x1b, 0xec, 0x7a, 0x3a, 0x37, 0x3f, 0xaa, 0xc0, 0xcc, 0xa6, 0x91, 0x1c, 0xa6, 0x99, 0x1e, 0xc2,
+	0x1f, 0xda, 0x1c, 0x56, 0x70, 0xe6, 0x50, 0x91, 0x8f, 0x60, 0xb6, 0xe4, 0x3a, 0xd7, 0x57, 0xb3,
+	0x49, 0x65, 0x17, 0x65, 0x97, 0x07, 0x35, 0x1c, 0x6b, 0x50, 0x93, 0x7d, 0xd8, 0xb6, 0x60, 0x6a,
+	0x72, 0x99, 0x2f, 0x5f, 0xad, 0x0d, 0xa1, 0x38, 0x68, 0xd2, 0x93, 0xdf, 0x86, 0xeb, 0x21, 0xc7,
+	0x4c, 0xcb, 0x0e, 0x11, 0x35, 0x3f, 0x23, 0x31, 0xd8, 0xcf, 0x0b, 0x75, 0xef, 0x22, 0x62, 0xdd,
+	0xfd, 0xc9, 0xef, 0xc2, 0x4e, 0x1b, 0x52, 0xb1, 0x3a, 0x16, 0xa3, 0x7f, 0xb3, 0x6b, 0x74, 0xc5,
+	0xf3, 0x39, 0x23, 0x90, 0xdf, 0x02, 0x1f, 0x45, 0x2e, 0xd6, 0x6b, 0xca, 0xd2, 0x4a, 0x4e, 0x40,
+	0x8c, 0xfe, 0xa6, 0x16, 0xd0, 0x36, 0x1a, 0xd6, 0xd9, 0x1b, 0xb7, 0xa5, 0x05, 0xa7, 0x18, 0x9f,
+	0x54, 0xdb, 0x72, 0xd0, 0x45, 0xc4, 0xba, 0xfb, 0xa3, 0x8c, 0x61, 0xb4, 0xaf, 0x39, 0x9d, 0x56,
+	0x32, 0xc6, 0x2a, 0x30, 0xb3, 0x69, 0x50, 0xc6, 0x5e, 0xe6, 0x91, 0xb9, 0xda, 0xf0, 0x37, 0x2a,
+	0x19, 0xfb, 0xcc, 0x82, 0x33, 0x87, 0x0a, 0x8d, 0x44, 0x99, 0x9e, 0xf2, 0x44, 0xa4, 0xc9, 0x63,
+	0x26, 0x1b, 0x55, 0x54, 0xba, 0x65, 0x47, 0xa5, 0x77, 0x60, 0x2a, 0xbc, 0x87, 0x9e, 0x61, 0x56,
+	0xcd, 0x70, 0xdf, 0x82, 0x33, 0x87, 0x0a, 0xf5, 0x4c, 0xb5, 0xd5, 0xde, 0x6c, 0x57, 0x7a, 0x76,
+	0xdf, 0x46, 0x30, 0x97, 0x4e, 0x1c, 0x5d, 0x54, 0x94, 0xf6, 0xb1, 0x1a, 0x35, 0x20, 0xd6, 0xd1,
+	0x75, 0xd0, 0xb0, 0xce, 0xde, 0xe2, 0xe8, 0x9a, 0x38, 0xc5, 0xde, 0x15, 0xeb, 0xe8, 0xba, 0x88,
+	0x58, 0x77, 0x7f, 0xf2, 0xa1, 0x4c, 0xdf, 0x0e, 0xd2, 0xa5, 0x66, 0x76, 0xbe, 0xeb, 0x69, 0x7b,
+	0xcf, 0x1c, 0x0c, 0xab, 0x51, 0x92, 0x1f, 0xc1, 0x96, 0x81, 0x28, 0x76, 0xae, 0x56, 0x36, 0x9a,
+	0xb9, 0x28, 0x56, 0xa7, 0xa5, 0x77, 0x61, 0xfd, 0x31, 0x2f, 0xf7, 0x4f, 0x82, 0xc4, 0xba, 0x40,
+	0xf0, 0x5a, 0x2f, 0x10, 0x7a, 0xee, 0x05, 0xc2, 0x86, 0x63, 0xf1, 0xd0, 0x9f, 0x66, 0x26, 0xcd,
+	0xc2, 0xcf, 0x4a, 0x22, 0x7a, 0xb6, 0x44, 0xbc, 0x85, 0xf9, 0x60, 0xc8, 0xf3, 0x5c, 0x19, 0xcf,
+	0x09, 0xb2, 0xa9, 0x58, 0x60, 0x0a, 0x45, 0x7e, 0x09, 0xd6, 0x65, 0x6e, 0xa1, 0x23, 0x21, 0x87,
+	0x4a, 0xe3, 0xe8, 0x21, 0x66, 0xfe, 0x96, 0xf5, 0xfb, 0x36, 0xcc, 0x6c, 0x7b, 0x8d, 0x71, 0xa8,
+	0x8a, 0x8f, 0x1b, 0xf0, 0x76, 0xee, 0xe8, 0xef, 0xc0, 0xd4, 0x96, 0x4b, 0x2c, 0x2b, 0xaa, 0x44,
+	0xc2, 0xe4, 0x1b, 0xe8, 0x4a, 0x5c, 0x20, 0xa6, 0xdf, 0x65, 0x74, 0xc6, 0xd3, 0x55, 0xe9, 0x5e,
+	0x42, 0xd4, 0xa0, 0xf4, 0x19, 0x6c, 0x38, 0xe2, 0xfb, 0xd5, 0xf3, 0x99, 0x0e, 0xf6, 0x77, 0xc0,
+	0xef, 0x92, 0x6d, 0xfa, 0x29, 0x5c, 0xef, 0x14, 0x4e, 0x72, 0xd3, 0x14, 0x00, 0x24, 0x03, 0xd7,
+	0xea, 0x05, 0x00, 0x5d, 0x3e, 0x56, 0xe9, 0xff, 0xbf, 0x7b, 0x40, 0x9a, 0xe8, 0x56, 0xff, 0x5e,
+	0x79, 0xed, 0x9e, 0x53, 0x73, 0xd0, 0x97, 0x60, 0xfd, 0xea, 0x12, 0x0c, 0xd3, 0x4c, 0xac, 0xd1,
+	0xe5, 0xbc, 0x28, 0x30, 0x1c, 0x90, 0x69, 0xb7, 0x0d, 0x42, 0xc7, 0xbe, 0xc8, 0x79, 0x50, 0x72,
+	0x91, 0x1a, 0xab, 0x18, 0xaf, 0x82, 0xe8, 0x3a, 0xb0, 0x30, 0x5f, 0x56, 0xde, 0xed, 0x02, 0xc5,
+	0x9d, 0x51, 0x50, 0x94, 0xa8, 0x1d, 0x56, 0xfe, 0xed, 0xc0, 0xe8, 0x0b, 0xd8, 0x74, 0x55, 0xef,
+	0x42, 0xd5, 0x3e, 0x0a, 0x53, 0x53, 0x08, 0xd3, 0xa1, 0xd7, 0x90, 0x39, 0x30, 0x59, 0x32, 0x8a,
+	0x62, 0x19, 0xa6, 0xca, 0xe5, 0x57, 0x00, 0x7a, 0x00, 0x5b, 0x35, 0xad, 0x25, 0x6f, 0x61, 0xd8,
+	0xbe, 0xd4, 0x67, 0x23, 0x6c, 0xba, 0x8e, 0x7a, 0x91, 0x4c, 0x20, 0x3b, 0x24, 0xe2, 0x4f, 0x3c,
+	0x98, 0x58, 0xb4, 0xb8, 0xc3, 0x16, 0x2f, 0x4a, 0x5d, 0x6d, 0x50, 0x57, 0xbd, 0xb2, 0x71, 0x56,
+	0x8d, 0x9d, 0x1e, 0xb4, 0xed, 0xb4, 0x0f, 0xeb, 0x8b, 0x34, 0x29, 0xb9, 0x2a, 0x8e, 0x4d, 0x99,
+	0x6e, 0xd2, 0x10, 0x66, 0xf5, 0x70, 0xe4, 0x52, 0x0a, 0xfc, 0x36, 0x6c, 0x2c, 0x62, 0x1e, 0xe4,
+	0x87, 0x79, 0xfa, 0x3c, 0x8a, 0x79, 0xa1, 0xc4, 0xcb, 0x05, 0xd2, 0xbf, 0xf1, 0x60, 0x03, 0x8d,
+	0x46, 0xc2, 0x95, 0xd7, 0x44, 0x79, 0x8c, 0x79, 0xb2, 0x2c, 0xe5, 0xc8, 0x7d, 0xa6, 0x5a, 0x6e,
+	0x41, 0xa6, 0x77, 0x5e, 0x41, 0xa6, 0x5f, 0x2b, 0xc8, 0xe8, 0x1d, 0x1b, 0xb8, 0x05, 0x46, 0xd3,
+	0xf9, 0xb1, 0x4e, 0x46, 0x6c, 0x90, 0xc8, 0x56, 0xd4, 0x08, 0x8f, 0x0b, 0x25, 0xa6, 0x16, 0x84,
+	0xfe, 0xe9, 0x40, 0x54, 0x0d, 0x6a, 0x91, 0xd5, 0x7f, 0xdb, 0xc4, 0xa1, 0x73, 0x15, 0x85, 0x1f,
+	0x39, 0x30, 0xd7, 0xe9, 0x97, 0x70, 0xae, 0xce, 0x4e, 0x31, 0x97, 0x8e, 0x7c, 0x00, 0x9b, 0xd2,
+	0xf0, 0x9a, 0x9e, 0x83, 0xae, 0x9e, 0x35, 0x42, 0xdc, 0x8d, 0x5c, 0x32, 0x66, 0xa9, 0xad, 0x0d,
+	0x7a, 0xcd, 0xf5, 0x84, 0xbd, 0xfb, 0xeb, 0xb5, 0xdd, 0xdf, 0x01, 0x73, 0x57, 0xaf, 0x4b, 0x65,
+	0xba, 0x8d, 0xda, 0x98, 0xf1, 0xe0, 0xd4, 0xa4, 0xa1, 0xf2, 0x4a, 0xc2, 0x81, 0xe1, 0x05, 0x83,
+	0xc9, 0xc7, 0x0c, 0xa1, 0x2c, 0xa0, 0x35, 0x11, 0x98, 0xac, 0x47, 0x6e, 0xdd, 0x4a, 0x57, 0xb9,
+	0x5b, 0x6b, 0x5a, 0x0d, 0x62, 0xf2, 0x2d, 0x18, 0x65, 0x5a, 0x62, 0xa7, 0x95, 0x6f, 0x53, 0x02,
+	0xcb, 0x0c, 0x12, 0x77, 0x44, 0xa5, 0x76, 0x3c, 0x14, 0x91, 0xd9, 0x88, 0x55, 0x00, 0xfa, 0x5d,
+	0xb8, 0xde, 0x19, 0x3c, 0xb7, 0x99, 0x61, 0x7a, 0x1b, 0x76, 0xba, 0xe3, 0xe1, 0x4a, 0x54, 0x3c,
+	0xdb, 0x78, 0xfc, 0x5d, 0x0f, 0xfc, 0xae, 0x30, 0xf7, 0xff, 0xa9, 0x24, 0xb6, 0x9d, 0xdf, 0xf0,
+	0xab, 0x9e, 0xdf, 0xda, 0x39, 0xe7, 0x47, 0xdf, 0x85, 0x75, 0x05, 0x6c, 0x75, 0x8b, 0x04, 0x06,
+	0x61, 0x50, 0x06, 0x62, 0x47, 0xa6, 0x4c, 0x7c, 0xd3, 0xff, 0xe8, 0xc1, 0x56, 0x8d, 0x03, 0xab,
+	0xe4, 0xef, 0x39, 0x25, 0xff, 0x6b, 0xb0, 0x86, 0x56, 0xbb, 0xba, 0x0a, 0x90, 0x2d, 0x33, 0x57,
+	0xdf, 0x9a, 0xcb, 0xa9, 0x0d, 0x0f, 0xce, 0xaf, 0x0d, 0x0f, 0x5f, 0x53, 0x1b, 0x5e, 0x7b, 0x5d,
+	0x6d, 0x78, 0xbd, 0x59, 0x1b, 0x16, 0x75, 0xf0, 0x38, 0xb6, 0xab, 0xd9, 0xba, 0x2d, 0x1c, 0x44,
+	0xb6, 0xb2, 0x2e, 0x0c, 0x75, 0x13, 0xe7, 0x2d, 0xb2, 0x28, 0x56, 0x7e, 0x52, 0x6a, 0xa4, 0x05,
+	0x71, 0x4d, 0xc6, 0xe4, 0x3c, 0x93, 0x31, 0x75, 0x4d, 0x06, 0x7d, 0x17, 0xae, 0x77, 0xa6, 0x58,
+	0x1d, 0xaa, 0xf0, 0x47, 0x1e, 0x4c, 0xed, 0x9c, 0x48, 0x84, 0x2a, 0x52, 0xd2, 0x1e, 0x57, 0x47,
+	0x6b, 0x83, 0x90, 0x7f, 0x91, 0x37, 0xe5, 0x8f, 0x2b, 0x77, 0x6a, 0x41, 0xa4, 0x51, 0x0c, 0x42,
+	0x9e, 0xef, 0x5b, 0x2f, 0x96, 0x6c, 0xd0, 0xeb, 0xc3, 0x21, 0xfa, 0x05, 0x4c, 0xac, 0xec, 0xee,
+	0x62, 0x4c, 0xc9, 0x19, 0x6c, 0xa6, 0x2a, 0x48, 0x7d, 0xca, 0x7e, 0x73, 0xca, 0xff, 0xec, 0x61,
+	0xd4, 0x6d, 0x55, 0x45, 0xde, 0x83, 0xa9, 0xa5, 0x25, 0x85, 0xef, 0x55, 0xb9, 0x8b, 0x2d, 0xcc,
+	0xbc, 0x64, 0x0e, 0x1d, 0x6e, 0xb4, 0x76, 0xdc, 0xe2, 0xce, 0x45, 0x34, 0x74, 0x5d, 0xae, 0x5f,
+	0xd5, 0xe5, 0xec, 0xf2, 0xd3, 0xe0, 0x22, 0x35, 0x58, 0x02, 0x83, 0x93, 0xb4, 0x28, 0xd5, 0x8d,
+	0xa9, 0xf8, 0x36, 0x49, 0xcc, 0x5a, 0x95, 0xc4, 0x18, 0x55, 0x59, 0x77, 0xd5, 0x92, 0x27, 0x2f,
+	0x0a, 0x7f, 0x24, 0x78, 0x12, 0xdf, 0xa8, 0x6a, 0x8b, 0x34, 0x79, 0x1e, 0x2d, 0xfd, 0xb1, 0x80,
+	0xaa, 0x56, 0x55, 0x2d, 0x03, 0xbb, 0x5a, 0x66, 0xbd, 0x77, 0x9a, 0x38, 0xef, 0x9d, 0xea, 0xb1,
+	0xd7, 0xb4, 0x19, 0x7b, 0x09, 0x91, 0x10, 0x32, 0x1c, 0x1c, 0xc7, 0x5c, 0x59, 0x7d, 0x1b, 0x44,
+	0x3f, 0x80, 0x89, 0x55, 0x32, 0xba, 0x8c, 0x11, 0xa6, 0xff, 0xea, 0xc1, 0xa6, 0x7b, 0x20, 0xe4,
+	0x7b, 0x8d, 0xa3, 0x33, 0x01, 0xa6, 0x45, 0x59, 0x3b, 0xb7, 0x9a, 0xdc, 0xf6, 0x9a, 0x72, 0x5b,
+	0x0f, 0x94, 0xfb, 0x2d, 0x81, 0xf2, 0x2e, 0x4c, 0xa2, 0x42, 0x1a, 0x48, 0x2c, 0x35, 0xcb, 0xfb,
+	0x48, 0x1b, 0x54, 0x17, 0xc5, 0x61, 0x53, 0x14, 0xff, 0x6d, 0x04, 0x13, 0x8b, 0xcf, 0x56, 0x3b,
+	0xfb, 0x13, 0xb8, 0x22, 0x9d, 0x07, 0xfa, 0xbb, 0x03, 0x53, 0x60, 0xef, 0xb5, 0xdf, 0x73, 0x6a,
+	0x02, 0xd6, 0xd6, 0x89, 0x1c, 0xc0, 0xfc, 0xc9, 0xaa, 0x6c, 0xc0, 0xfd, 0xfe, 0x6b, 0x06, 0x9b,
+	0xa7, 0x2d, 0xbd, 0x50, 0x15, 0x65, 0xa0, 0xf2, 0x30, 0x79, 0x74, 0x4f, 0x15, 0xb5, 0x2d, 0x08,
+	0x79, 0x02, 0x57, 0x7f, 0x9a, 0x46, 0xc9, 0x61, 0x90, 0x97, 0x11, 0xf6, 0xe0, 0xe1, 0x51, 0x9a,
+	0x63, 0x30, 0x20, 0x4b, 0x81, 0xd7, 0x71, 0xba, 0x9f, 0xb4, 0x11, 0xb0, 0xf6, 0x7e, 0x58, 0x1d,
+	0x59, 0xa4, 0xf2, 0x6e, 0xb4, 0x31, 0xe6, 0x5a, 0x55, 0x1d, 0xd9, 0xef, 0xa0, 0x61, 0x9d, 0xbd,
+	0xc9, 0x4d, 0x80, 0x2c, 0xca, 0xf8, 0x5e, 0xb1, 0x97, 0x2f, 0x0b, 0x55, 0x2d, 0x14, 0x77, 0x61,
+	0x87, 0x06, 0xca, 0x2c, 0x0a, 0x2c, 0x32, 0x16, 0x8b, 0xa0, 0x2c, 0x79, 0x6e, 0xc6, 0x2a, 0xfc,
+	0x51, 0x55, 0x64, 0x3c, 0xaa, 0x23, 0x59, 0x93, 0x1e, 0x07, 0x59, 0xa4, 0x71, 0xcc, 0x17, 0xa5,
+	0x35, 0xc8, 0xb8, 0x1a, 0x64, 0xbf, 0x8e, 0x64, 0x4d, 0x7a, 0x2c, 0x98, 0xca, 0x93, 0xce, 0xe2,
+	0x48, 0xe4, 0x7e, 0x3c, 0xf7, 0xa1, 0x2a, 0x98, 0x3e, 0xac, 0xe1, 0x58, 0x83, 0x1a, 0xd7, 0x9e,
+	0xa7, 0xab, 0x24, 0x64, 0xe9, 0x71, 0x94, 0xf8, 0x93, 0x6a, 0xed, 0xcc, 0x40, 0x99, 0x45, 0xa1,
+	0xeb, 0xdd, 0xf1, 0xd3, 0x34, 0xf3, 0xa7, 0x6e, 0xbd, 0x1b, 0x61, 0xcc, 0x60, 0xc9, 0xaf, 0xc0,
+	0xf8, 0x38, 0x4f, 0x83, 0x70, 0x11, 0x98, 0xda, 0x9c, 0xb8, 0x24, 0xb9, 0xa7, 0x81, 0xac, 0xc2,
+	0xa3, 0x6c, 0x8a, 0x8e, 0xa8, 0x60, 0x7b, 0x49, 0x88, 0x82, 0xf1, 0x59, 0x54, 0x9e, 0x88, 0x22,
+	0x9d, 0x92, 0xcd, 0x83, 0x16, 0x3c, 0x6b, 0xed, 0x45, 0x28, 0xac, 0x15, 0x8b, 0x3c, 0xca, 0x4a,
+	0x51, 0xce, 0x9b, 0xdc, 0x06, 0x79, 0x2a, 0x08, 0x61, 0x0a, 0x83, 0xec, 0x89, 0xbe, 0x28, 0x03,
+	0xfe, 0xac, 0x62, 0xef, 0x40, 0x03, 0x59, 0x85, 0x27, 0x1f, 0x03, 0x09, 0xc2, 0x20, 0xc3, 0xe7,
+	0x7c, 0xd6, 0x4e, 0xcb, 0xba, 0x9e, 0x28, 0x36, 0xec, 0x35, 0xb0, 0xac, 0xa5, 0x07, 0xc6, 0x8c,
+	0x67, 0x3c, 0x5f, 0x72, 0x29, 0x78, 0x4f, 0x53, 0x55, 0xd6, 0x13, 0x91, 0xdf, 0x23, 0x1b, 0xc1,
+	0x5c, 0x3a, 0x2b, 0x8e, 0xba, 0xd2, 0x11, 0x47, 0xcd, 0xed, 0x38, 0x8a, 0xfe, 0x2a, 0x6c, 0x37,
+	0xa4, 0x10, 0x6d, 0x7b, 0x94, 0x84, 0xfc, 0x4b, 0x2e, 0x4d, 0xe5, 0x90, 0xe9, 0x26, 0x9d, 0x02,
+	0x54, 0xe7, 0x4d, 0xaf, 0xc0, 0x76, 0x43, 0xfa, 0xe8, 0x1d, 0x18, 0x9b, 0xad, 0xc1, 0x30, 0x32,
+	0xcd, 0x43, 0x9e, 0xdf, 0x7b, 0xa5, 0xad, 0xae, 0x08, 0x23, 0x9f, 0x48, 0x18, 0x33, 0x48, 0xba,
+	0x27, 0xdf, 0x05, 0x0b, 0x81, 0x98, 0x82, 0x97, 0xa8, 0x30, 0xd0, 0x4b, 0x9c, 0x21, 0x7a, 0xe7,
+	0x0d, 0xf1, 0x7d, 0xd8, 0x70, 0xb6, 0xe6, 0xe2, 0x93, 0xdf, 0x85, 0x75, 0x05, 0x44, 0x67, 0x27,
+	0xd6, 0xaa, 0xe6, 0x97, 0x0d, 0x84, 0x0a, 0x62, 0x7d, 0x59, 0x2e, 0x1a, 0x78, 0xef, 0x7b, 0xb5,
+	0xd5, 0x32, 0x75, 0x6f, 0x20, 0x5e, 0x5b, 0x45, 0xc5, 0x01, 0x7f, 0x5e, 0x3e, 0x59, 0x95, 0x3c,
+	0xc7, 0xde, 0x2a, 0xa1, 0xaf, 0x83, 0xd1, 0xe7, 0x45, 0x05, 0x8b, 0x96, 0x27, 0x16, 0xa9, 0xbc,
+	0x10, 0x6a, 0xc0, 0xe9, 0x1d, 0xf0, 0xbb, 0xcc, 0xd9, 0x39, 0x87, 0xb9, 0x0b, 0x50, 0x19, 0x2e,
+	0xf4, 0x2a, 0x0b, 0x5d, 0xee, 0x19, 0x33, 0xf1, 0x4d, 0x3f, 0x87, 0x35, 0xa9, 0x0d, 0x28, 0x3f,
+	0x51, 0x81, 0xd4, 0xaa, 0x34, 0xa8, 0x5a, 0xd8, 0x2b, 0x0b, 0xca, 0x13, 0x5d, 0x46, 0xc1, 0x6f,
+	0x84, 0x05, 0xf9, 0x52, 0xfa, 0x8b, 0x31, 0x13, 0xdf, 0x18, 0xef, 0xf0, 0xe4, 0x85, 0x48, 0x60,
+	0xc6, 0x0c, 0x3f, 0xe9, 0x2d, 0x98, 0xd5, 0xcd, 0x8e, 0x89, 0xe0, 0x9f, 0xbe, 0x52, 0x13, 0x8d,
+	0x59, 0x05, 0xa0, 0x9f, 0x03, 0x69, 0xaa, 0x0f, 0xfa, 0x4f, 0xa5, 0x40, 0x76, 0x30, 0x68, 0x81,
+	0xd0, 0x4f, 0x2f, 0xd2, 0x24, 0xe1, 0xc2, 0x7b, 0xaa, 0x4c, 0x62, 0xcc, 0x1c, 0x18, 0x9d, 0xc0,
+	0xd8, 0xd8, 0x1b, 0x7a, 0x0b, 0xe6, 0x6d, 0x46, 0xe4, 0x9c, 0xad, 0xc4, 0xb8, 0xd9, 0xf6, 0x89,
+	0x18, 0x97, 0x7f, 0xac, 0xff, 0x27, 0xf0, 0x6a, 0xff, 0x13, 0xbc, 0x09, 0x63, 0x45, 0x6b, 0xd2,
+	0x9a, 0x71, 0xa8, 0x01, 0x58, 0x65, 0xb5, 0x47, 0x52, 0xef, 0xc9, 0x87, 0x6c, 0x33, 0x74, 0xa0,
+	0xb8, 0x2a, 0xfb, 0x49, 0xb4, 0x3f, 0x68, 0x46, 0x1f, 0xf4, 0xa7, 0xee, 0xeb, 0x25, 0xfb, 0x82,
+	0xf2, 0x71, 0x4b, 0xa6, 0x86, 0x7f, 0x33, 0xf8, 0x3d, 0x37, 0x9c, 0x3c, 0x44, 0xb3, 0xd7, 0xb7,
+	0xc2, 0xc9, 0x8e, 0xc7, 0x55, 0xb7, 0xff, 0x6c, 0x08, 0x93, 0x07, 0x31, 0x0f, 0xce, 0x1e, 0x89,
+	0x7f, 0x4c, 0xc8, 0x87, 0x30, 0x7d, 0xc0, 0xcb, 0xea, 0x6f, 0x0f, 0xe2, 0x44, 0xb3, 0x22, 0x5e,
+	0xdb, 0x99, 0xd7, 0x9e, 0x5e, 0x8a, 0xc7, 0xf1, 0xf4, 0x0d, 0xf2, 0x03, 0xd8, 0x38, 0xe2, 0x49,
+	0x58, 0x3d, 0x65, 0x10, 0x16, 0xd7, 0x34, 0x77, 0xae, 0x3a, 0x4d, 0x53, 0xe5, 0x7f, 0xe3, 0x86,
+	0x77, 0xcb, 0x23, 0x77, 0x30, 0x59, 0x48, 0xf8, 0x4b, 0xf9, 0xda, 0x83, 0x88, 0x22, 0xb3, 0xfd,
+	0xde, 0x63, 0x67, 0xdb, 0x82, 0xe8, 0x9e, 0xe4, 0x3d, 0xd8, 0x60, 0x5c, 0x84, 0xb0, 0x97, 0xeb,
+	0xf7, 0x23, 0x80, 0xea, 0xe9, 0x3b, 0xb9, 0x6a, 0xee, 0x6e, 0xec, 0x37, 0xf8, 0x3b, 0xd7, 0xea,
+	0x60, 0xd3, 0xfd, 0x43, 0x98, 0x3c, 0xe0, 0xa5, 0x7e, 0xb6, 0x4e, 0x44, 0xc8, 0x5f, 0x7b, 0xd8,
+	0xbe, 0x33, 0x77, 0x81, 0x56, 0xdf, 0xb1, 0x79, 0xaf, 0x4b, 0xe6, 0xfa, 0x5a, 0xc6, 0x7e, 0xd2,
+	0xbb, 0x73, 0xb5, 0x06, 0x35, 0x7d, 0xdf, 0x87, 0x91, 0x7e, 0x3b, 0x23, 0x27, 0xad, 0x3d, 0xc0,
+	0xd9, 0x99, 0xbb, 0x40, 0xd3, 0xf1, 0x1e, 0x10, 0x99, 0x49, 0x3a, 0x2f, 0x44, 0x67, 0xfa, 0x45,
+	0x90, 0x86, 0xec, 0xf8, 0x75, 0x88, 0x35, 0xc6, 0x47, 0x30, 0xb1, 0x5e, 0x19, 0x91, 0x6b, 0x9a,
+	0x49, 0xf7, 0xe9, 0xd0, 0xce, 0x37, 0x1a, 0x70, 0x8b, 0x7d, 0xa8, 0x9e, 0x1a, 0x11, 0x7d, 0x63,
+	0x5b, 0xeb, 0xdf, 0x60, 0x8a, 0xbe, 0x71, 0xbc, 0x26, 0x7e, 0x7a, 0xfa, 0xde, 0x7f, 0x0d, 0x00,
+	0x60, 0x2e, 0xa2, 0x2d, 0x0a, 0x35, 0x00, 0x00,
 }
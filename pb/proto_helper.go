@@ -2,6 +2,8 @@ package pb
 
 import (
 	"fmt"
+	"net"
+	"strings"
 )
 
 func (l *Location) URL() string {
@@ -22,6 +24,28 @@ func (a *Location) Distance(b *Location) float64 {
 	return 1
 }
 
+// IsOnHost checks whether the location is on the host. Host names are
+// case insensitive, and a short host name matches its fully qualified name.
+func (l *Location) IsOnHost(host string) bool {
+	server := strings.ToLower(l.Server)
+	host = strings.ToLower(host)
+	if server == host {
+		return true
+	}
+	return shortHostName(server) == shortHostName(host) &&
+		(!strings.Contains(server, ".") || !strings.Contains(host, "."))
+}
+
+func shortHostName(host string) string {
+	if net.ParseIP(host) != nil {
+		return host
+	}
+	if i := strings.Index(host, "."); i >= 0 {
+		return host[:i]
+	}
+	return host
+}
+
 func (a ComputeResource) Minus(b ComputeResource) ComputeResource {
 	return ComputeResource{
 		CpuCount: a.GetCpuCount() - b.GetCpuCount(),
@@ -55,3 +79,18 @@ func (a ComputeResource) IsZero() bool {
 func (a ComputeResource) Covers(b ComputeResource) bool {
 	return a.CpuCount >= b.CpuCount && a.MemoryMb >= b.MemoryMb
 }
+
+// Accommodates also checks the cpu level and the disk space besides Covers,
+// so that a requested resource can run on the available resource.
+func (a ComputeResource) Accommodates(b ComputeResource) bool {
+	return a.Covers(b) && a.CpuLevel >= b.CpuLevel && a.DiskMb >= b.DiskMb
+}
+
+// states of flows and task groups reported by drivers
+const (
+	StatePending   = "pending"
+	StateWaiting   = "waiting" // for resources
+	StateRunning   = "running"
+	StateCompleted = "completed"
+	StateFailed    = "failed"
+)
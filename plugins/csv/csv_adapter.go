@@ -51,6 +51,7 @@ type CsvDataSplit struct {
 	Config    map[string]string
 	FileName  string
 	HasHeader bool
+	Hosts     []string
 }
 
 func (q *Source) GetParallelLimit() int {
@@ -84,3 +85,7 @@ func (c *CsvAdapter) LoadConfiguration(config map[string]string) {
 func (cs CsvDataSplit) GetConfiguration() map[string]string {
 	return cs.Config
 }
+
+func (cs CsvDataSplit) GetPreferredHosts() []string {
+	return cs.Hosts
+}
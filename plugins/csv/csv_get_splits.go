@@ -25,6 +25,7 @@ func (c *CsvAdapter) GetSplits(connectionId string, aq adapter.AdapterQuery) (sp
 		splits = append(splits, &CsvDataSplit{
 			FileName:  s.Path,
 			HasHeader: s.HasHeader,
+			Hosts:     filesystem.Hosts(s.Path),
 		})
 	} else {
 		virtualFiles, err := filesystem.List(s.folder)
@@ -36,6 +37,7 @@ func (c *CsvAdapter) GetSplits(connectionId string, aq adapter.AdapterQuery) (sp
 				splits = append(splits, &CsvDataSplit{
 					FileName:  vf.Location,
 					HasHeader: s.HasHeader,
+					Hosts:     filesystem.Hosts(vf.Location),
 				})
 			}
 		}
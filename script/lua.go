@@ -22,6 +22,8 @@ func NewLuajitScript() Script {
 
 func (c *LuaScript) Init(code string) {
 	c.initCode = `
+-- look up modules shipped with the flow in the working directory first
+package.path = "./?.lua;./?/init.lua;" .. package.path
 local mp = require "MessagePack"
 mp.set_string 'binary'
 local unpack = table.unpack or unpack
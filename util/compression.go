@@ -0,0 +1,197 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+/*
+Compressed Message format:
+  consecutive messages are grouped into blocks of about BUFFER_SIZE bytes.
+  Each block is compressed and written as one message, i.e.,
+    32 bits byte length of the compressed block
+    compressed block, which decompresses to consecutive raw messages
+  The stream still ends with the -1 length EOF message.
+*/
+
+const (
+	CompressionNone   = ""
+	CompressionSnappy = "snappy"
+	CompressionZstd   = "zstd"
+	CompressionLz4    = "lz4"
+)
+
+var (
+	zstdEncoder     *zstd.Encoder
+	zstdDecoder     *zstd.Decoder
+	zstdInitOnce    sync.Once
+	zstdInitFailure error
+)
+
+func initZstd() error {
+	zstdInitOnce.Do(func() {
+		if zstdEncoder, zstdInitFailure = zstd.NewWriter(nil); zstdInitFailure != nil {
+			return
+		}
+		zstdDecoder, zstdInitFailure = zstd.NewReader(nil)
+	})
+	return zstdInitFailure
+}
+
+// ValidateCompression checks whether the compression codec is supported.
+func ValidateCompression(codec string) error {
+	switch codec {
+	case CompressionNone, CompressionSnappy, CompressionZstd, CompressionLz4:
+		return nil
+	}
+	return fmt.Errorf("Unknown compression %q", codec)
+}
+
+func CompressBlock(codec string, block []byte) ([]byte, error) {
+	switch codec {
+	case CompressionNone:
+		return block, nil
+	case CompressionSnappy:
+		return snappy.Encode(nil, block), nil
+	case CompressionZstd:
+		if err := initZstd(); err != nil {
+			return nil, err
+		}
+		return zstdEncoder.EncodeAll(block, nil), nil
+	case CompressionLz4:
+		return lz4Encode(block), nil
+	}
+	return nil, ValidateCompression(codec)
+}
+
+func DecompressBlock(codec string, block []byte) ([]byte, error) {
+	switch codec {
+	case CompressionNone:
+		return block, nil
+	case CompressionSnappy:
+		return snappy.Decode(nil, block)
+	case CompressionZstd:
+		if err := initZstd(); err != nil {
+			return nil, err
+		}
+		return zstdDecoder.DecodeAll(block, nil)
+	case CompressionLz4:
+		return lz4Decode(block)
+	}
+	return nil, ValidateCompression(codec)
+}
+
+// CompressMessages groups the messages from reader into compressed blocks,
+// and ends the output with an EOF message.
+func CompressMessages(codec string, reader io.Reader, writer io.Writer) error {
+	var block bytes.Buffer
+	flush := func() error {
+		if block.Len() == 0 {
+			return nil
+		}
+		compressed, err := CompressBlock(codec, block.Bytes())
+		if err != nil {
+			return fmt.Errorf("Failed to compress %s block: %v", codec, err)
+		}
+		block.Reset()
+		return WriteMessage(writer, compressed)
+	}
+
+	err := ProcessMessage(reader, func(m []byte) error {
+		if err := WriteMessage(&block, m); err != nil {
+			return err
+		}
+		if block.Len() >= BUFFER_SIZE {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err = flush(); err != nil {
+		return err
+	}
+	return WriteEOFMessage(writer)
+}
+
+// DecompressMessages expands the compressed blocks from reader back into raw messages.
+func DecompressMessages(codec string, reader io.Reader, writer io.Writer) error {
+	return ProcessMessage(reader, func(compressed []byte) error {
+		block, err := DecompressBlock(codec, compressed)
+		if err != nil {
+			return fmt.Errorf("Failed to decompress %s block: %v", codec, err)
+		}
+		_, err = writer.Write(block)
+		return err
+	})
+}
+
+// NewCompressingReader reads raw messages from reader and returns them as compressed blocks.
+func NewCompressingReader(codec string, reader io.Reader) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(CompressMessages(codec, reader, pw))
+	}()
+	return pr
+}
+
+// NewDecompressingReader reads compressed blocks from reader and returns them as raw messages.
+func NewDecompressingReader(codec string, reader io.Reader) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		err := DecompressMessages(codec, reader, pw)
+		if err == nil {
+			// drain anything after the EOF message
+			io.Copy(ioutil.Discard, reader)
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+// NewTranscodingReader converts messages compressed with one codec into another codec.
+func NewTranscodingReader(from, to string, reader io.Reader) io.Reader {
+	if from == to {
+		return reader
+	}
+	if from != CompressionNone {
+		reader = NewDecompressingReader(from, reader)
+	}
+	if to != CompressionNone {
+		reader = NewCompressingReader(to, reader)
+	}
+	return reader
+}
+
+type transcodingWriter struct {
+	*io.PipeWriter
+	done chan error
+}
+
+// NewTranscodingWriter converts messages written with one codec into another codec
+// before writing them to writer. Close() waits until all data is written.
+func NewTranscodingWriter(from, to string, writer io.Writer) io.WriteCloser {
+	pr, pw := io.Pipe()
+	tw := &transcodingWriter{
+		PipeWriter: pw,
+		done:       make(chan error, 1),
+	}
+	go func() {
+		_, err := io.Copy(writer, NewTranscodingReader(from, to, pr))
+		pr.CloseWithError(err)
+		tw.done <- err
+	}()
+	return tw
+}
+
+func (tw *transcodingWriter) Close() error {
+	tw.PipeWriter.Close()
+	return <-tw.done
+}
@@ -0,0 +1,163 @@
+package util
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+/*
+LZ4 block format, see https://github.com/lz4/lz4/blob/dev/doc/lz4_Block_format.md
+  a block is a series of sequences, each one
+    token: 4 bits literal length, 4 bits match length - 4
+    more literal length bytes if the literal length is 15
+    literals
+    2 bytes little endian offset of the match
+    more match length bytes if the match length is 19 or more
+  The last sequence has only literals.
+  There is no frame header, since each block is already a length-prefixed message.
+*/
+
+const (
+	lz4MinMatch     = 4
+	lz4MaxOffset    = 65535
+	lz4LastLiterals = 5  // the last bytes are always literals
+	lz4MatchLimit   = 12 // the last match starts at least this many bytes before the end
+	lz4HashLog      = 14
+)
+
+var errCorruptLz4Block = fmt.Errorf("Corrupt lz4 block")
+
+func lz4Hash(v uint32) uint32 {
+	return (v * 2654435761) >> (32 - lz4HashLog)
+}
+
+// lz4Encode compresses the block with a greedy match search.
+func lz4Encode(src []byte) []byte {
+	dst := make([]byte, 0, len(src)+len(src)/255+16)
+	anchor := 0
+	if len(src) > lz4MatchLimit {
+		table := make([]int32, 1<<lz4HashLog) // the last position of each hash, plus 1
+		for i := 0; i <= len(src)-lz4MatchLimit; {
+			seq := binary.LittleEndian.Uint32(src[i:])
+			h := lz4Hash(seq)
+			candidate := int(table[h]) - 1
+			table[h] = int32(i + 1)
+			if candidate < 0 || i-candidate > lz4MaxOffset || binary.LittleEndian.Uint32(src[candidate:]) != seq {
+				i++
+				continue
+			}
+			matchEnd := i + lz4MinMatch
+			for matchEnd < len(src)-lz4LastLiterals && src[matchEnd] == src[candidate+matchEnd-i] {
+				matchEnd++
+			}
+			dst = lz4AppendSequence(dst, src[anchor:i], i-candidate, matchEnd-i)
+			i, anchor = matchEnd, matchEnd
+		}
+	}
+	return lz4AppendSequence(dst, src[anchor:], 0, 0)
+}
+
+// lz4AppendSequence appends the literals, and then the match if matchLength is not 0.
+func lz4AppendSequence(dst, literals []byte, offset, matchLength int) []byte {
+	literalLength := len(literals)
+	matchCode := matchLength - lz4MinMatch
+	token := byte(15 << 4)
+	if literalLength < 15 {
+		token = byte(literalLength << 4)
+	}
+	if matchLength > 0 {
+		if matchCode < 15 {
+			token |= byte(matchCode)
+		} else {
+			token |= 15
+		}
+	}
+	dst = append(dst, token)
+	if literalLength >= 15 {
+		dst = lz4AppendLength(dst, literalLength-15)
+	}
+	dst = append(dst, literals...)
+	if matchLength == 0 {
+		return dst
+	}
+	dst = append(dst, byte(offset), byte(offset>>8))
+	if matchCode >= 15 {
+		dst = lz4AppendLength(dst, matchCode-15)
+	}
+	return dst
+}
+
+func lz4AppendLength(dst []byte, n int) []byte {
+	for ; n >= 255; n -= 255 {
+		dst = append(dst, 255)
+	}
+	return append(dst, byte(n))
+}
+
+// lz4Decode decompresses a block written by lz4Encode, or any other LZ4 block.
+func lz4Decode(src []byte) ([]byte, error) {
+	if len(src) == 0 {
+		return nil, errCorruptLz4Block
+	}
+	dst := make([]byte, 0, 4*len(src))
+	for i := 0; i < len(src); {
+		token := src[i]
+		i++
+
+		literalLength := int(token >> 4)
+		if literalLength == 15 {
+			n, next, err := lz4ReadLength(src, i)
+			if err != nil {
+				return nil, err
+			}
+			literalLength, i = literalLength+n, next
+		}
+		if literalLength > len(src)-i {
+			return nil, errCorruptLz4Block
+		}
+		dst = append(dst, src[i:i+literalLength]...)
+		i += literalLength
+		if i == len(src) {
+			break
+		}
+
+		if i+2 > len(src) {
+			return nil, errCorruptLz4Block
+		}
+		offset := int(src[i]) | int(src[i+1])<<8
+		i += 2
+		if offset == 0 || offset > len(dst) {
+			return nil, errCorruptLz4Block
+		}
+		matchLength := int(token&15) + lz4MinMatch
+		if token&15 == 15 {
+			n, next, err := lz4ReadLength(src, i)
+			if err != nil {
+				return nil, err
+			}
+			matchLength, i = matchLength+n, next
+		}
+		start := len(dst) - offset
+		if offset >= matchLength {
+			dst = append(dst, dst[start:start+matchLength]...)
+			continue
+		}
+		// the match overlaps the bytes it copies, e.g., a repeated byte
+		for k := 0; k < matchLength; k++ {
+			dst = append(dst, dst[start+k])
+		}
+	}
+	return dst, nil
+}
+
+func lz4ReadLength(src []byte, i int) (n, next int, err error) {
+	for i < len(src) {
+		b := src[i]
+		i++
+		n += int(b)
+		if b != 255 {
+			return n, i, nil
+		}
+	}
+	return 0, i, errCorruptLz4Block
+}
@@ -0,0 +1,112 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+
+	var raw bytes.Buffer
+	for i := 0; i < 100000; i++ {
+		WriteMessage(&raw, []byte(fmt.Sprintf("row %d\tsome repeated text", i)))
+	}
+
+	for _, codec := range []string{CompressionSnappy, CompressionLz4, CompressionZstd} {
+		var compressed bytes.Buffer
+		if err := CompressMessages(codec, bytes.NewReader(raw.Bytes()), &compressed); err != nil {
+			t.Fatalf("%s: compress: %v", codec, err)
+		}
+		if compressed.Len() >= raw.Len() {
+			t.Errorf("%s: compressed size %d is not smaller than %d", codec, compressed.Len(), raw.Len())
+		}
+
+		decompressed, err := ioutil.ReadAll(NewDecompressingReader(codec, &compressed))
+		if err != nil {
+			t.Fatalf("%s: decompress: %v", codec, err)
+		}
+		if !bytes.Equal(decompressed, raw.Bytes()) {
+			t.Errorf("%s: decompressed %d bytes, expected %d bytes", codec, len(decompressed), raw.Len())
+		}
+	}
+
+}
+
+func TestTranscodingWriter(t *testing.T) {
+
+	var raw bytes.Buffer
+	for i := 0; i < 1000; i++ {
+		WriteMessage(&raw, []byte(fmt.Sprintf("row %d", i)))
+	}
+
+	var snappyData bytes.Buffer
+	if err := CompressMessages(CompressionSnappy, bytes.NewReader(raw.Bytes()), &snappyData); err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+
+	var zstdData bytes.Buffer
+	w := NewTranscodingWriter(CompressionSnappy, CompressionZstd, &zstdData)
+	w.Write(snappyData.Bytes())
+	if err := w.Close(); err != nil {
+		t.Fatalf("transcode: %v", err)
+	}
+
+	decompressed, err := ioutil.ReadAll(NewDecompressingReader(CompressionZstd, &zstdData))
+	if err != nil {
+		t.Fatalf("decompress: %v", err)
+	}
+	if !bytes.Equal(decompressed, raw.Bytes()) {
+		t.Errorf("transcoded %d bytes, expected %d bytes", len(decompressed), raw.Len())
+	}
+
+	if err := ValidateCompression("lzma"); err == nil {
+		t.Errorf("expected error for unknown compression")
+	}
+}
+
+func TestLz4Block(t *testing.T) {
+
+	// a literal "abc", a match of 9 bytes at offset 3 overlapping itself, and no last literals
+	decoded, err := lz4Decode([]byte{0x35, 'a', 'b', 'c', 0x03, 0x00, 0x00})
+	if err != nil || string(decoded) != "abcabcabcabc" {
+		t.Errorf("expecting abcabcabcabc, got %q: %v", decoded, err)
+	}
+
+	random := make([]byte, 70000)
+	rand.New(rand.NewSource(1)).Read(random)
+	blocks := [][]byte{
+		{},
+		[]byte("short"),
+		bytes.Repeat([]byte{'x'}, 1000), // long overlapping matches
+		bytes.Repeat([]byte("0123456789abcdefghij"), 5000), // long matches over the 64KB offset limit
+		random, // long literals
+		append(append([]byte{}, random[:300]...), random...), // a match of long literals
+	}
+	for _, block := range blocks {
+		compressed := lz4Encode(block)
+		decompressed, err := lz4Decode(compressed)
+		if err != nil {
+			t.Errorf("decode %d bytes: %v", len(block), err)
+			continue
+		}
+		if !bytes.Equal(decompressed, block) {
+			t.Errorf("decoded %d bytes, expected %d bytes", len(decompressed), len(block))
+		}
+	}
+
+	for _, corrupt := range [][]byte{
+		{},
+		{0xf0},                  // missing literal length
+		{0x50, 'a', 'b'},        // literals cut short
+		{0x10, 'a', 0x02, 0x00}, // offset before the start
+		{0x10, 'a', 0x01},       // offset cut short
+		{0x1f, 'a', 0x01, 0x00}, // missing match length
+	} {
+		if _, err := lz4Decode(corrupt); err == nil {
+			t.Errorf("expected error decoding %v", corrupt)
+		}
+	}
+}
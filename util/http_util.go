@@ -35,7 +35,19 @@ func Post(url string, values url.Values) ([]byte, error) {
 }
 
 func Get(url string) ([]byte, error) {
-	r, err := client.Get(url)
+	return GetWithToken(url, "")
+}
+
+// GetWithToken sends the token as "Authorization: Bearer <token>", if not empty.
+func GetWithToken(url, token string) ([]byte, error) {
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	r, err := client.Do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,42 @@
+package util
+
+import (
+	"encoding/binary"
+)
+
+// MessageCounter follows the length prefixed messages in one stream,
+// which can be split anywhere.
+type MessageCounter struct {
+	header    [4]byte
+	headerLen int
+	remaining int64 // bytes left in the current message
+}
+
+// Count returns the number of messages started in the next part of the
+// stream.
+func (c *MessageCounter) Count(p []byte) (messages int64) {
+	for len(p) > 0 {
+		if c.remaining > 0 {
+			n := c.remaining
+			if n > int64(len(p)) {
+				n = int64(len(p))
+			}
+			c.remaining -= n
+			p = p[n:]
+			continue
+		}
+		n := copy(c.header[c.headerLen:], p)
+		c.headerLen += n
+		p = p[n:]
+		if c.headerLen < len(c.header) {
+			break
+		}
+		c.headerLen = 0
+		// a negative length marks the end of the stream
+		if size := int32(binary.LittleEndian.Uint32(c.header[:])); size >= 0 {
+			messages++
+			c.remaining = int64(size)
+		}
+	}
+	return messages
+}
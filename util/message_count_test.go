@@ -0,0 +1,33 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestMessageCounterSplitAnywhere(t *testing.T) {
+
+	var raw bytes.Buffer
+	for i := 0; i < 100; i++ {
+		WriteMessage(&raw, []byte(fmt.Sprintf("row %d", i)))
+	}
+	WriteEOFMessage(&raw)
+
+	for _, chunkSize := range []int{1, 3, 7, raw.Len()} {
+		var c MessageCounter
+		var count int64
+		data := raw.Bytes()
+		for len(data) > 0 {
+			n := chunkSize
+			if n > len(data) {
+				n = len(data)
+			}
+			count += c.Count(data[:n])
+			data = data[n:]
+		}
+		if count != 100 {
+			t.Errorf("chunk size %d: expecting 100 messages, got %d", chunkSize, count)
+		}
+	}
+}
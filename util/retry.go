@@ -5,6 +5,16 @@ import (
 	"time"
 )
 
+// NoRetry wraps an error to stop retrying, when trying again the same way
+// can not succeed. The retry returns the wrapped error.
+func NoRetry(err error) error {
+	return noRetryError{err}
+}
+
+type noRetryError struct {
+	error
+}
+
 func Retry(fn func() error) error {
 	return TimeDelayedRetry(fn, time.Second, 3*time.Second)
 }
@@ -15,6 +25,9 @@ func TimeDelayedRetry(fn func() error, waitTimes ...time.Duration) error {
 	if err == nil {
 		return nil
 	}
+	if e, ok := err.(noRetryError); ok {
+		return e.error
+	}
 
 	log.Printf("Failed due to %v, retrying...", err)
 
@@ -24,6 +37,9 @@ func TimeDelayedRetry(fn func() error, waitTimes ...time.Duration) error {
 		if err == nil {
 			return nil
 		}
+		if e, ok := err.(noRetryError); ok {
+			return e.error
+		}
 		log.Printf("Failed %d time due to %v", i+1, err)
 	}
 
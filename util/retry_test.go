@@ -0,0 +1,28 @@
+package util
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNoRetry(t *testing.T) {
+
+	var calls int
+	failure := fmt.Errorf("allocation is gone")
+	err := TimeDelayedRetry(func() error {
+		calls++
+		return NoRetry(failure)
+	}, 0, 0)
+	if err != failure || calls != 1 {
+		t.Errorf("expecting the wrapped error after 1 call, got %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	TimeDelayedRetry(func() error {
+		calls++
+		return failure
+	}, 0, 0)
+	if calls != 3 {
+		t.Errorf("expecting 3 calls, got %d", calls)
+	}
+}